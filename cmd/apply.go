@@ -5,6 +5,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"slices"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -21,11 +23,14 @@ import (
 	"github.com/sighupio/furyctl/internal/analytics"
 	_ "github.com/sighupio/furyctl/internal/apis/kfd/v1alpha2/ekscluster"
 	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/atrest"
 	"github.com/sighupio/furyctl/internal/cluster"
 	"github.com/sighupio/furyctl/internal/config"
+	"github.com/sighupio/furyctl/internal/dependencies/lock"
 	"github.com/sighupio/furyctl/internal/flags"
 	"github.com/sighupio/furyctl/internal/git"
 	"github.com/sighupio/furyctl/internal/lockfile"
+	"github.com/sighupio/furyctl/internal/parser"
 	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
 	"github.com/sighupio/furyctl/pkg/dependencies"
@@ -38,6 +43,7 @@ const WrappedErrMessage = "%w: %s"
 type Timeouts struct {
 	ProcessTimeout         int
 	PodRunningCheckTimeout int
+	GracePeriod            int
 }
 
 type ClusterSkipsCmdFlags struct {
@@ -65,6 +71,7 @@ type ClusterCmdFlags struct {
 	UpgradePathLocation   string
 	UpgradeNode           string
 	DistroPatchesLocation string
+	Bundle                string
 	PostApplyPhases       []string
 	ClusterSkipsCmdFlags
 }
@@ -121,7 +128,28 @@ func NewApplyCmd() *cobra.Command {
 			logrus.Debugf("Using configuration file from path %s", flags.FuryctlPath)
 
 			// Init first half of collaborators.
-			client := netx.NewGoGetterClient()
+			var client netx.Client = netx.NewGoGetterClient()
+
+			if flags.Bundle != "" {
+				bundleClient, err := netx.NewBundleClient(flags.Bundle)
+				if err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while opening bundle: %w", err)
+				}
+
+				defer bundleClient.Close() //nolint:errcheck // ignore error
+
+				client = bundleClient
+
+				if flags.UpgradePathLocation == "" {
+					if upgradesPath, err := bundleClient.Path(netx.BundleUpgradesSrc); err == nil {
+						flags.UpgradePathLocation = upgradesPath
+					}
+				}
+			}
+
 			executor := execx.NewStdExecutor()
 			depsvl := dependencies.NewValidator(executor, flags.BinPath, flags.FuryctlPath, flags.VpnAutoConnect)
 
@@ -142,6 +170,21 @@ func NewApplyCmd() *cobra.Command {
 				return fmt.Errorf("error while validating requirements: %w", err)
 			}
 
+			// Pin the dependencies locked by 'furyctl download dependencies', if any.
+			var depsLock *lock.Lock
+
+			if _, err := os.Stat(lock.Path(flags.FuryctlPath)); err == nil {
+				depsLock, err = lock.Load(lock.Path(flags.FuryctlPath), false)
+				if err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while loading lock file: %w", err)
+				}
+
+				distrodl.UseLock(depsLock)
+			}
+
 			// Download the distribution.
 			logrus.Info("Downloading distribution...")
 			res, err := distrodl.Download(flags.DistroLocation, flags.FuryctlPath)
@@ -162,8 +205,29 @@ func NewApplyCmd() *cobra.Command {
 			lockFileHandler := lockfile.NewLockFile(res.MinimalConf.Metadata.Name)
 			sigs := make(chan os.Signal, 1)
 
+			ctx, cancel := context.WithCancelCause(context.Background())
+			defer cancel(nil)
+
 			go func() {
-				<-sigs
+				sig := <-sigs
+
+				// The first signal asks the running tools to stop and lets the phases persist their state, a second
+				// one or the end of the grace period forces the exit.
+				logrus.Warnf(
+					"Received %s, stopping the running tools, waiting up to %d seconds. Press Ctrl-C again to force exit.",
+					sig,
+					flags.GracePeriod,
+				)
+
+				cancel(&execx.SignalCause{Signal: sig, Err: cluster.ErrInterrupted})
+
+				select {
+				case <-sigs:
+					logrus.Warn("Forcing exit...")
+
+				case <-time.After(time.Duration(flags.GracePeriod) * time.Second):
+					logrus.Warn("Grace period expired, forcing exit...")
+				}
 
 				if lockFileHandler != nil {
 					logrus.Debugf("Removing lock file %s", lockFileHandler.Path)
@@ -173,6 +237,10 @@ func NewApplyCmd() *cobra.Command {
 					}
 				}
 
+				if err := atrest.Default.Seal(); err != nil {
+					logrus.Error(err)
+				}
+
 				os.Exit(1) //nolint:revive // ignore error
 			}()
 
@@ -199,6 +267,8 @@ func NewApplyCmd() *cobra.Command {
 
 			// Init second half of collaborators.
 			depsdl := dependencies.NewCachingDownloader(client, flags.Outdir, basePath, flags.BinPath, flags.GitProtocol)
+			depsdl.PinChecksums(res.ToolsChecksums)
+			depsdl.UseLock(depsLock)
 
 			// Validate the furyctl.yaml file.
 			logrus.Info("Validating configuration file...")
@@ -209,10 +279,16 @@ func NewApplyCmd() *cobra.Command {
 				return fmt.Errorf("error while validating configuration file: %w", err)
 			}
 
+			// Validate the terraform outputs used by each phase once they are resolved.
+			parser.TfOutputs.SetValidator(func() error {
+				return config.ValidateTfOutputs(flags.FuryctlPath, res.RepoPath)
+			})
+			defer parser.TfOutputs.SetValidator(nil)
+
 			// Download the dependencies.
 			if !flags.SkipDepsDownload {
 				logrus.Info("Downloading dependencies...")
-				if errs, _ := depsdl.DownloadAll(res.DistroManifest); len(errs) > 0 {
+				if errs, _ := depsdl.DownloadAll(ctx, res.DistroManifest); len(errs) > 0 {
 					cmdEvent.AddErrorMessage(ErrDownloadDependenciesFailed)
 					tracker.Track(cmdEvent)
 
@@ -270,6 +346,7 @@ func NewApplyCmd() *cobra.Command {
 			}
 
 			if err := clusterCreator.Create(
+				ctx,
 				flags.StartFrom,
 				flags.Timeouts.ProcessTimeout,
 				flags.PodRunningCheckTimeout,
@@ -328,6 +405,14 @@ func getApplyCmdFlags() (ClusterCmdFlags, error) {
 		}
 	}
 
+	bundle := viper.GetString("bundle")
+	if bundle != "" {
+		bundle, err = filepath.Abs(bundle)
+		if err != nil {
+			return ClusterCmdFlags{}, fmt.Errorf("error while getting absolute path of bundle: %w", err)
+		}
+	}
+
 	furyctlPath := viper.GetString("config")
 
 	if furyctlPath == "" {
@@ -413,12 +498,14 @@ func getApplyCmdFlags() (ClusterCmdFlags, error) {
 		Timeouts: Timeouts{
 			ProcessTimeout:         viper.GetInt("timeout"),
 			PodRunningCheckTimeout: viper.GetInt("pod-running-check-timeout"),
+			GracePeriod:            viper.GetInt("grace-period"),
 		},
 		Outdir:                viper.GetString("outdir"),
 		Upgrade:               upgrade,
 		UpgradePathLocation:   viper.GetString("upgrade-path-location"),
 		UpgradeNode:           upgradeNode,
 		DistroPatchesLocation: distroPatchesLocation,
+		Bundle:                bundle,
 		ClusterSkipsCmdFlags:  skips,
 		PostApplyPhases:       postApplyPhases,
 	}, nil
@@ -490,6 +577,13 @@ func setupApplyCmdFlags(cmd *cobra.Command) {
 			"must have the same structure as the distribution's repository",
 	)
 
+	cmd.Flags().String(
+		"bundle",
+		"",
+		"Path to a bundle created with 'furyctl download bundle'. When set, the distribution and all the "+
+			"dependencies are taken from the bundle instead of being downloaded",
+	)
+
 	cmd.Flags().StringP(
 		"bin-path",
 		"b",
@@ -589,6 +683,12 @@ func setupApplyCmdFlags(cmd *cobra.Command) {
 		"Timeout for the pod running check after the worker nodes upgrade, expressed in seconds",
 	)
 
+	cmd.Flags().Int(
+		"grace-period",
+		300, //nolint:mnd,revive // ignore magic number linters
+		"Time to wait for the running tools to stop after an interrupt before forcing the exit, expressed in seconds",
+	)
+
 	cmd.Flags().Bool(
 		"upgrade",
 		false,
@@ -0,0 +1,25 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/sighupio/furyctl/cmd/cache"
+)
+
+func NewCacheCmd() *cobra.Command {
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect and clean up the cache of the downloaded distributions, modules, installers and tools",
+	}
+
+	cacheCmd.AddCommand(cache.NewListCmd())
+	cacheCmd.AddCommand(cache.NewVerifyCmd())
+	cacheCmd.AddCommand(cache.NewPruneCmd())
+	cacheCmd.AddCommand(cache.NewClearCmd())
+
+	return cacheCmd
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sighupio/furyctl/internal/analytics"
+	"github.com/sighupio/furyctl/internal/app"
+	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
+)
+
+func NewClearCmd() *cobra.Command {
+	var cmdEvent analytics.Event
+
+	clearCmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all the items of the download cache",
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			cmdEvent = analytics.NewCommandEvent(cobrax.GetFullname(cmd))
+
+			if err := viper.BindPFlags(cmd.Flags()); err != nil {
+				logrus.Fatalf("error while binding flags: %v", err)
+			}
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctn := app.GetContainerInstance()
+
+			tracker := ctn.Tracker()
+			tracker.Flush()
+
+			if err := localCache().Clear(); err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while clearing the download cache: %w", err)
+			}
+
+			logrus.Info("Download cache cleared")
+
+			cmdEvent.AddSuccessMessage("download cache cleared")
+			tracker.Track(cmdEvent)
+
+			return nil
+		},
+	}
+
+	return clearCmd
+}
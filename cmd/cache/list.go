@@ -0,0 +1,119 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sighupio/furyctl/internal/analytics"
+	"github.com/sighupio/furyctl/internal/app"
+	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
+	netx "github.com/sighupio/furyctl/pkg/x/net"
+)
+
+const (
+	unknown   = "-"
+	keyLength = 12
+)
+
+func NewListCmd() *cobra.Command {
+	var cmdEvent analytics.Event
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the items of the download cache",
+		Long:  "List the items of the download cache with the URL they have been downloaded from, their size, when they have been used for the last time and the SD versions that use them. Items cached by older versions of furyctl have no URL.",
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			cmdEvent = analytics.NewCommandEvent(cobrax.GetFullname(cmd))
+
+			if err := viper.BindPFlags(cmd.Flags()); err != nil {
+				logrus.Fatalf("error while binding flags: %v", err)
+			}
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctn := app.GetContainerInstance()
+
+			tracker := ctn.Tracker()
+			tracker.Flush()
+
+			entries, err := localCache().List()
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while listing the download cache: %w", err)
+			}
+
+			if len(entries) == 0 {
+				logrus.Info("The download cache is empty")
+			} else {
+				logrus.Info(FormatEntries(entries))
+			}
+
+			cmdEvent.AddSuccessMessage("download cache listed")
+			tracker.Track(cmdEvent)
+
+			return nil
+		},
+	}
+
+	return listCmd
+}
+
+// FormatEntries returns a table describing the given cache entries, followed by their total size.
+func FormatEntries(entries []netx.CacheEntry) string {
+	var (
+		sb    strings.Builder
+		total int64
+	)
+
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0) //nolint:mnd // Padding.
+
+	fmt.Fprintln(w, "\nKEY\tSIZE\tLAST USED\tSD VERSIONS\tURL")
+
+	for _, e := range entries {
+		url := e.URL
+		if url == "" {
+			url = unknown
+		}
+
+		versions := strings.Join(e.Distributions, ",")
+		if versions == "" {
+			versions = unknown
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			shortKey(e.Key),
+			FormatSize(e.Size),
+			e.LastUsed.Local().Format(time.DateTime),
+			versions,
+			url,
+		)
+
+		total += e.Size
+	}
+
+	fmt.Fprintf(w, "\n%d items, %s\n", len(entries), FormatSize(total))
+
+	w.Flush()
+
+	return sb.String()
+}
+
+// shortKey returns the prefix of the key shown to the user, enough to tell the entries apart.
+func shortKey(key string) string {
+	if len(key) <= keyLength {
+		return key
+	}
+
+	return key[:keyLength]
+}
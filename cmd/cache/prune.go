@@ -0,0 +1,224 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sighupio/furyctl/internal/analytics"
+	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/distribution"
+	"github.com/sighupio/furyctl/internal/git"
+	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
+	netx "github.com/sighupio/furyctl/pkg/x/net"
+)
+
+var ErrNoPruneCriteria = errors.New("at least one of --older-than, --max-size or --unreferenced is required")
+
+func NewPruneCmd() *cobra.Command {
+	var cmdEvent analytics.Event
+
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove the items of the download cache that are old, exceed a size budget or are no longer used",
+		Long: "Remove the items of the download cache that have not been used for longer than --older-than, the least recently used " +
+			"ones until the cache fits --max-size, or the ones that are not used by any known SD version with --unreferenced. " +
+			"Known SD versions are the ones supported by this version of furyctl, unless --distribution-version is specified.",
+		Example: `  furyctl cache prune --older-than 720h                 removes the items that have not been used in the last 30 days
+  furyctl cache prune --max-size 10G                    removes the least recently used items until the cache is 10GiB or less
+  furyctl cache prune --unreferenced --dry-run          shows the items that are not used by the supported SD versions
+  furyctl cache prune --unreferenced --distribution-version v1.31.1   removes the items not used by SD v1.31.1
+`,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			cmdEvent = analytics.NewCommandEvent(cobrax.GetFullname(cmd))
+
+			if err := viper.BindPFlags(cmd.Flags()); err != nil {
+				logrus.Fatalf("error while binding flags: %v", err)
+			}
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctn := app.GetContainerInstance()
+
+			tracker := ctn.Tracker()
+			tracker.Flush()
+
+			opts, err := getPruneOptions()
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return err
+			}
+
+			lc := localCache()
+
+			entries, err := lc.List()
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while listing the download cache: %w", err)
+			}
+
+			prunable := netx.SelectPrunable(entries, opts, time.Now())
+			if len(prunable) == 0 {
+				logrus.Info("Nothing to prune")
+
+				cmdEvent.AddSuccessMessage("download cache pruned")
+				tracker.Track(cmdEvent)
+
+				return nil
+			}
+
+			if viper.GetBool("dry-run") {
+				logrus.Info("The following items would be removed:" + FormatEntries(prunable))
+
+				cmdEvent.AddSuccessMessage("download cache prune dry run")
+				tracker.Track(cmdEvent)
+
+				return nil
+			}
+
+			var freed int64
+
+			for _, e := range prunable {
+				if err := lc.Remove(e); err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while removing %s from the download cache: %w", e.Key, err)
+				}
+
+				logrus.Debugf("%s (%s) removed from the download cache", e.Key, e.URL)
+
+				freed += e.Size
+			}
+
+			logrus.Infof("%d items removed from the download cache, %s freed", len(prunable), FormatSize(freed))
+
+			cmdEvent.AddSuccessMessage("download cache pruned")
+			tracker.Track(cmdEvent)
+
+			return nil
+		},
+	}
+
+	pruneCmd.Flags().Duration(
+		"older-than",
+		0,
+		"Remove the items that have not been used for longer than the given duration, e.g. 720h",
+	)
+
+	pruneCmd.Flags().String(
+		"max-size",
+		"",
+		"Remove the least recently used items until the download cache fits the given size, e.g. 10G. Supported suffixes: K, M, G, T",
+	)
+
+	pruneCmd.Flags().Bool(
+		"unreferenced",
+		false,
+		"Remove the items that are not used by any known SD version",
+	)
+
+	pruneCmd.Flags().StringSlice(
+		"distribution-version",
+		[]string{},
+		"SD versions to consider known with --unreferenced, instead of the ones supported by this version of furyctl. Can be specified multiple times",
+	)
+
+	pruneCmd.Flags().Bool(
+		"dry-run",
+		false,
+		"Show the items that would be removed without removing them",
+	)
+
+	return pruneCmd
+}
+
+func getPruneOptions() (netx.PruneOptions, error) {
+	opts := netx.PruneOptions{
+		OlderThan: viper.GetDuration("older-than"),
+	}
+
+	if maxSize := viper.GetString("max-size"); maxSize != "" {
+		size, err := ParseSize(maxSize)
+		if err != nil {
+			return opts, fmt.Errorf("error while parsing --max-size: %w", err)
+		}
+
+		opts.MaxSize = size
+	}
+
+	if viper.GetBool("unreferenced") {
+		versions, err := knownVersions(viper.GetStringSlice("distribution-version"))
+		if err != nil {
+			return opts, err
+		}
+
+		logrus.Debugf("Known SD versions: %s", strings.Join(versions, ", "))
+
+		opts.Keep = KeepReferenced(versions)
+	}
+
+	if opts.OlderThan <= 0 && opts.MaxSize <= 0 && opts.Keep == nil {
+		return opts, ErrNoPruneCriteria
+	}
+
+	return opts, nil
+}
+
+// knownVersions returns the given SD versions or, if none, the ones supported by this version of furyctl.
+func knownVersions(versions []string) ([]string, error) {
+	if len(versions) > 0 {
+		return versions, nil
+	}
+
+	releases, err := distribution.GetSupportedVersions(git.NewGitHubClient())
+	if err != nil {
+		return nil, fmt.Errorf("error getting supported SD versions, use --distribution-version to specify them: %w", err)
+	}
+
+	known := []string{}
+
+	for _, r := range releases {
+		for _, supported := range r.Support {
+			if supported {
+				known = append(known, r.Version.String())
+
+				break
+			}
+		}
+	}
+
+	return known, nil
+}
+
+// KeepReferenced returns a function that keeps the cache entries used by any of the given SD versions. Entries
+// cached by older versions of furyctl are not known to be used by any version.
+func KeepReferenced(versions []string) func(e netx.CacheEntry) bool {
+	known := map[string]bool{}
+
+	for _, v := range versions {
+		known[strings.TrimPrefix(v, "v")] = true
+	}
+
+	return func(e netx.CacheEntry) bool {
+		for _, v := range e.Distributions {
+			if known[strings.TrimPrefix(v, "v")] {
+				return true
+			}
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	netx "github.com/sighupio/furyctl/pkg/x/net"
+)
+
+const sizeUnit = 1024
+
+var (
+	ErrInvalidSize = errors.New("invalid size")
+
+	sizeSuffixes = "KMGT" //nolint:gochecknoglobals // Read only.
+)
+
+// ParseSize parses a size in bytes, optionally followed by one of the K, M, G or T binary suffixes, e.g. 512M.
+func ParseSize(s string) (int64, error) {
+	n := strings.TrimSuffix(strings.TrimSuffix(strings.ToUpper(strings.TrimSpace(s)), "B"), "I")
+
+	multiplier := int64(1)
+
+	if n != "" {
+		if i := strings.IndexByte(sizeSuffixes, n[len(n)-1]); i >= 0 {
+			for range i + 1 {
+				multiplier *= sizeUnit
+			}
+
+			n = n[:len(n)-1]
+		}
+	}
+
+	size, err := strconv.ParseInt(n, 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("%w: '%s'", ErrInvalidSize, s)
+	}
+
+	return size * multiplier, nil
+}
+
+// FormatSize formats a size in bytes with the largest binary suffix that fits it, e.g. 1.5M.
+func FormatSize(size int64) string {
+	if size < sizeUnit {
+		return fmt.Sprintf("%dB", size)
+	}
+
+	f := float64(size)
+	i := -1
+
+	for f >= sizeUnit && i < len(sizeSuffixes)-1 {
+		f /= sizeUnit
+		i++
+	}
+
+	return fmt.Sprintf("%.1f%c", f, sizeSuffixes[i])
+}
+
+// localCache returns the cache used by the downloads of the current output folder.
+func localCache() *netx.LocalCache {
+	return netx.NewLocalCache(filepath.Join(viper.GetString("outdir"), ".furyctl", "cache"))
+}
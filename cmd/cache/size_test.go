@@ -0,0 +1,71 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sighupio/furyctl/cmd/cache"
+	netx "github.com/sighupio/furyctl/pkg/x/net"
+)
+
+func TestParseSize(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "512", want: 512},
+		{in: "1K", want: 1024},
+		{in: "10M", want: 10 * 1024 * 1024},
+		{in: "2Gi", want: 2 * 1024 * 1024 * 1024},
+		{in: "1tb", want: 1024 * 1024 * 1024 * 1024},
+		{in: "", wantErr: true},
+		{in: "-1G", wantErr: true},
+		{in: "1X", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := cache.ParseSize(tc.in)
+			if tc.wantErr {
+				require.ErrorIs(t, err, cache.ErrInvalidSize)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "512B", cache.FormatSize(512))
+	assert.Equal(t, "1.5K", cache.FormatSize(1536))
+	assert.Equal(t, "10.0M", cache.FormatSize(10*1024*1024))
+}
+
+func TestKeepReferenced(t *testing.T) {
+	t.Parallel()
+
+	keep := cache.KeepReferenced([]string{"1.31.0", "v1.30.1"})
+
+	assert.True(t, keep(netx.CacheEntry{CacheMetadata: netx.CacheMetadata{Distributions: []string{"v1.31.0"}}}))
+	assert.True(t, keep(netx.CacheEntry{CacheMetadata: netx.CacheMetadata{Distributions: []string{"1.30.1"}}}))
+	assert.False(t, keep(netx.CacheEntry{CacheMetadata: netx.CacheMetadata{Distributions: []string{"v1.29.0"}}}))
+	assert.False(t, keep(netx.CacheEntry{}))
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sighupio/furyctl/internal/analytics"
+	"github.com/sighupio/furyctl/internal/app"
+	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
+)
+
+var ErrCorruptedCache = errors.New("corrupted items found in the download cache")
+
+func NewVerifyCmd() *cobra.Command {
+	var cmdEvent analytics.Event
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify that the items of the download cache have not changed since they have been downloaded",
+		Long:  "Verify that the items of the download cache have not changed since they have been downloaded. Use the --remove flag to remove the items that fail the verification, they will be downloaded again when needed.",
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			cmdEvent = analytics.NewCommandEvent(cobrax.GetFullname(cmd))
+
+			if err := viper.BindPFlags(cmd.Flags()); err != nil {
+				logrus.Fatalf("error while binding flags: %v", err)
+			}
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctn := app.GetContainerInstance()
+
+			tracker := ctn.Tracker()
+			tracker.Flush()
+
+			remove := viper.GetBool("remove")
+
+			lc := localCache()
+
+			entries, err := lc.List()
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while listing the download cache: %w", err)
+			}
+
+			corrupted := 0
+
+			for _, e := range entries {
+				if err := lc.Verify(e); err != nil {
+					corrupted++
+
+					logrus.Warnf("%s (%s): %v", e.Key, e.URL, err)
+
+					if !remove {
+						continue
+					}
+
+					if err := lc.Remove(e); err != nil {
+						cmdEvent.AddErrorMessage(err)
+						tracker.Track(cmdEvent)
+
+						return fmt.Errorf("error while removing %s from the download cache: %w", e.Key, err)
+					}
+
+					logrus.Infof("%s removed from the download cache", e.Key)
+				}
+			}
+
+			if corrupted > 0 && !remove {
+				err := fmt.Errorf("%w: %d of %d, run again with --remove to delete them", ErrCorruptedCache, corrupted, len(entries))
+
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return err
+			}
+
+			logrus.Infof("%d items verified", len(entries)-corrupted)
+
+			cmdEvent.AddSuccessMessage("download cache verified")
+			tracker.Track(cmdEvent)
+
+			return nil
+		},
+	}
+
+	verifyCmd.Flags().Bool(
+		"remove",
+		false,
+		"Remove the items that fail the verification from the download cache",
+	)
+
+	return verifyCmd
+}
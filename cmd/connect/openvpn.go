@@ -17,6 +17,7 @@ import (
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
 	"github.com/sighupio/furyctl/internal/analytics"
 	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/atrest"
 	"github.com/sighupio/furyctl/internal/flags"
 	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
@@ -100,6 +101,13 @@ func NewOpenVPNCmd() *cobra.Command {
 			basePath := filepath.Join(outDir, ".furyctl", furyctlConf.Metadata.Name)
 			openVPNWorkDir := filepath.Join(basePath, "infrastructure", "terraform", "secrets")
 
+			if err := atrest.Default.Protect(openVPNWorkDir); err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while decrypting vpn certificates: %w", err)
+			}
+
 			executor := execx.NewStdExecutor()
 			openVPNCmd := execx.NewCmd("sudo", execx.CmdOptions{
 				Args:     []string{"openvpn", "--config", fmt.Sprintf("%s-%s.ovpn", furyctlConf.Metadata.Name, flags.Profile)},
@@ -5,51 +5,47 @@
 package create
 
 import (
-	"crypto/x509"
+	"errors"
 	"fmt"
-	"net"
 	"path/filepath"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	certutil "k8s.io/client-go/util/cert"
-	pki "k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
 
 	"github.com/sighupio/furyctl/internal/analytics"
 	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/atrest"
 	"github.com/sighupio/furyctl/internal/clusterpki"
 	"github.com/sighupio/furyctl/internal/flags"
 	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
 )
 
-func NewPki(etcd, controlplane bool, pkiPath string) error {
+var (
+	ErrSameCA = errors.New("etcd and the Kubernetes control plane cannot share the same CA, " +
+		"etcd would accept the client certificates of every Kubernetes component")
+	ErrCommonCAWithoutComponent = errors.New("--ca-cert and --ca-key need either --etcd or --controlplane, " +
+		"use --etcd-ca-cert and --controlplane-ca-cert to import the CAs of both components")
+)
+
+// NewPki creates the PKI in pkiPath, importing etcdCA and controlPlaneCA when set instead of generating new CAs.
+func NewPki(etcd, controlplane bool, pkiPath string, etcdCA, controlPlaneCA *clusterpki.CA) error {
 	var (
 		err  error
 		msg  error
 		data clusterpki.ClusterPKI
-
-		cert = certutil.Config{
-			CommonName:   "SIGHUP s.r.l. Server",
-			Organization: []string{"SIGHUP s.r.l."},
-			AltNames:     certutil.AltNames{DNSNames: []string{}, IPs: []net.IP{}},
-			Usages:       []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
-		}
-		certConfig = pki.CertConfig{
-			Config:              cert,
-			EncryptionAlgorithm: "",
-		}
 	)
 
 	data.Path = pkiPath
-	data.CertConfig = certConfig
+	data.CertConfig = clusterpki.DefaultCertConfig()
 
 	switch {
 	default:
 		logrus.Debug("creating PKI for etcd and Kubernetes control plane")
 
-		etcd := clusterpki.Etcd{ClusterPKI: data}
-		cp := clusterpki.ControlPlanePKI{ClusterPKI: data}
+		etcd := clusterpki.Etcd{ClusterPKI: data, CA: etcdCA}
+		cp := clusterpki.ControlPlanePKI{ClusterPKI: data, CA: controlPlaneCA}
 
 		err = etcd.Create()
 		if err != nil {
@@ -66,7 +62,7 @@ func NewPki(etcd, controlplane bool, pkiPath string) error {
 	case etcd:
 		logrus.Debug("creating PKI for etcd")
 
-		etcd := clusterpki.Etcd{ClusterPKI: data}
+		etcd := clusterpki.Etcd{ClusterPKI: data, CA: etcdCA}
 
 		err = etcd.Create()
 		if err != nil {
@@ -78,7 +74,7 @@ func NewPki(etcd, controlplane bool, pkiPath string) error {
 	case controlplane:
 		logrus.Debug("creating PKI for Kubernetes control plane")
 
-		cp := clusterpki.ControlPlanePKI{ClusterPKI: data}
+		cp := clusterpki.ControlPlanePKI{ClusterPKI: data, CA: controlPlaneCA}
 
 		err := cp.Create()
 		if err != nil {
@@ -96,7 +92,8 @@ func NewPKICmd() *cobra.Command {
 		Use:   "pki",
 		Short: "Creates the Public Key Infrastructure files needed for an on-premises cluster.",
 		Long: `Creates the Public Key Infrastructure files needed (CA, certificates, keys, etc.) by a Kubernetes cluster and its etcd database.
-You can limit the creation of the PKI to just etcd or just Kubernetes using the flags, if not specified the command will create the PKI for both of them.`,
+You can limit the creation of the PKI to just etcd or just Kubernetes using the flags, if not specified the command will create the PKI for both of them.
+Existing CAs, such as intermediates issued by a corporate root, can be imported with the per-component CA flags, or with --ca-cert and --ca-key for the component selected with --etcd or --controlplane, instead of generating self-signed ones.`,
 		PreRun: func(cmd *cobra.Command, _ []string) {
 			cmdEvent = analytics.NewCommandEvent(cobrax.GetFullname(cmd))
 
@@ -128,7 +125,22 @@ You can limit the creation of the PKI to just etcd or just Kubernetes using the
 				return fmt.Errorf("error while getting absolute path for PKI folder path: %w", err)
 			}
 
-			if err := NewPki(etcd, controlplane, pkiPath); err != nil {
+			if err := atrest.Default.Protect(pkiPath); err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while decrypting PKI folder: %w", err)
+			}
+
+			etcdCA, controlPlaneCA, err := loadCAs(etcd, controlplane, time.Now())
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while importing CA: %w", err)
+			}
+
+			if err := NewPki(etcd, controlplane, pkiPath, etcdCA, controlPlaneCA); err != nil {
 				cmdEvent.AddErrorMessage(err)
 
 				return fmt.Errorf("PKI creation failed with error: %w", err)
@@ -170,5 +182,101 @@ You can limit the creation of the PKI to just etcd or just Kubernetes using the
 		"Path to the configuration file",
 	)
 
+	pkiCmd.Flags().String(
+		"ca-cert",
+		"",
+		"path to the PEM encoded certificate of an existing CA to import, optionally followed by its chain, "+
+			"for the component selected with --etcd or --controlplane",
+	)
+
+	pkiCmd.Flags().String(
+		"ca-key",
+		"",
+		"path to the PEM encoded private key of the CA set with --ca-cert",
+	)
+
+	pkiCmd.Flags().String(
+		"etcd-ca-cert",
+		"",
+		"path to the PEM encoded certificate of an existing CA to import for etcd, optionally followed by its chain",
+	)
+
+	pkiCmd.Flags().String(
+		"etcd-ca-key",
+		"",
+		"path to the PEM encoded private key of the CA set with --etcd-ca-cert",
+	)
+
+	pkiCmd.Flags().String(
+		"controlplane-ca-cert",
+		"",
+		"path to the PEM encoded certificate of an existing CA to import for the Kubernetes control plane, "+
+			"optionally followed by its chain",
+	)
+
+	pkiCmd.Flags().String(
+		"controlplane-ca-key",
+		"",
+		"path to the PEM encoded private key of the CA set with --controlplane-ca-cert",
+	)
+
 	return pkiCmd
 }
+
+// loadCAs loads the CAs to import for etcd and the control plane, nil when a new one has to be generated. The common
+// flags are only accepted when a single component is selected, as the components cannot share the same CA, and the
+// flags of the component take precedence over them.
+func loadCAs(etcd, controlplane bool, now time.Time) (*clusterpki.CA, *clusterpki.CA, error) {
+	var etcdCA, controlPlaneCA *clusterpki.CA
+
+	if etcd == controlplane && (viper.GetString("ca-cert") != "" || viper.GetString("ca-key") != "") {
+		return nil, nil, ErrCommonCAWithoutComponent
+	}
+
+	if !controlplane || etcd {
+		ca, err := loadCA("etcd", now)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		etcdCA = ca
+	}
+
+	if !etcd {
+		ca, err := loadCA("controlplane", now)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		controlPlaneCA = ca
+	}
+
+	if etcdCA != nil && controlPlaneCA != nil && etcdCA.Cert.Equal(controlPlaneCA.Cert) {
+		return nil, nil, ErrSameCA
+	}
+
+	return etcdCA, controlPlaneCA, nil
+}
+
+func loadCA(component string, now time.Time) (*clusterpki.CA, error) {
+	certPath := viper.GetString(component + "-ca-cert")
+	keyPath := viper.GetString(component + "-ca-key")
+
+	if certPath == "" && keyPath == "" {
+		certPath = viper.GetString("ca-cert")
+		keyPath = viper.GetString("ca-key")
+	}
+
+	if certPath == "" && keyPath == "" {
+		return nil, nil //nolint:nilnil // No CA to import, a new one is generated.
+	}
+
+	ca, err := clusterpki.LoadCA(certPath, keyPath, now)
+	if err != nil {
+		return nil, fmt.Errorf("error while loading %s CA: %w", component, err)
+	}
+
+	logrus.Infof("Importing %s CA %s", component, ca.Cert.Subject)
+
+	return ca, nil
+}
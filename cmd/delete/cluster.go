@@ -6,6 +6,7 @@ package del
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -20,37 +22,42 @@ import (
 
 	"github.com/sighupio/furyctl/internal/analytics"
 	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/atrest"
 	"github.com/sighupio/furyctl/internal/cluster"
 	"github.com/sighupio/furyctl/internal/config"
 	"github.com/sighupio/furyctl/internal/flags"
 	"github.com/sighupio/furyctl/internal/git"
 	"github.com/sighupio/furyctl/internal/lockfile"
+	"github.com/sighupio/furyctl/internal/state"
 	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
 	iox "github.com/sighupio/furyctl/internal/x/io"
 	"github.com/sighupio/furyctl/pkg/dependencies"
 	dist "github.com/sighupio/furyctl/pkg/distribution"
 	netx "github.com/sighupio/furyctl/pkg/x/net"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
 )
 
 const WrappedErrMessage = "%w: %s"
 
 type ClusterCmdFlags struct {
-	Debug                 bool
-	FuryctlPath           string
-	DistroLocation        string
-	Phase                 string
-	BinPath               string
-	Force                 bool
-	SkipVpn               bool
-	VpnAutoConnect        bool
-	DryRun                bool
-	NoTTY                 bool
-	GitProtocol           git.Protocol
-	Outdir                string
-	SkipDepsDownload      bool
-	SkipDepsValidation    bool
-	DistroPatchesLocation string
+	Debug                   bool
+	FuryctlPath             string
+	DistroLocation          string
+	Phase                   string
+	BinPath                 string
+	Force                   bool
+	AllowUnreachableCluster bool
+	SkipVpn                 bool
+	VpnAutoConnect          bool
+	DryRun                  bool
+	NoTTY                   bool
+	GitProtocol             git.Protocol
+	Outdir                  string
+	SkipDepsDownload        bool
+	SkipDepsValidation      bool
+	DistroPatchesLocation   string
+	Bundle                  string
 }
 
 var (
@@ -102,7 +109,22 @@ func NewClusterCmd() *cobra.Command {
 			var distrodl *dist.Downloader
 
 			// Init first half of collaborators.
-			client := netx.NewGoGetterClient()
+			var client netx.Client = netx.NewGoGetterClient()
+
+			if flags.Bundle != "" {
+				bundleClient, err := netx.NewBundleClient(flags.Bundle)
+				if err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while opening bundle: %w", err)
+				}
+
+				defer bundleClient.Close() //nolint:errcheck // ignore error
+
+				client = bundleClient
+			}
+
 			executor := execx.NewStdExecutor()
 			depsvl := dependencies.NewValidator(executor, flags.BinPath, flags.FuryctlPath, flags.VpnAutoConnect)
 
@@ -155,6 +177,10 @@ func NewClusterCmd() *cobra.Command {
 					}
 				}
 
+				if err := atrest.Default.Seal(); err != nil {
+					logrus.Error(err)
+				}
+
 				os.Exit(1) //nolint:revive // ignore exit code
 			}()
 
@@ -181,6 +207,7 @@ func NewClusterCmd() *cobra.Command {
 
 			// Init second half of collaborators.
 			depsdl := dependencies.NewCachingDownloader(client, outDir, basePath, flags.BinPath, flags.GitProtocol)
+			depsdl.PinChecksums(res.ToolsChecksums)
 
 			// Validate the furyctl.yaml file.
 			logrus.Info("Validating configuration file...")
@@ -194,7 +221,7 @@ func NewClusterCmd() *cobra.Command {
 			// Download the dependencies.
 			if !flags.SkipDepsDownload {
 				logrus.Info("Downloading dependencies...")
-				if errs, _ := depsdl.DownloadAll(res.DistroManifest); len(errs) > 0 {
+				if errs, _ := depsdl.DownloadAll(context.Background(), res.DistroManifest); len(errs) > 0 {
 					cmdEvent.AddErrorMessage(ErrDownloadDependenciesFailed)
 					tracker.Track(cmdEvent)
 
@@ -244,6 +271,73 @@ func NewClusterCmd() *cobra.Command {
 				return fmt.Errorf("error while initializing cluster deleter: %w", err)
 			}
 
+			var deletionPlan *cluster.DeletionPlan
+
+			if flags.DryRun {
+				deletionPlan = cluster.NewDeletionPlan(res.MinimalConf.Metadata.Name, res.MinimalConf.Kind)
+
+				clusterDeleter.SetProperty(cluster.DeleterPropertyDeletionPlan, deletionPlan)
+			}
+
+			if err := checkLocalDeletionProtection(flags.FuryctlPath, res.MinimalConf.Metadata.Name); err != nil {
+				if !flags.DryRun {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return err
+				}
+
+				logrus.Warnf("The cluster would not be deleted: %v", err)
+			}
+
+			// The configuration applied to the cluster is read once the preflight phase has selected its kubeconfig.
+			clusterDeleter.SetProperty(cluster.DeleterPropertyPreDelete, cluster.PreDeleteFunc(func() error {
+				stateStore := state.NewStore(
+					res.RepoPath,
+					flags.FuryctlPath,
+					basePath,
+					res.DistroManifest.Tools.Common.Kubectl.Version,
+					flags.BinPath,
+				)
+
+				if err := checkStoredDeletionProtection(
+					res.MinimalConf.Metadata.Name,
+					stateStore,
+					flags.AllowUnreachableCluster,
+				); err != nil {
+					if !flags.DryRun {
+						return err
+					}
+
+					logrus.Warnf("The cluster would not be deleted: %v", err)
+				}
+
+				if flags.DryRun {
+					return nil
+				}
+
+				logrus.Info("Backing up cluster configuration and state...")
+
+				archive, err := cluster.DeletionBackup{
+					Kind:        res.MinimalConf.Kind,
+					ClusterName: res.MinimalConf.Metadata.Name,
+					ConfigPath:  flags.FuryctlPath,
+					DistroPath:  res.RepoPath,
+					WorkDir:     basePath,
+					BinPath:     flags.BinPath,
+					KFDTools:    res.DistroManifest.Tools,
+					StateStore:  stateStore,
+					AtRest:      atrest.Default,
+				}.Create(time.Now())
+				if err != nil {
+					return fmt.Errorf("error while backing up cluster: %w", err)
+				}
+
+				logrus.Infof("Backup saved to %s", archive)
+
+				return nil
+			}))
+
 			if !flags.Force {
 				_, err = fmt.Println("\nWARNING: You are about to delete a cluster. This action is irreversible.")
 				if err != nil {
@@ -281,6 +375,15 @@ func NewClusterCmd() *cobra.Command {
 				return fmt.Errorf("error while deleting cluster: %w", err)
 			}
 
+			if deletionPlan != nil {
+				if err := printDeletionPlan(deletionPlan, basePath); err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return err
+				}
+			}
+
 			cmdEvent.AddSuccessMessage("Cluster deleted successfully!")
 			tracker.Track(cmdEvent)
 
@@ -316,6 +419,13 @@ func NewClusterCmd() *cobra.Command {
 			"must have the same structure as the distribution's repository",
 	)
 
+	clusterCmd.Flags().String(
+		"bundle",
+		"",
+		"Path to a bundle created with 'furyctl download bundle'. When set, the distribution and all the "+
+			"dependencies are taken from the bundle instead of being downloaded",
+	)
+
 	clusterCmd.Flags().StringP(
 		"bin-path",
 		"b",
@@ -369,6 +479,13 @@ func NewClusterCmd() *cobra.Command {
 		"WARNING: furyctl won't ask for confirmation and will force delete the cluster and its resources.",
 	)
 
+	clusterCmd.Flags().Bool(
+		"allow-unreachable-cluster",
+		false,
+		"Delete the cluster even when the configuration applied to it cannot be read to check its deletion "+
+			"protection, e.g. when it is already partially deleted. The configuration file is still checked",
+	)
+
 	clusterCmd.Flags().Bool(
 		"skip-deps-download",
 		false,
@@ -384,6 +501,74 @@ func NewClusterCmd() *cobra.Command {
 	return clusterCmd
 }
 
+// printDeletionPlan prints the resources that would be deleted as a table and saves them as JSON in the work
+// directory, so that the plan can be reviewed and attached to the change.
+func printDeletionPlan(deletionPlan *cluster.DeletionPlan, basePath string) error {
+	if _, err := fmt.Printf("\nDeletion plan:\n\n%s", deletionPlan.Table()); err != nil {
+		return fmt.Errorf("error while printing to stdout: %w", err)
+	}
+
+	out, err := deletionPlan.JSON()
+	if err != nil {
+		return fmt.Errorf("error while encoding deletion plan: %w", err)
+	}
+
+	planPath := filepath.Join(basePath, fmt.Sprintf("deletion-plan-%d.json", time.Now().Unix()))
+
+	if err := iox.EnsureDir(planPath); err != nil {
+		return fmt.Errorf("error while creating deletion plan directory: %w", err)
+	}
+
+	if err := iox.WriteFile(planPath, out); err != nil {
+		return fmt.Errorf("error while writing deletion plan: %w", err)
+	}
+
+	logrus.Infof("Deletion plan saved to %s", planPath)
+
+	return nil
+}
+
+// checkLocalDeletionProtection reads the deletion protection from the configuration file.
+func checkLocalDeletionProtection(configPath, clusterName string) error {
+	local, err := yamlx.FromFileV3[map[string]any](configPath)
+	if err != nil {
+		return fmt.Errorf("error while reading configuration file: %w", err)
+	}
+
+	if err := cluster.CheckDeletionProtection(local, nil); err != nil {
+		return fmt.Errorf("cluster %s cannot be deleted: %w", clusterName, err)
+	}
+
+	return nil
+}
+
+// checkStoredDeletionProtection reads the deletion protection from the configuration last applied to the cluster. It
+// fails when that configuration cannot be read, unless allowUnreachable is set, so that the protection cannot be
+// bypassed by pointing to another cluster or by turning it off in the local file only.
+func checkStoredDeletionProtection(clusterName string, stateStore state.Storer, allowUnreachable bool) error {
+	_, stored, err := cluster.ReadStoredConfig(stateStore, clusterName)
+	if err != nil {
+		if !allowUnreachable {
+			return fmt.Errorf(
+				"deletion protection of cluster %s cannot be verified, use --allow-unreachable-cluster "+
+					"to delete it anyway: %w",
+				clusterName,
+				err,
+			)
+		}
+
+		logrus.Warnf("Deletion protection of cluster %s not verified: %v", clusterName, err)
+
+		return nil
+	}
+
+	if err := cluster.CheckDeletionProtection(nil, stored); err != nil {
+		return fmt.Errorf("cluster %s cannot be deleted: %w", clusterName, err)
+	}
+
+	return nil
+}
+
 func getDeleteClusterCmdFlags() (ClusterCmdFlags, error) {
 	var err error
 
@@ -409,6 +594,14 @@ func getDeleteClusterCmdFlags() (ClusterCmdFlags, error) {
 		}
 	}
 
+	bundle := viper.GetString("bundle")
+	if bundle != "" {
+		bundle, err = filepath.Abs(bundle)
+		if err != nil {
+			return ClusterCmdFlags{}, fmt.Errorf("error while getting absolute path of bundle: %w", err)
+		}
+	}
+
 	furyctlPath := viper.GetString("config")
 
 	if furyctlPath == "" {
@@ -444,20 +637,22 @@ func getDeleteClusterCmdFlags() (ClusterCmdFlags, error) {
 	}
 
 	return ClusterCmdFlags{
-		Debug:                 viper.GetBool("debug"),
-		FuryctlPath:           furyctlPath,
-		DistroLocation:        viper.GetString("distro-location"),
-		Phase:                 phase,
-		BinPath:               binPath,
-		SkipVpn:               skipVpn,
-		VpnAutoConnect:        vpnAutoConnect,
-		DryRun:                viper.GetBool("dry-run"),
-		Force:                 viper.GetBool("force"),
-		NoTTY:                 viper.GetBool("no-tty"),
-		GitProtocol:           typedGitProtocol,
-		Outdir:                viper.GetString("outdir"),
-		SkipDepsDownload:      viper.GetBool("skip-deps-download"),
-		SkipDepsValidation:    viper.GetBool("skip-deps-validation"),
-		DistroPatchesLocation: distroPatchesLocation,
+		Debug:                   viper.GetBool("debug"),
+		FuryctlPath:             furyctlPath,
+		DistroLocation:          viper.GetString("distro-location"),
+		Phase:                   phase,
+		BinPath:                 binPath,
+		SkipVpn:                 skipVpn,
+		VpnAutoConnect:          vpnAutoConnect,
+		DryRun:                  viper.GetBool("dry-run"),
+		Force:                   viper.GetBool("force"),
+		AllowUnreachableCluster: viper.GetBool("allow-unreachable-cluster"),
+		NoTTY:                   viper.GetBool("no-tty"),
+		GitProtocol:             typedGitProtocol,
+		Outdir:                  viper.GetString("outdir"),
+		SkipDepsDownload:        viper.GetBool("skip-deps-download"),
+		SkipDepsValidation:      viper.GetBool("skip-deps-validation"),
+		DistroPatchesLocation:   distroPatchesLocation,
+		Bundle:                  bundle,
 	}, nil
 }
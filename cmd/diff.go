@@ -40,6 +40,7 @@ type DiffCommandFlags struct {
 	Outdir                string
 	UpgradePathLocation   string
 	DistroPatchesLocation string
+	Bundle                string
 }
 
 func NewDiffCmd() *cobra.Command {
@@ -76,7 +77,27 @@ func NewDiffCmd() *cobra.Command {
 
 			execx.Debug = flags.Debug
 
-			client := netx.NewGoGetterClient()
+			var client netx.Client = netx.NewGoGetterClient()
+
+			if flags.Bundle != "" {
+				bundleClient, err := netx.NewBundleClient(flags.Bundle)
+				if err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while opening bundle: %w", err)
+				}
+
+				defer bundleClient.Close() //nolint:errcheck // ignore error
+
+				client = bundleClient
+
+				if flags.UpgradePathLocation == "" {
+					if upgradesPath, err := bundleClient.Path(netx.BundleUpgradesSrc); err == nil {
+						flags.UpgradePathLocation = upgradesPath
+					}
+				}
+			}
 
 			distrodl := dist.NewDownloader(client, flags.GitProtocol, flags.DistroPatchesLocation)
 
@@ -194,6 +215,13 @@ func NewDiffCmd() *cobra.Command {
 			"must have the same structure as the distribution's repository",
 	)
 
+	diffCmd.Flags().String(
+		"bundle",
+		"",
+		"Path to a bundle created with 'furyctl download bundle'. When set, the distribution and all the "+
+			"dependencies are taken from the bundle instead of being downloaded",
+	)
+
 	diffCmd.Flags().StringP(
 		"bin-path",
 		"b",
@@ -312,6 +340,14 @@ func getDiffCommandFlags() (DiffCommandFlags, error) {
 		}
 	}
 
+	bundle := viper.GetString("bundle")
+	if bundle != "" {
+		bundle, err = filepath.Abs(bundle)
+		if err != nil {
+			return DiffCommandFlags{}, fmt.Errorf("error while getting absolute path of bundle: %w", err)
+		}
+	}
+
 	phase := viper.GetString("phase")
 	if err := cluster.CheckPhase(phase); err != nil {
 		return DiffCommandFlags{}, fmt.Errorf("%w: %s: %s", ErrParsingFlag, "phase", err.Error())
@@ -335,5 +371,6 @@ func getDiffCommandFlags() (DiffCommandFlags, error) {
 		Outdir:                viper.GetString("outdir"),
 		UpgradePathLocation:   viper.GetString("upgrade-path-location"),
 		DistroPatchesLocation: distroPatchesLocation,
+		Bundle:                bundle,
 	}, nil
 }
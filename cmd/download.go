@@ -16,6 +16,7 @@ func NewDownloadCmd() *cobra.Command {
 		Short: "Download all dependencies for the SIGHUP Distribution version specified in the configuration file",
 	}
 
+	downloadCmd.AddCommand(download.NewBundleCmd())
 	downloadCmd.AddCommand(download.NewDependenciesCmd())
 
 	return downloadCmd
@@ -0,0 +1,261 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sighupio/furyctl/internal/analytics"
+	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/dependencies/lock"
+	"github.com/sighupio/furyctl/internal/flags"
+	"github.com/sighupio/furyctl/internal/git"
+	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+	"github.com/sighupio/furyctl/pkg/dependencies"
+	dist "github.com/sighupio/furyctl/pkg/distribution"
+	netx "github.com/sighupio/furyctl/pkg/x/net"
+)
+
+var ErrBundleFailed = errors.New("bundle creation failed")
+
+func NewBundleCmd() *cobra.Command {
+	var cmdEvent analytics.Event
+
+	bundleCmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Download everything needed by the configuration file in a bundle, to be used without internet access",
+		Long: `Download the distribution, the modules, the installers, the tools and the custom upgrade scripts needed
+by the configuration file, and pack them in a single archive. The archive can be moved to a network without internet
+access and passed to the apply, diff, delete and validate commands with the --bundle flag.`,
+		Example: `  furyctl download bundle -o cluster-bundle.tar.zst
+  furyctl apply --bundle cluster-bundle.tar.zst`,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			cmdEvent = analytics.NewCommandEvent(cobrax.GetFullname(cmd))
+
+			// Load and validate flags from configuration FIRST.
+			if err := flags.LoadAndMergeCommandFlags("download"); err != nil {
+				logrus.Fatalf("failed to load flags from configuration: %v", err)
+			}
+
+			if err := viper.BindPFlags(cmd.Flags()); err != nil {
+				logrus.Fatalf("error while binding flags: %v", err)
+			}
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctn := app.GetContainerInstance()
+
+			tracker := ctn.Tracker()
+			tracker.Flush()
+
+			furyctlPath := viper.GetString("config")
+			distroLocation := viper.GetString("distro-location")
+			gitProtocol := viper.GetString("git-protocol")
+			outDir := viper.GetString("outdir")
+			distroPatchesLocation := viper.GetString("distro-patches")
+			upgradePathLocation := viper.GetString("upgrade-path-location")
+			output := viper.GetString("output")
+
+			typedGitProtocol, err := git.NewProtocol(gitProtocol)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %w", ErrParsingFlag, err)
+			}
+
+			absDistroPatchesLocation := distroPatchesLocation
+
+			if absDistroPatchesLocation != "" {
+				absDistroPatchesLocation, err = filepath.Abs(distroPatchesLocation)
+				if err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while getting absolute path of distro patches location: %w", err)
+				}
+			}
+
+			stagingDir, err := os.MkdirTemp("", "furyctl-bundle-")
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while creating temporary folder: %w", err)
+			}
+
+			defer os.RemoveAll(stagingDir)
+
+			workDir, err := os.MkdirTemp("", "furyctl-bundle-work-")
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while creating temporary folder: %w", err)
+			}
+
+			defer os.RemoveAll(workDir)
+
+			recorder := netx.NewBundleRecorder(
+				netx.WithLocalCache(netx.NewGoGetterClient(), filepath.Join(outDir, ".furyctl", "cache")),
+				stagingDir,
+			)
+			executor := execx.NewStdExecutor()
+			depsvl := dependencies.NewValidator(executor, "", furyctlPath, false)
+			distrodl := dist.NewDownloader(recorder, typedGitProtocol, absDistroPatchesLocation)
+
+			// Validate base requirements.
+			if err := depsvl.ValidateBaseReqs(); err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while validating requirements: %w", err)
+			}
+
+			// Bundle the dependencies locked by 'furyctl download dependencies', if any.
+			var depsLock *lock.Lock
+
+			if _, err := os.Stat(lock.Path(furyctlPath)); err == nil {
+				depsLock, err = lock.Load(lock.Path(furyctlPath), false)
+				if err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while loading lock file: %w", err)
+				}
+
+				distrodl.UseLock(depsLock)
+			}
+
+			logrus.Info("Downloading distribution...")
+
+			dres, err := distrodl.Download(distroLocation, furyctlPath)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("failed to download distribution: %w", err)
+			}
+
+			cmdEvent.AddClusterDetails(analytics.ClusterDetails{
+				Provider:   dres.MinimalConf.Kind,
+				KFDVersion: dres.DistroManifest.Version,
+			})
+
+			basePath := filepath.Join(workDir, dres.MinimalConf.Metadata.Name)
+
+			depsdl := dependencies.NewDownloader(recorder, basePath, filepath.Join(workDir, "bin"), typedGitProtocol)
+			depsdl.PinChecksums(dres.ToolsChecksums)
+			depsdl.UseLock(depsLock)
+
+			logrus.Info("Downloading dependencies...")
+
+			errs, uts := depsdl.DownloadAll(context.Background(), dres.DistroManifest)
+
+			for _, ut := range uts {
+				logrus.Warn(fmt.Sprintf("'%s' download is not supported, it must be installed manually in the target environment", ut))
+			}
+
+			if len(errs) > 0 {
+				for _, err := range errs {
+					logrus.Error(err)
+				}
+
+				cmdEvent.AddErrorMessage(ErrDownloadFailed)
+				tracker.Track(cmdEvent)
+
+				return ErrDownloadFailed
+			}
+
+			if upgradePathLocation != "" {
+				logrus.Info("Adding upgrade scripts...")
+
+				absUpgradePathLocation, err := filepath.Abs(upgradePathLocation)
+				if err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while getting absolute path of upgrade path location: %w", err)
+				}
+
+				if err := recorder.Add(netx.BundleUpgradesSrc, absUpgradePathLocation); err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("%w: %w", ErrBundleFailed, err)
+				}
+			}
+
+			logrus.Infof("Writing bundle to %s...", output)
+
+			if err := recorder.Pack(output); err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %w", ErrBundleFailed, err)
+			}
+
+			logrus.Info("Bundle created successfully")
+
+			cmdEvent.AddSuccessMessage("Bundle created successfully")
+			tracker.Track(cmdEvent)
+
+			return nil
+		},
+	}
+
+	bundleCmd.Flags().StringP(
+		"output",
+		"o",
+		"furyctl-bundle.tar.zst",
+		"Path of the bundle to create",
+	)
+
+	bundleCmd.Flags().StringP(
+		"config",
+		"c",
+		"furyctl.yaml",
+		"Path to the configuration file",
+	)
+
+	bundleCmd.Flags().StringP(
+		"distro-location",
+		"",
+		"",
+		"Location where to download schemas, defaults and the distribution manifests from. "+
+			"It can either be a local path (eg: /path/to/distribution) or "+
+			"a remote URL (eg: git::git@github.com:sighupio/distribution?depth=1&ref=BRANCH_NAME). "+
+			"Any format supported by hashicorp/go-getter can be used. "+
+			"The same value must be passed to the commands using the bundle",
+	)
+
+	bundleCmd.Flags().String(
+		"distro-patches",
+		"",
+		"Location where the distribution's user-made patches can be downloaded from. "+
+			"This can be either a local path (eg: /path/to/distro-patches) or "+
+			"a remote URL (eg: git::git@github.com:your-org/distro-patches?depth=1&ref=BRANCH_NAME). "+
+			"Any format supported by hashicorp/go-getter can be used."+
+			" Patches within this location must be in a folder named after the distribution version (eg: v1.29.0) and "+
+			"must have the same structure as the distribution's repository",
+	)
+
+	bundleCmd.Flags().String(
+		"upgrade-path-location",
+		"",
+		"Local folder with custom upgrade scripts to add to the bundle, they are used in place of the embedded ones "+
+			"by the commands using the bundle",
+	)
+
+	return bundleCmd
+}
@@ -5,6 +5,7 @@
 package download
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -15,6 +16,8 @@ import (
 
 	"github.com/sighupio/furyctl/internal/analytics"
 	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/dependencies/lock"
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/flags"
 	"github.com/sighupio/furyctl/internal/git"
 	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
@@ -59,6 +62,8 @@ func NewDependenciesCmd() *cobra.Command {
 			outDir := viper.GetString("outdir")
 			distroPatchesLocation := viper.GetString("distro-patches")
 			binPath := viper.GetString("bin-path")
+			updateLock := viper.GetBool("update-lock")
+			platforms := viper.GetString("platform")
 
 			typedGitProtocol, err := git.NewProtocol(gitProtocol)
 			if err != nil {
@@ -80,6 +85,18 @@ func NewDependenciesCmd() *cobra.Command {
 				}
 			}
 
+			var typedPlatforms []platform.Platform
+
+			if platforms != "" {
+				typedPlatforms, err = platform.ParseList(platforms)
+				if err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("%w: %w", ErrParsingFlag, err)
+				}
+			}
+
 			absDistroPatchesLocation := distroPatchesLocation
 
 			if absDistroPatchesLocation != "" {
@@ -112,6 +129,16 @@ func NewDependenciesCmd() *cobra.Command {
 				return fmt.Errorf("error while validating requirements: %w", err)
 			}
 
+			depsLock, err := lock.Load(lock.Path(furyctlPath), updateLock)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while loading lock file: %w", err)
+			}
+
+			distrodl.UseLock(depsLock)
+
 			dres, err := distrodl.Download(distroLocation, furyctlPath)
 			if err != nil {
 				cmdEvent.AddErrorMessage(err)
@@ -128,10 +155,13 @@ func NewDependenciesCmd() *cobra.Command {
 			basePath := filepath.Join(outDir, ".furyctl", dres.MinimalConf.Metadata.Name)
 
 			depsdl := dependencies.NewCachingDownloader(client, outDir, basePath, binPath, typedGitProtocol)
+			depsdl.PinChecksums(dres.ToolsChecksums)
+			depsdl.UseLock(depsLock)
+			depsdl.UsePlatforms(typedPlatforms)
 
 			logrus.Info("Downloading dependencies...")
 
-			errs, uts := depsdl.DownloadAll(dres.DistroManifest)
+			errs, uts := depsdl.DownloadAll(context.Background(), dres.DistroManifest)
 
 			for _, ut := range uts {
 				logrus.Warn(fmt.Sprintf("'%s' download is not supported, please install it manually if not present", ut))
@@ -150,6 +180,15 @@ func NewDependenciesCmd() *cobra.Command {
 				return ErrDownloadFailed
 			}
 
+			if err := depsLock.Save(); err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while writing lock file: %w", err)
+			}
+
+			logrus.Debugf("Dependencies locked in %s", lock.Path(furyctlPath))
+
 			logrus.Info("Dependencies download succeeded")
 
 			cmdEvent.AddSuccessMessage("Dependencies download succeeded")
@@ -194,5 +233,21 @@ func NewDependenciesCmd() *cobra.Command {
 			"must have the same structure as the distribution's repository",
 	)
 
+	dependenciesCmd.Flags().String(
+		"platform",
+		"",
+		"Comma separated list of platforms to download the tools for, in the <os>/<arch> format "+
+			"(eg: darwin/arm64,linux/amd64). The tools of each platform are downloaded in the <os>_<arch> folder "+
+			"of the bin path, that furyctl uses when it runs on the same platform. Defaults to the current platform, "+
+			"downloaded directly in the bin path",
+	)
+
+	dependenciesCmd.Flags().Bool(
+		"update-lock",
+		false,
+		"Update the furyctl.lock file next to the configuration file with the resolved revisions of the dependencies, "+
+			"instead of failing when they do not match the locked ones",
+	)
+
 	return dependenciesCmd
 }
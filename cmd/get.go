@@ -13,10 +13,11 @@ import (
 func NewGetCmd() *cobra.Command {
 	getCmd := &cobra.Command{
 		Use:   "get",
-		Short: "Get the kubeconfig, available upgrade paths for a cluster or compatible versions to use between SD, providers, furyctl",
+		Short: "Get the kubeconfig, the certificates, upgrade paths for a cluster or compatible versions to use between SD, providers, furyctl",
 	}
 
 	getCmd.AddCommand(get.NewKubeconfigCmd())
+	getCmd.AddCommand(get.NewCertificatesCmd())
 	getCmd.AddCommand(get.NewUpgradePathsCmd())
 	getCmd.AddCommand(get.NewSupportedVersionsCmd())
 
@@ -0,0 +1,371 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package get
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sighupio/furyctl/internal/analytics"
+	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/clusterpki"
+	"github.com/sighupio/furyctl/internal/config"
+	"github.com/sighupio/furyctl/internal/flags"
+	"github.com/sighupio/furyctl/internal/git"
+	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+	"github.com/sighupio/furyctl/pkg/dependencies"
+	dist "github.com/sighupio/furyctl/pkg/distribution"
+	netx "github.com/sighupio/furyctl/pkg/x/net"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	localNode   = "local"
+)
+
+var ErrInvalidOutput = errors.New("invalid value for output flag, valid values are: table, json")
+
+// CertificateReport is a certificate as printed by the get certificates command.
+type CertificateReport struct {
+	clusterpki.Certificate
+	Status string `json:"status"`
+}
+
+func NewCertificatesCmd() *cobra.Command {
+	var cmdEvent analytics.Event
+
+	certificatesCmd := &cobra.Command{
+		Use:   "certificates",
+		Short: "Get the certificates of the cluster's PKI and their expiration",
+		Long: "Get the certificates of the cluster's PKI and their expiration. The certificates in the local PKI folder " +
+			"created with 'furyctl create pki' are inspected, together with the ones on the control plane, etcd and " +
+			"worker nodes for the OnPremises kind, read through the Ansible inventory. Certificates that expire within " +
+			"the days set with --warn-days are reported as EXPIRING.",
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			cmdEvent = analytics.NewCommandEvent(cobrax.GetFullname(cmd))
+
+			// Load and validate flags from configuration FIRST.
+			if err := flags.LoadAndMergeCommandFlags("get"); err != nil {
+				logrus.Fatalf("failed to load flags from configuration: %v", err)
+			}
+
+			if err := viper.BindPFlags(cmd.Flags()); err != nil {
+				logrus.Fatalf("error while binding flags: %v", err)
+			}
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			var err error
+			ctn := app.GetContainerInstance()
+
+			tracker := ctn.Tracker()
+			tracker.Flush()
+
+			// Get flags.
+			binPath := viper.GetString("bin-path")
+			debug := viper.GetBool("debug")
+			distroLocation := viper.GetString("distro-location")
+			furyctlPath := viper.GetString("config")
+			gitProtocol := viper.GetString("git-protocol")
+			outDir := viper.GetString("outdir")
+			skipDepsDownload := viper.GetBool("skip-deps-download")
+			skipDepsValidation := viper.GetBool("skip-deps-validation")
+			skipNodes := viper.GetBool("skip-nodes")
+			warnDays := viper.GetInt("warn-days")
+			output := viper.GetString("output")
+
+			if output != outputTable && output != outputJSON {
+				cmdEvent.AddErrorMessage(ErrInvalidOutput)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %s", ErrInvalidOutput, output)
+			}
+
+			// Get absolute path to the config file.
+			furyctlPath, err = filepath.Abs(furyctlPath)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while getting config directory: %w", err)
+			}
+
+			if binPath == "" {
+				binPath = path.Join(outDir, ".furyctl", "bin")
+			} else {
+				binPath, err = filepath.Abs(binPath)
+				if err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while getting absolute path for bin folder: %w", err)
+				}
+			}
+
+			typedGitProtocol, err := git.NewProtocol(gitProtocol)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %w", ErrParsingFlag, err)
+			}
+
+			// Init packages.
+			execx.Debug = debug
+
+			executor := execx.NewStdExecutor()
+
+			distrodl := &dist.Downloader{}
+			depsvl := dependencies.NewValidator(executor, binPath, furyctlPath, false)
+
+			// Init first half of collaborators.
+			client := netx.NewGoGetterClient()
+
+			if distroLocation == "" {
+				distrodl = dist.NewCachingDownloader(client, outDir, typedGitProtocol, "")
+			} else {
+				distrodl = dist.NewDownloader(client, typedGitProtocol, "")
+			}
+
+			// Validate base requirements.
+			if err := depsvl.ValidateBaseReqs(); err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while validating requirements: %w", err)
+			}
+
+			// Download the distribution.
+			logrus.Info("Downloading distribution...")
+
+			res, err := distrodl.Download(distroLocation, furyctlPath)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while downloading distribution: %w", err)
+			}
+
+			basePath := path.Join(outDir, ".furyctl", res.MinimalConf.Metadata.Name)
+
+			// Init second half of collaborators.
+			depsdl := dependencies.NewCachingDownloader(client, outDir, basePath, binPath, typedGitProtocol)
+			depsdl.PinChecksums(res.ToolsChecksums)
+
+			// Validate the furyctl.yaml file.
+			logrus.Info("Validating configuration file...")
+			if err := config.Validate(furyctlPath, res.RepoPath); err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while validating configuration file: %w", err)
+			}
+
+			// Download the dependencies.
+			if !skipDepsDownload {
+				logrus.Info("Downloading dependencies...")
+				if _, err := depsdl.DownloadTools(res.DistroManifest); err != nil {
+					cmdEvent.AddErrorMessage(ErrDownloadDependenciesFailed)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("%w: %v", ErrDownloadDependenciesFailed, err)
+				}
+			} else {
+				logrus.Info("Dependencies download skipped")
+			}
+
+			// Validate the dependencies, unless explicitly told to skip it.
+			if !skipDepsValidation {
+				logrus.Info("Validating dependencies...")
+				if err := depsvl.Validate(res); err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while validating dependencies: %w", err)
+				}
+			} else {
+				logrus.Info("Dependencies validation skipped")
+			}
+
+			getter, err := cluster.NewCertificatesGetter(res.MinimalConf, res.DistroManifest, res.RepoPath, furyctlPath, skipNodes)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while creating the certificates getter: %w", err)
+			}
+
+			certs, err := getter.Get()
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("error while getting the certificates: %w", err)
+			}
+
+			if output == outputJSON {
+				out, err := FormatCertificatesJSON(certs, warnDays)
+				if err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return err
+				}
+
+				if _, err := fmt.Println(out); err != nil {
+					return fmt.Errorf("error while printing certificates: %w", err)
+				}
+			} else {
+				logrus.Info(FormatCertificates(certs, warnDays))
+			}
+
+			if expiring := countExpiring(certs, warnDays); expiring > 0 {
+				logrus.Warnf("%d certificates are expired or expire within %d days, "+
+					"renew them with 'furyctl renew certificates'", expiring, warnDays)
+			}
+
+			cmdEvent.AddSuccessMessage("certificates successfully retrieved")
+			tracker.Track(cmdEvent)
+
+			return nil
+		},
+	}
+
+	certificatesCmd.Flags().StringP(
+		"bin-path",
+		"b",
+		"",
+		"Path to the folder where all the dependencies' binaries are downloaded",
+	)
+
+	certificatesCmd.Flags().StringP(
+		"config",
+		"c",
+		"furyctl.yaml",
+		"Path to the configuration file",
+	)
+
+	certificatesCmd.Flags().StringP(
+		"distro-location",
+		"",
+		"",
+		"Location where to download schemas, defaults and the distribution manifests from. "+
+			"It can either be a local path (eg: /path/to/distribution) or "+
+			"a remote URL (eg: git::git@github.com:sighupio/distribution?depth=1&ref=BRANCH_NAME). "+
+			"Any format supported by hashicorp/go-getter can be used",
+	)
+
+	certificatesCmd.Flags().Bool(
+		"skip-deps-download",
+		false,
+		"Skip downloading the binaries",
+	)
+
+	certificatesCmd.Flags().Bool(
+		"skip-deps-validation",
+		false,
+		"Skip validating dependencies",
+	)
+
+	certificatesCmd.Flags().Bool(
+		"skip-nodes",
+		false,
+		"Only inspect the local PKI folder, without reading the certificates from the nodes",
+	)
+
+	certificatesCmd.Flags().Int(
+		"warn-days",
+		flags.DefaultCertificatesWarnDays,
+		"Report the certificates that expire within this number of days as EXPIRING",
+	)
+
+	certificatesCmd.Flags().StringP(
+		"output",
+		"o",
+		outputTable,
+		"Output format, valid values are: table, json",
+	)
+
+	return certificatesCmd
+}
+
+// FormatCertificates returns a table describing the given certificates.
+func FormatCertificates(certs []clusterpki.Certificate, warnDays int) string {
+	var sb strings.Builder
+
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0) //nolint:mnd // Padding.
+
+	fmt.Fprintln(w, "\nNODE\tPATH\tSUBJECT\tISSUER\tSANS\tNOT AFTER\tDAYS LEFT\tSTATUS")
+
+	for _, c := range certs {
+		node := c.Node
+		if node == "" {
+			node = localNode
+		}
+
+		sans := strings.Join(c.SANs, ",")
+		if sans == "" {
+			sans = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			node,
+			c.Path,
+			c.Subject,
+			c.Issuer,
+			sans,
+			c.NotAfter.Local().Format(time.DateTime),
+			c.DaysRemaining,
+			c.Status(warnDays),
+		)
+	}
+
+	w.Flush()
+
+	return sb.String()
+}
+
+// FormatCertificatesJSON returns the given certificates as JSON, with their status, to be consumed by monitoring tools.
+func FormatCertificatesJSON(certs []clusterpki.Certificate, warnDays int) (string, error) {
+	reports := make([]CertificateReport, 0, len(certs))
+
+	for _, c := range certs {
+		reports = append(reports, CertificateReport{
+			Certificate: c,
+			Status:      c.Status(warnDays),
+		})
+	}
+
+	out, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error while marshalling certificates: %w", err)
+	}
+
+	return string(out), nil
+}
+
+func countExpiring(certs []clusterpki.Certificate, warnDays int) int {
+	count := 0
+
+	for _, c := range certs {
+		if c.Status(warnDays) != clusterpki.CertificateStatusOK {
+			count++
+		}
+	}
+
+	return count
+}
@@ -7,13 +7,18 @@ package get
 import (
 	"errors"
 	"fmt"
+	"os"
 	"path"
 	"path/filepath"
+	"strings"
+	"text/template"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/client-go/tools/clientcmd"
 
+	distroconf "github.com/sighupio/fury-distribution/pkg/apis/config"
 	"github.com/sighupio/furyctl/internal/analytics"
 	"github.com/sighupio/furyctl/internal/app"
 	"github.com/sighupio/furyctl/internal/cluster"
@@ -22,6 +27,7 @@ import (
 	"github.com/sighupio/furyctl/internal/git"
 	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
+	kubex "github.com/sighupio/furyctl/internal/x/kube"
 	"github.com/sighupio/furyctl/pkg/dependencies"
 	dist "github.com/sighupio/furyctl/pkg/distribution"
 	netx "github.com/sighupio/furyctl/pkg/x/net"
@@ -67,6 +73,9 @@ func NewKubeconfigCmd() *cobra.Command {
 			outDir := viper.GetString("outdir")
 			skipDepsDownload := viper.GetBool("skip-deps-download")
 			skipDepsValidation := viper.GetBool("skip-deps-validation")
+			merge := viper.GetBool("merge")
+			contextNameTpl := viper.GetString("context-name")
+			setCurrentContext := viper.GetBool("set-current-context")
 
 			// Get absolute path to the config file.
 			furyctlPath, err = filepath.Abs(furyctlPath)
@@ -137,6 +146,7 @@ func NewKubeconfigCmd() *cobra.Command {
 
 			// Init second half of collaborators.
 			depsdl := dependencies.NewCachingDownloader(client, outDir, basePath, binPath, typedGitProtocol)
+			depsdl.PinChecksums(res.ToolsChecksums)
 
 			// Validate the furyctl.yaml file.
 			logrus.Info("Validating configuration file...")
@@ -190,6 +200,20 @@ func NewKubeconfigCmd() *cobra.Command {
 
 			logrus.Infof("Kubeconfig successfully retrieved, you can find it at: %s", path.Join(currentDir, "kubeconfig"))
 
+			if merge {
+				if err := mergeKubeconfig(
+					path.Join(currentDir, "kubeconfig"),
+					contextNameTpl,
+					res.MinimalConf,
+					setCurrentContext,
+				); err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return err
+				}
+			}
+
 			cmdEvent.AddSuccessMessage("kubeconfig successfully retrieved")
 			tracker.Track(cmdEvent)
 
@@ -233,5 +257,71 @@ func NewKubeconfigCmd() *cobra.Command {
 		"Skip validating dependencies",
 	)
 
+	kubeconfigCmd.Flags().Bool(
+		"merge",
+		false,
+		"Merge the kubeconfig into the first file of the KUBECONFIG environment variable, or ~/.kube/config, "+
+			"backing it up first",
+	)
+
+	kubeconfigCmd.Flags().String(
+		"context-name",
+		"{{.Name}}",
+		"Name of the cluster, user and context merged with --merge, as a Go template. "+
+			"Available fields: .Name (the cluster name), .Kind and .Context (the context of the retrieved kubeconfig)",
+	)
+
+	kubeconfigCmd.Flags().Bool(
+		"set-current-context",
+		false,
+		"Set the merged context as the current one",
+	)
+
 	return kubeconfigCmd
 }
+
+// mergeKubeconfig merges the kubeconfig at kubeconfigPath into the default kubeconfig of the user, naming its entries
+// after contextNameTpl.
+func mergeKubeconfig(kubeconfigPath, contextNameTpl string, minimalConf distroconf.Furyctl, setCurrent bool) error {
+	kubeconfig, err := os.ReadFile(kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("error while reading kubeconfig: %w", err)
+	}
+
+	srcContext := ""
+	if src, err := clientcmd.Load(kubeconfig); err == nil {
+		srcContext = src.CurrentContext
+	}
+
+	tpl, err := template.New("context-name").Option("missingkey=error").Parse(contextNameTpl)
+	if err != nil {
+		return fmt.Errorf("%w context-name: %w", ErrParsingFlag, err)
+	}
+
+	var name strings.Builder
+
+	if err := tpl.Execute(&name, map[string]string{
+		"Name":    minimalConf.Metadata.Name,
+		"Kind":    minimalConf.Kind,
+		"Context": srcContext,
+	}); err != nil {
+		return fmt.Errorf("%w context-name: %w", ErrParsingFlag, err)
+	}
+
+	merged, err := kubex.MergeKubeconfig(kubeconfig, kubex.DefaultKubeconfigPath(), name.String(), setCurrent)
+	if err != nil {
+		return fmt.Errorf("error while merging kubeconfig: %w", err)
+	}
+
+	if merged.BackupPath != "" {
+		logrus.Infof("Previous kubeconfig backed up at %s", merged.BackupPath)
+	}
+
+	if merged.Replaced {
+		logrus.Warnf("Entries named %s already existed in %s and have been replaced", name.String(), merged.Path)
+	}
+
+	logrus.Infof("Kubeconfig merged into %s as context %s", merged.Path, name.String())
+
+	return nil
+}
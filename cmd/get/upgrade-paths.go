@@ -157,6 +157,7 @@ func NewUpgradePathsCmd() *cobra.Command {
 
 				// Init second half of collaborators.
 				depsdl := dependencies.NewCachingDownloader(client, outDir, basePath, binPath, typedGitProtocol)
+				depsdl.PinChecksums(res.ToolsChecksums)
 
 				// Validate the furyctl.yaml file.
 				logrus.Info("Validating configuration file...")
@@ -16,6 +16,7 @@ func NewLegacyCmd() *cobra.Command {
 		Short: "Legacy commands for compatibility with older versions of furyctl",
 	}
 
+	legacyCmd.AddCommand(legacy.NewMigrateCmd())
 	legacyCmd.AddCommand(legacy.NewVendorCmd())
 
 	return legacyCmd
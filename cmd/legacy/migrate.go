@@ -0,0 +1,341 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package legacy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	distroconf "github.com/sighupio/fury-distribution/pkg/apis/config"
+	"github.com/sighupio/furyctl/internal/analytics"
+	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/config"
+	"github.com/sighupio/furyctl/internal/distribution"
+	"github.com/sighupio/furyctl/internal/git"
+	"github.com/sighupio/furyctl/internal/legacy"
+	"github.com/sighupio/furyctl/internal/semver"
+	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
+	dist "github.com/sighupio/furyctl/pkg/distribution"
+	netx "github.com/sighupio/furyctl/pkg/x/net"
+)
+
+const migrationAPIVersion = "kfd.sighup.io/v1alpha2"
+
+var (
+	ErrConfigExists       = errors.New("a configuration file already exists")
+	ErrMigrationFailed    = errors.New("error while migrating the Furyfile")
+	ErrNoSupportedVersion = errors.New("no supported KFDDistribution version found")
+)
+
+type MigrateCmdFlags struct {
+	FuryFilePath        string
+	ProjectDir          string
+	FuryctlPath         string
+	Name                string
+	DistributionVersion string
+	DistroLocation      string
+	GitProtocol         git.Protocol
+	Outdir              string
+}
+
+func NewMigrateCmd() *cobra.Command {
+	var cmdEvent analytics.Event
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Generate a KFDDistribution configuration file from a Furyfile and its kustomize project",
+		Long: "Generate a best-effort KFDDistribution configuration file from the packages of a Furyfile and the " +
+			"kustomize project deploying them. The distribution version is the supported one whose module versions " +
+			"are the nearest to the vendored ones, unless one is given. Everything that cannot be mapped is reported " +
+			"and needs manual attention.",
+		Example: `  furyctl legacy migrate                                    generates furyctl.yaml from Furyfile.yaml and the kustomize project in the current folder
+  furyctl legacy migrate --distribution-version v1.31.0    generates furyctl.yaml for the given distribution version
+`,
+		Args: cobra.NoArgs,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			cmdEvent = analytics.NewCommandEvent(cobrax.GetFullname(cmd))
+
+			if err := viper.BindPFlags(cmd.Flags()); err != nil {
+				logrus.Fatalf("error while binding flags: %v", err)
+			}
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctn := app.GetContainerInstance()
+
+			tracker := ctn.Tracker()
+			tracker.Flush()
+
+			flags, err := getLegacyMigrateCmdFlags()
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return err
+			}
+
+			if _, err := os.Stat(flags.FuryctlPath); err == nil {
+				cmdEvent.AddErrorMessage(ErrConfigExists)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %s, please remove it and try again", ErrConfigExists, flags.FuryctlPath)
+			}
+
+			ff, err := legacy.NewFuryFile(flags.FuryFilePath)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %v", ErrParsingFuryFile, err)
+			}
+
+			pkgs, err := ff.BuildPackages("")
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %v", ErrParsingPackages, err)
+			}
+
+			project, err := legacy.ScanKustomizeProject(flags.ProjectDir, ff.VendorFolderName)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %w", ErrMigrationFailed, err)
+			}
+
+			used, unused := project.UsedPackages(pkgs, ff.VendorFolderName)
+
+			modules, notes := legacy.ModuleVersions(used)
+			notes = append(notes, legacy.ProjectNotes(project, unused)...)
+
+			distrodl := newMigrateDownloader(flags)
+
+			logrus.Info("Looking for the nearest distribution version...")
+
+			candidates, results, err := downloadCandidates(distrodl, flags)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %w", ErrMigrationFailed, err)
+			}
+
+			nearest, err := legacy.NearestDistribution(modules, candidates)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %w", ErrMigrationFailed, err)
+			}
+
+			migration := legacy.Migrate(used, modules, nearest)
+			notes = append(notes, migration.Notes...)
+
+			res := results[nearest.Version]
+
+			out, err := config.Create(res, flags.FuryctlPath, cmdEvent, tracker, map[string]string{
+				"Kind":                distribution.KFDDistributionKind,
+				"Name":                flags.Name,
+				"DistributionVersion": nearest.Version,
+			})
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %w", ErrMigrationFailed, err)
+			}
+
+			if err := out.Close(); err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %w", ErrMigrationFailed, err)
+			}
+
+			if err := legacy.SetModuleFields(flags.FuryctlPath, migration.Fields); err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %w", ErrMigrationFailed, err)
+			}
+
+			if err := config.Validate(flags.FuryctlPath, res.RepoPath); err != nil {
+				notes = append(notes, fmt.Sprintf("the configuration file is not valid yet: %v", err))
+			}
+
+			logrus.Infof(
+				"Configuration file for distribution %s created at: %s",
+				nearest.Version,
+				flags.FuryctlPath,
+			)
+
+			if len(notes) > 0 {
+				logrus.Warn("The following items need manual attention:")
+
+				for _, n := range notes {
+					logrus.Warn("- " + n)
+				}
+			}
+
+			cmdEvent.AddSuccessMessage("Furyfile migrated to " + flags.FuryctlPath)
+			tracker.Track(cmdEvent)
+
+			return nil
+		},
+	}
+
+	migrateCmd.Flags().StringP(
+		"furyfile",
+		"F",
+		"Furyfile.yaml",
+		"Path to the Furyfile.yaml file",
+	)
+
+	migrateCmd.Flags().String(
+		"project-dir",
+		"",
+		"Path to the kustomize project deploying the vendored packages, defaults to the folder of the Furyfile",
+	)
+
+	migrateCmd.Flags().StringP(
+		"config",
+		"c",
+		"furyctl.yaml",
+		"Path to the configuration file to generate",
+	)
+
+	migrateCmd.Flags().StringP(
+		"name",
+		"n",
+		"example",
+		"Name of the cluster",
+	)
+
+	migrateCmd.Flags().String(
+		"distribution-version",
+		"",
+		"SIGHUP Distribution version to migrate to (eg: v1.31.0), defaults to the supported version whose modules "+
+			"are the nearest to the vendored ones",
+	)
+
+	migrateCmd.Flags().String(
+		"distro-location",
+		"",
+		"Location where to download schemas, defaults and the distribution manifests from. "+
+			"It can either be a local path (eg: /path/to/distribution) or "+
+			"a remote URL (eg: git::git@github.com:sighupio/distribution?depth=1&ref=BRANCH_NAME). "+
+			"Any format supported by hashicorp/go-getter can be used. Requires --distribution-version",
+	)
+
+	return migrateCmd
+}
+
+func getLegacyMigrateCmdFlags() (MigrateCmdFlags, error) {
+	typedGitProtocol, err := git.NewProtocol(viper.GetString("git-protocol"))
+	if err != nil {
+		return MigrateCmdFlags{}, fmt.Errorf("%w: %w", ErrParsingFlag, err)
+	}
+
+	flags := MigrateCmdFlags{
+		FuryFilePath:        viper.GetString("furyfile"),
+		ProjectDir:          viper.GetString("project-dir"),
+		FuryctlPath:         viper.GetString("config"),
+		Name:                viper.GetString("name"),
+		DistributionVersion: viper.GetString("distribution-version"),
+		DistroLocation:      viper.GetString("distro-location"),
+		GitProtocol:         typedGitProtocol,
+		Outdir:              viper.GetString("outdir"),
+	}
+
+	if flags.DistroLocation != "" && flags.DistributionVersion == "" {
+		return MigrateCmdFlags{}, fmt.Errorf("%w: --distro-location requires --distribution-version", ErrParsingFlag)
+	}
+
+	if flags.ProjectDir == "" {
+		flags.ProjectDir = filepath.Dir(flags.FuryFilePath)
+	}
+
+	if flags.FuryctlPath, err = filepath.Abs(flags.FuryctlPath); err != nil {
+		return MigrateCmdFlags{}, fmt.Errorf("%w: config: %w", ErrParsingFlag, err)
+	}
+
+	return flags, nil
+}
+
+func newMigrateDownloader(flags MigrateCmdFlags) *dist.Downloader {
+	client := netx.NewGoGetterClient()
+
+	if flags.DistroLocation == "" {
+		return dist.NewCachingDownloader(client, flags.Outdir, flags.GitProtocol, "")
+	}
+
+	return dist.NewDownloader(client, flags.GitProtocol, "")
+}
+
+// downloadCandidates downloads the distribution versions to compare the modules with: the given one, or the latest
+// patch of every supported minor version, from the newest.
+func downloadCandidates(
+	distrodl *dist.Downloader,
+	flags MigrateCmdFlags,
+) ([]legacy.DistributionCandidate, map[string]dist.DownloadResult, error) {
+	versions := []string{}
+
+	if flags.DistributionVersion != "" {
+		versions = append(versions, semver.EnsurePrefix(flags.DistributionVersion))
+	} else {
+		releases, err := distribution.GetSupportedVersions(git.NewGitHubClient())
+		if err != nil {
+			return nil, nil, fmt.Errorf("error while getting the supported versions: %w", err)
+		}
+
+		minors := map[int]bool{}
+
+		for _, r := range releases {
+			if !r.Support[distribution.KFDDistributionKind] || minors[r.Version.Segments()[1]] {
+				continue
+			}
+
+			minors[r.Version.Segments()[1]] = true
+
+			versions = append(versions, "v"+r.Version.String())
+		}
+	}
+
+	if len(versions) == 0 {
+		return nil, nil, ErrNoSupportedVersion
+	}
+
+	candidates := make([]legacy.DistributionCandidate, 0, len(versions))
+	results := map[string]dist.DownloadResult{}
+
+	for _, v := range versions {
+		logrus.Debugf("Downloading distribution %s...", v)
+
+		res, err := distrodl.DoDownload(flags.DistroLocation, distroconf.Furyctl{
+			APIVersion: migrationAPIVersion,
+			Kind:       distribution.KFDDistributionKind,
+			Metadata:   distroconf.FuryctlMeta{Name: flags.Name},
+			Spec:       distroconf.FuryctlSpec{DistributionVersion: v},
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("error while downloading distribution %s: %w", v, err)
+		}
+
+		candidates = append(candidates, legacy.DistributionCandidate{
+			Version: v,
+			Modules: legacy.KFDModuleVersions(res.DistroManifest),
+		})
+		results[v] = res
+	}
+
+	return candidates, results, nil
+}
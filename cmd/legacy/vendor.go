@@ -16,6 +16,7 @@ import (
 
 	"github.com/sighupio/furyctl/internal/analytics"
 	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/dependencies/lock"
 	"github.com/sighupio/furyctl/internal/legacy"
 	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
 )
@@ -24,21 +25,35 @@ type VendorCmdFlags struct {
 	FuryFilePath string
 	Prefix       string
 	GitProtocol  string
+	Frozen       bool
+	Update       bool
+	Packages     []string
 }
 
+const shortCommitLength = 12
+
 var (
 	ErrParsingFlag     = errors.New("error while parsing flag")
 	ErrParsingFuryFile = errors.New("error while parsing furyfile")
 	ErrParsingPackages = errors.New("error while parsing packages")
 	ErrDownloading     = errors.New("error while downloading")
+	ErrLockFile        = errors.New("error while using the lock file")
 )
 
 func NewVendorCmd() *cobra.Command {
 	var cmdEvent analytics.Event
 
 	vendorCmd := &cobra.Command{
-		Use:   "vendor",
+		Use:   "vendor [packages...]",
 		Short: "Download the dependencies specified in the Furyfile.yml",
+		Long: "Download the dependencies specified in the Furyfile.yml, pinned to the commits recorded in the " +
+			legacy.LockFileName + " file next to it. The packages that are not in the lock file yet, or whose version " +
+			"has changed, are resolved and added to it.",
+		Example: `  furyctl legacy vendor                             downloads the packages and records the new ones in the lock file
+  furyctl legacy vendor --frozen                    downloads the packages, failing if any does not match the lock file
+  furyctl legacy vendor --update                    resolves the versions of all the packages again and updates the lock file
+  furyctl legacy vendor --update monitoring         resolves the versions of the monitoring packages again
+`,
 		PreRun: func(cmd *cobra.Command, _ []string) {
 			cmdEvent = analytics.NewCommandEvent(cobrax.GetFullname(cmd))
 
@@ -46,13 +61,19 @@ func NewVendorCmd() *cobra.Command {
 				logrus.Fatalf("error while binding flags: %v", err)
 			}
 		},
-		RunE: func(_ *cobra.Command, _ []string) error {
+		RunE: func(_ *cobra.Command, args []string) error {
 			ctn := app.GetContainerInstance()
 
 			tracker := ctn.Tracker()
 			tracker.Flush()
 
-			flags := getLegacyVendorCmdFlags()
+			flags, err := getLegacyVendorCmdFlags(args)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return err
+			}
 
 			ff, err := legacy.NewFuryFile(flags.FuryFilePath)
 			if err != nil {
@@ -86,7 +107,26 @@ func NewVendorCmd() *cobra.Command {
 				}
 			}
 
+			pkgsLock, err := lock.Load(legacy.LockPath(flags.FuryFilePath), flags.Update)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %w", ErrLockFile, err)
+			}
+
+			if flags.Frozen {
+				pkgsLock.Freeze()
+			}
+
+			if len(flags.Packages) > 0 {
+				pkgsLock.UpdateOnly(func(_ lock.Section, name string) bool {
+					return matchesAny(name, flags.Packages)
+				})
+			}
+
 			downloader := legacy.NewDownloader(flags.GitProtocol)
+			downloader.UseLock(pkgsLock)
 
 			err = downloader.Download(ps)
 			if err != nil {
@@ -96,6 +136,23 @@ func NewVendorCmd() *cobra.Command {
 				return fmt.Errorf("%w: %v", ErrDownloading, err)
 			}
 
+			if err := pkgsLock.Save(); err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return fmt.Errorf("%w: %w", ErrLockFile, err)
+			}
+
+			changes := pkgsLock.Changes()
+
+			for _, c := range changes {
+				logrus.Info(FormatChange(c))
+			}
+
+			if flags.Update && len(changes) == 0 {
+				logrus.Info("all packages are up to date")
+			}
+
 			cmdEvent.AddSuccessMessage("dependencies downloaded successfully")
 			tracker.Track(cmdEvent)
 
@@ -119,13 +176,76 @@ func NewVendorCmd() *cobra.Command {
 			"like 'monitoring', and ignore the rest",
 	)
 
+	vendorCmd.Flags().Bool(
+		"frozen",
+		false,
+		"fail if any package is missing from the "+legacy.LockFileName+" file or does not match it, "+
+			"without changing the lock file",
+	)
+
+	vendorCmd.Flags().Bool(
+		"update",
+		false,
+		"resolve the versions of the packages again, or of the given packages only, "+
+			"and update the "+legacy.LockFileName+" file",
+	)
+
 	return vendorCmd
 }
 
-func getLegacyVendorCmdFlags() VendorCmdFlags {
-	return VendorCmdFlags{
+func getLegacyVendorCmdFlags(args []string) (VendorCmdFlags, error) {
+	flags := VendorCmdFlags{
 		FuryFilePath: viper.GetString("furyfile"),
 		Prefix:       viper.GetString("prefix"),
 		GitProtocol:  viper.GetString("git-protocol"),
+		Frozen:       viper.GetBool("frozen"),
+		Update:       viper.GetBool("update"),
+		Packages:     args,
+	}
+
+	if flags.Frozen && flags.Update {
+		return VendorCmdFlags{}, fmt.Errorf("%w: --frozen and --update cannot be used together", ErrParsingFlag)
 	}
+
+	if len(args) > 0 && !flags.Update {
+		return VendorCmdFlags{}, fmt.Errorf("%w: packages can only be given with --update", ErrParsingFlag)
+	}
+
+	return flags, nil
+}
+
+// matchesAny tells whether the package with the given lock file name is one of the packages, or inside one of them.
+func matchesAny(name string, packages []string) bool {
+	for _, p := range packages {
+		p = strings.Trim(p, "/")
+
+		if name == p || strings.HasPrefix(name, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatChange describes a change of the lock file of the packages.
+func FormatChange(c lock.Change) string {
+	if c.Old == nil {
+		return fmt.Sprintf("%s '%s' locked at %s", c.Section, c.Name, formatRevision(c.New))
+	}
+
+	return fmt.Sprintf("%s '%s' updated from %s to %s", c.Section, c.Name, formatRevision(*c.Old), formatRevision(c.New))
+}
+
+func formatRevision(e lock.Entry) string {
+	commit := e.Commit
+	if len(commit) > shortCommitLength {
+		commit = commit[:shortCommitLength]
+	}
+
+	version := e.Version
+	if version == "" {
+		version = "default branch"
+	}
+
+	return fmt.Sprintf("%s (%s)", version, commit)
 }
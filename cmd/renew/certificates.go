@@ -14,9 +14,11 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	distroconf "github.com/sighupio/fury-distribution/pkg/apis/config"
 	"github.com/sighupio/furyctl/internal/analytics"
 	"github.com/sighupio/furyctl/internal/app"
 	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/clusterpki"
 	"github.com/sighupio/furyctl/internal/config"
 	"github.com/sighupio/furyctl/internal/flags"
 	"github.com/sighupio/furyctl/internal/git"
@@ -63,9 +65,28 @@ func NewCertificatesCmd() *cobra.Command {
 			gitProtocol := viper.GetString("git-protocol")
 			skipDepsDownload := viper.GetBool("skip-deps-download")
 			skipDepsValidation := viper.GetBool("skip-deps-validation")
+			onlyExpiringWithin := viper.GetString("only-expiring-within")
+			nodes := viper.GetStringSlice("node")
+			serial := viper.GetBool("serial")
 
-			// Get absolute path to the config file.
 			var err error
+
+			renewerOpts := cluster.CertificatesRenewerOptions{
+				Nodes:  nodes,
+				Serial: serial,
+			}
+
+			if onlyExpiringWithin != "" {
+				renewerOpts.OnlyExpiringWithin, err = clusterpki.ParseThreshold(onlyExpiringWithin)
+				if err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while parsing --only-expiring-within: %w", err)
+				}
+			}
+
+			// Get absolute path to the config file.
 			furyctlPath, err = filepath.Abs(furyctlPath)
 			if err != nil {
 				cmdEvent.AddErrorMessage(err)
@@ -134,6 +155,7 @@ func NewCertificatesCmd() *cobra.Command {
 
 			// Init second half of collaborators.
 			depsdl := dependencies.NewCachingDownloader(client, outDir, basePath, binPath, typedGitProtocol)
+			depsdl.PinChecksums(res.ToolsChecksums)
 
 			// Validate the furyctl.yaml file.
 			logrus.Info("Validating configuration file...")
@@ -153,6 +175,16 @@ func NewCertificatesCmd() *cobra.Command {
 
 					return fmt.Errorf("%w: %v", ErrDownloadDependenciesFailed, err)
 				}
+
+				// The VPN of the EKSCluster kind is renewed through the infrastructure phase, that needs the installers.
+				if res.MinimalConf.Kind == "EKSCluster" {
+					if err := downloadInstallers(depsdl, res.DistroManifest, typedGitProtocol); err != nil {
+						cmdEvent.AddErrorMessage(ErrDownloadDependenciesFailed)
+						tracker.Track(cmdEvent)
+
+						return fmt.Errorf("%w: %v", ErrDownloadDependenciesFailed, err)
+					}
+				}
 			} else {
 				logrus.Info("Dependencies download skipped")
 			}
@@ -170,7 +202,15 @@ func NewCertificatesCmd() *cobra.Command {
 				logrus.Info("Dependencies validation skipped")
 			}
 
-			renewer, err := cluster.NewCertificatesRenewer(res.MinimalConf, res.DistroManifest, res.RepoPath, furyctlPath)
+			renewer, err := cluster.NewCertificatesRenewer(
+				res.MinimalConf,
+				res.DistroManifest,
+				res.RepoPath,
+				furyctlPath,
+				basePath,
+				binPath,
+				renewerOpts,
+			)
 			if err != nil {
 				cmdEvent.AddErrorMessage(err)
 				tracker.Track(cmdEvent)
@@ -185,9 +225,9 @@ func NewCertificatesCmd() *cobra.Command {
 				return fmt.Errorf("error while renewing certificates: %w", err)
 			}
 
-			logrus.Infof("Certificates successfully renewed")
+			logrus.Info("Certificates renewal completed")
 
-			cmdEvent.AddSuccessMessage("certificates successfully renewed")
+			cmdEvent.AddSuccessMessage("certificates renewal completed")
 			tracker.Track(cmdEvent)
 
 			return nil
@@ -230,5 +270,37 @@ func NewCertificatesCmd() *cobra.Command {
 		"Skip validating dependencies",
 	)
 
+	certificatesCmd.Flags().String(
+		"only-expiring-within",
+		"",
+		"Renew only the certificates expiring within this threshold, expressed in days (eg: 30d) or as a duration (eg: 720h). "+
+			"CA certificates are not taken into account",
+	)
+
+	certificatesCmd.Flags().StringSlice(
+		"node",
+		[]string{},
+		"Renew certificates only on these nodes, as named in the inventory. Can be repeated or comma separated",
+	)
+
+	certificatesCmd.Flags().Bool(
+		"serial",
+		false,
+		"Renew certificates one node at a time, waiting for the API server to be healthy before moving to the next node",
+	)
+
 	return certificatesCmd
 }
+
+func downloadInstallers(depsdl *dependencies.Downloader, kfdManifest distroconf.KFD, gitProtocol git.Protocol) error {
+	gitPrefix, err := git.RepoPrefixByProtocol(gitProtocol)
+	if err != nil {
+		return fmt.Errorf("error while getting git prefix: %w", err)
+	}
+
+	if err := depsdl.DownloadInstallers(kfdManifest, gitPrefix); err != nil {
+		return fmt.Errorf("error while downloading installers: %w", err)
+	}
+
+	return nil
+}
@@ -20,22 +20,34 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/atrest"
+	"github.com/sighupio/furyctl/internal/dependencies/scheduler"
 	"github.com/sighupio/furyctl/internal/flags"
 	"github.com/sighupio/furyctl/internal/git"
+	"github.com/sighupio/furyctl/internal/mirror"
+	"github.com/sighupio/furyctl/internal/redact"
+	"github.com/sighupio/furyctl/internal/state"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
 	iox "github.com/sighupio/furyctl/internal/x/io"
 	logrusx "github.com/sighupio/furyctl/internal/x/logrus"
 )
 
 type rootConfig struct {
-	Debug            bool
-	DisableAnalytics bool
-	DisableTty       bool
-	GitProtocol      git.Protocol
-	Log              string
-	Outdir           string
-	Spinner          *spinner.Spinner
-	Workdir          string
+	AtRestIdentity     string
+	AtRestPassphrase   string
+	AtRestRecipients   []string
+	Debug              bool
+	DisableAnalytics   bool
+	DisableTty         bool
+	DownloadRetries    int
+	DownloadWorkers    int
+	GitProtocol        git.Protocol
+	Log                string
+	Mirrors            string
+	Outdir             string
+	Spinner            *spinner.Spinner
+	StateEncryptionKey string
+	Workdir            string
 }
 
 type RootCommand struct {
@@ -161,11 +173,52 @@ furyctl is a command line interface tool to manage the full lifecycle of SIGHUP
 					execx.LogFile = logFile
 				}
 
+				// Configure the encryption of the rendered configuration stored in the cluster.
+				if key := viper.GetString("state-encryption-key"); key != "" {
+					redact.Secrets.Add(key)
+
+					state.EncryptionKey = key
+				}
+
 				// Configure logging level and format.
 				cflag := viper.GetBool("no-tty")
 				dflag := viper.GetBool("debug")
 				logrusx.InitLog(logFile, dflag, cflag)
 
+				// Configure the encryption at rest of the sensitive local files.
+				atRestCfg := atrest.Config{
+					Recipients:   viper.GetStringSlice("at-rest-recipient"),
+					IdentityPath: viper.GetString("at-rest-identity"),
+					Passphrase:   viper.GetString("at-rest-passphrase"),
+				}
+
+				redact.Secrets.Add(atRestCfg.Passphrase)
+
+				if err := atrest.Default.Configure(atRestCfg); err != nil {
+					logrus.Fatalf("error while configuring encryption at rest: %v", err)
+				}
+
+				// Encrypt the decrypted files back also when exiting because of a fatal error.
+				logrus.RegisterExitHandler(func() {
+					if err := atrest.Default.Seal(); err != nil {
+						logrus.Error(err)
+					}
+				})
+
+				// Configure the mirrors the artifacts are downloaded from.
+				if mirrorsPath := viper.GetString("mirrors"); mirrorsPath != "" {
+					if err := mirror.Default.Load(mirrorsPath); err != nil {
+						logrus.Fatalf("error while loading mirrors: %v", err)
+					}
+
+					logrus.Debugf("Using mirrors from %s", mirrorsPath)
+				}
+
+				// Configure how many dependencies are downloaded at the same time and how many times they are retried.
+				scheduler.Default.Workers = viper.GetInt("download-workers")
+				scheduler.Default.Retries = viper.GetInt("download-retries")
+				scheduler.Default.Tracker = tracker
+
 				logrus.Debugf("Writing logs to %s", logPath)
 
 				// Deprected flags.
@@ -228,6 +281,61 @@ furyctl is a command line interface tool to manage the full lifecycle of SIGHUP
 		"Path to a file or folder where to write logs to. Set to 'stdout' write to standard output. Target path will be created if it does not exists. Path is relative to --workdir. Default is '<outdir>/.furyctl/furyctl.<timestamp>-<random number>.log'",
 	)
 
+	rootCmd.PersistentFlags().StringVar(
+		&rootCmd.config.StateEncryptionKey,
+		"state-encryption-key",
+		"",
+		"Passphrase used to encrypt the rendered configuration, which contains the resolved secrets, before storing it in the cluster. "+
+			"The same passphrase is needed to read it back in following executions. Prefer setting it with the FURYCTL_STATE_ENCRYPTION_KEY environment variable",
+	)
+
+	rootCmd.PersistentFlags().StringSliceVar(
+		&rootCmd.config.AtRestRecipients,
+		"at-rest-recipient",
+		[]string{},
+		"age public key used to encrypt the sensitive files furyctl stores locally, such as kubeconfigs, VPN certificates and PKI. "+
+			"Can be repeated. Encrypted files are decrypted in a memory-backed temporary folder only for the duration of the command",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&rootCmd.config.AtRestIdentity,
+		"at-rest-identity",
+		"",
+		"Path to the age identity file used to decrypt the sensitive files furyctl stores locally. "+
+			"When no --at-rest-recipient is given, files are encrypted to this identity",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&rootCmd.config.AtRestPassphrase,
+		"at-rest-passphrase",
+		"",
+		"Passphrase used to encrypt and decrypt the sensitive files furyctl stores locally, "+
+			"alternative to --at-rest-recipient and --at-rest-identity. Prefer setting it with the FURYCTL_AT_REST_PASSPHRASE environment variable",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&rootCmd.config.Mirrors,
+		"mirrors",
+		"",
+		"Path to a file with the rules to rewrite the URLs the distribution, modules, installers and tools are downloaded from, "+
+			"for example to download them through an internal Git server or artifact proxy, and the credential helpers to authenticate to them. "+
+			"Path is relative to --workdir",
+	)
+
+	rootCmd.PersistentFlags().IntVar(
+		&rootCmd.config.DownloadWorkers,
+		"download-workers",
+		scheduler.DefaultWorkers,
+		"Maximum number of modules, installers and tools downloaded at the same time",
+	)
+
+	rootCmd.PersistentFlags().IntVar(
+		&rootCmd.config.DownloadRetries,
+		"download-retries",
+		scheduler.DefaultRetries,
+		"Number of times a download failing because of a network or server error is retried, waiting longer after every attempt",
+	)
+
 	rootCmd.PersistentFlags().VarP(
 		&git.ProtocolFlag{Protocol: git.ProtocolHTTPS},
 		"git-protocol",
@@ -254,6 +362,7 @@ furyctl is a command line interface tool to manage the full lifecycle of SIGHUP
 	}
 
 	rootCmd.AddCommand(NewApplyCmd())
+	rootCmd.AddCommand(NewCacheCmd())
 	rootCmd.AddCommand(NewCompletionCmd(rootCmd.Root()))
 	rootCmd.AddCommand(NewConnectCmd())
 	rootCmd.AddCommand(NewCreateCmd())
@@ -266,6 +375,7 @@ furyctl is a command line interface tool to manage the full lifecycle of SIGHUP
 	rootCmd.AddCommand(NewValidateCmd())
 	rootCmd.AddCommand(NewVersionCmd())
 	rootCmd.AddCommand(NewRenewCmd())
+	rootCmd.AddCommand(NewToolsCmd())
 
 	return rootCmd
 }
@@ -0,0 +1,23 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/sighupio/furyctl/cmd/tools"
+)
+
+func NewToolsCmd() *cobra.Command {
+	toolsCmd := &cobra.Command{
+		Use:   "tools",
+		Short: "Use the tools pinned by the SIGHUP Distribution version specified in the configuration file",
+	}
+
+	toolsCmd.AddCommand(tools.NewEnvCmd())
+	toolsCmd.AddCommand(tools.NewExecCmd())
+
+	return toolsCmd
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sighupio/furyctl/internal/analytics"
+	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/flags"
+	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
+)
+
+func NewEnvCmd() *cobra.Command {
+	var cmdEvent analytics.Event
+
+	envCmd := &cobra.Command{
+		Use:   "env",
+		Short: "Print the shell exports to use the tools pinned by the SD version of the configuration file",
+		Long: "Print the shell exports to use the tools pinned by the SD version of the configuration file, as downloaded " +
+			"by furyctl, with the kubeconfig of the cluster. The PATH lists the folder of every pinned tool first, so " +
+			"running `eval $(furyctl tools env)` gives the same toolchain furyctl uses.",
+		Example: `  eval $(furyctl tools env)     sets PATH, HELM_PLUGINS and KUBECONFIG in the current shell
+`,
+		Args: cobra.NoArgs,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			cmdEvent = analytics.NewCommandEvent(cobrax.GetFullname(cmd))
+
+			// Load and validate flags from configuration FIRST.
+			if err := flags.LoadAndMergeCommandFlags("tools"); err != nil {
+				logrus.Fatalf("failed to load flags from configuration: %v", err)
+			}
+
+			if err := viper.BindPFlags(cmd.Flags()); err != nil {
+				logrus.Fatalf("error while binding flags: %v", err)
+			}
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctn := app.GetContainerInstance()
+
+			tracker := ctn.Tracker()
+			tracker.Flush()
+
+			flags, err := getCmdFlags()
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return err
+			}
+
+			c, err := loadCluster(flags)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return err
+			}
+
+			// The exports are printed to stdout without going through the logger, to keep them usable with eval.
+			for _, line := range Exports(c.Env(c.Kubeconfig)) {
+				if _, err := fmt.Println(line); err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while printing exports: %w", err)
+				}
+			}
+
+			cmdEvent.AddSuccessMessage("tools environment printed")
+			tracker.Track(cmdEvent)
+
+			return nil
+		},
+	}
+
+	addCommonFlags(envCmd)
+
+	return envCmd
+}
+
+// Exports formats KEY=value environment variables as POSIX shell export statements.
+func Exports(env []string) []string {
+	lines := make([]string, 0, len(env))
+
+	for _, kv := range env {
+		k, v, _ := strings.Cut(kv, "=")
+
+		lines = append(lines, fmt.Sprintf("export %s='%s'", k, strings.ReplaceAll(v, "'", `'\''`)))
+	}
+
+	return lines
+}
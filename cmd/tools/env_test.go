@@ -0,0 +1,29 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sighupio/furyctl/cmd/tools"
+)
+
+func TestExports(t *testing.T) {
+	t.Parallel()
+
+	got := tools.Exports([]string{
+		"PATH=/bin/kubectl/1.30.0:/usr/bin",
+		"KUBECONFIG=/home/o'brien/kubeconfig",
+	})
+
+	assert.Equal(t, []string{
+		"export PATH='/bin/kubectl/1.30.0:/usr/bin'",
+		`export KUBECONFIG='/home/o'\''brien/kubeconfig'`,
+	}, got)
+}
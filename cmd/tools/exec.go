@@ -0,0 +1,153 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sighupio/furyctl/internal/analytics"
+	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/flags"
+	cobrax "github.com/sighupio/furyctl/internal/x/cobra"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+)
+
+var (
+	ErrMissingTool = errors.New("the tool to run is required")
+	ErrRunningTool = errors.New("tool execution failed")
+)
+
+func NewExecCmd() *cobra.Command {
+	var cmdEvent analytics.Event
+
+	execCmd := &cobra.Command{
+		Use:   "exec <tool> -- [args...]",
+		Short: "Run a tool with the version pinned by the SD version of the configuration file",
+		Long: "Run a tool with the version pinned by the SD version of the configuration file, as downloaded by furyctl, " +
+			"with the kubeconfig of the cluster. The tool runs in the folder of the cluster, or in the folder of the given phase.",
+		Example: `  furyctl tools exec kubectl -- get nodes                               runs the pinned kubectl against the cluster
+  furyctl tools exec terraform --phase infrastructure -- state list     runs the pinned terraform (or OpenTofu) in the infrastructure phase folder
+`,
+		Args: cobra.MinimumNArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			cmdEvent = analytics.NewCommandEvent(cobrax.GetFullname(cmd))
+
+			// Load and validate flags from configuration FIRST.
+			if err := flags.LoadAndMergeCommandFlags("tools"); err != nil {
+				logrus.Fatalf("failed to load flags from configuration: %v", err)
+			}
+
+			if err := viper.BindPFlags(cmd.Flags()); err != nil {
+				logrus.Fatalf("error while binding flags: %v", err)
+			}
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			ctn := app.GetContainerInstance()
+
+			tracker := ctn.Tracker()
+			tracker.Flush()
+
+			flags, err := getCmdFlags()
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return err
+			}
+
+			phase := viper.GetString("phase")
+			if phase != "" {
+				if err := cluster.CheckPhase(phase); err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("%w: phase: %w", ErrParsingFlag, err)
+				}
+			}
+
+			c, err := loadCluster(flags)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return err
+			}
+
+			name := args[0]
+
+			bin, err := c.Path(name)
+			if err != nil {
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return err
+			}
+
+			workDir := workDir(c, name, phase)
+
+			logrus.Debugf("Running %s in %s", bin, workDir)
+
+			cmd := execx.NewStdExecutor().Command(bin, args[1:]...)
+			cmd.Dir = workDir
+			cmd.Env = append(os.Environ(), c.Env(c.Kubeconfig)...)
+			cmd.Stdin = os.Stdin
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+
+			if err := cmd.Run(); err != nil {
+				err = fmt.Errorf("%w: %s: %w", ErrRunningTool, name, err)
+
+				cmdEvent.AddErrorMessage(err)
+				tracker.Track(cmdEvent)
+
+				return err
+			}
+
+			cmdEvent.AddSuccessMessage("tool executed")
+			tracker.Track(cmdEvent)
+
+			return nil
+		},
+	}
+
+	addCommonFlags(execCmd)
+
+	execCmd.Flags().String(
+		"phase",
+		"",
+		"Run the tool in the folder of the given phase, the terraform subfolder for terraform and OpenTofu. Options are: "+
+			cluster.OperationPhaseInfrastructure+", "+cluster.OperationPhaseKubernetes+", "+cluster.OperationPhaseDistribution,
+	)
+
+	return execCmd
+}
+
+// workDir returns the folder to run the tool in: the folder of the phase, if any, or the one of the cluster. The
+// current directory is used if furyctl has not created them yet.
+func workDir(c Cluster, name, phase string) string {
+	dir := c.Dir
+
+	if phase != "" {
+		dir = filepath.Join(dir, phase)
+
+		if name == "terraform" || name == "tofu" || name == "opentofu" {
+			dir = filepath.Join(dir, "terraform")
+		}
+	}
+
+	if !fileExists(dir) {
+		return ""
+	}
+
+	return dir
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sighupio/furyctl/internal/atrest"
+	"github.com/sighupio/furyctl/internal/git"
+	"github.com/sighupio/furyctl/internal/toolchain"
+	dist "github.com/sighupio/furyctl/pkg/distribution"
+	netx "github.com/sighupio/furyctl/pkg/x/net"
+)
+
+var ErrParsingFlag = errors.New("error while parsing flag")
+
+type CmdFlags struct {
+	BinPath        string
+	DistroLocation string
+	FuryctlPath    string
+	GitProtocol    git.Protocol
+	Kubeconfig     string
+	Outdir         string
+	Workdir        string
+}
+
+// Cluster is the toolchain of the cluster described by the configuration file, with its folders.
+type Cluster struct {
+	*toolchain.Toolchain
+
+	// Dir is the folder where furyctl stores the phases of the cluster.
+	Dir string
+	// Kubeconfig is the path of the kubeconfig of the cluster, empty if it is not known.
+	Kubeconfig string
+}
+
+func getCmdFlags() (CmdFlags, error) {
+	gitProtocol := viper.GetString("git-protocol")
+
+	typedGitProtocol, err := git.NewProtocol(gitProtocol)
+	if err != nil {
+		return CmdFlags{}, fmt.Errorf("%w: %w", ErrParsingFlag, err)
+	}
+
+	flags := CmdFlags{
+		BinPath:        viper.GetString("bin-path"),
+		DistroLocation: viper.GetString("distro-location"),
+		FuryctlPath:    viper.GetString("config"),
+		GitProtocol:    typedGitProtocol,
+		Kubeconfig:     viper.GetString("kubeconfig"),
+		Outdir:         viper.GetString("outdir"),
+		Workdir:        viper.GetString("workdir"),
+	}
+
+	if flags.FuryctlPath, err = filepath.Abs(flags.FuryctlPath); err != nil {
+		return CmdFlags{}, fmt.Errorf("%w: config: %w", ErrParsingFlag, err)
+	}
+
+	if flags.BinPath == "" {
+		flags.BinPath = filepath.Join(flags.Outdir, ".furyctl", "bin")
+	} else if flags.BinPath, err = filepath.Abs(flags.BinPath); err != nil {
+		return CmdFlags{}, fmt.Errorf("%w: bin-path: %w", ErrParsingFlag, err)
+	}
+
+	if flags.Kubeconfig != "" {
+		if flags.Kubeconfig, err = filepath.Abs(flags.Kubeconfig); err != nil {
+			return CmdFlags{}, fmt.Errorf("%w: kubeconfig: %w", ErrParsingFlag, err)
+		}
+	}
+
+	return flags, nil
+}
+
+// loadCluster resolves the toolchain pinned by the distribution version of the configuration file. The kubeconfig is
+// the one given with the flag, or the one furyctl writes in the working directory when creating the cluster.
+func loadCluster(flags CmdFlags) (Cluster, error) {
+	var distrodl *dist.Downloader
+
+	client := netx.NewGoGetterClient()
+
+	if flags.DistroLocation == "" {
+		distrodl = dist.NewCachingDownloader(client, flags.Outdir, flags.GitProtocol, "")
+	} else {
+		distrodl = dist.NewDownloader(client, flags.GitProtocol, "")
+	}
+
+	logrus.Debug("Downloading distribution...")
+
+	res, err := distrodl.Download(flags.DistroLocation, flags.FuryctlPath)
+	if err != nil {
+		return Cluster{}, fmt.Errorf("error while downloading distribution: %w", err)
+	}
+
+	kubeconfig := flags.Kubeconfig
+
+	if kubeconfig == "" {
+		workdir := flags.Workdir
+		if workdir == "" {
+			if workdir, err = os.Getwd(); err != nil {
+				return Cluster{}, fmt.Errorf("error while getting current directory: %w", err)
+			}
+		}
+
+		p := filepath.Join(workdir, "kubeconfig")
+
+		// The kubeconfig encrypted at rest is decrypted for the duration of the command.
+		if fileExists(p + atrest.FileExt) {
+			if err := atrest.Default.ProtectFile(p); err != nil {
+				return Cluster{}, fmt.Errorf("error while decrypting kubeconfig: %w", err)
+			}
+		}
+
+		if fileExists(p) {
+			kubeconfig = p
+		}
+	}
+
+	return Cluster{
+		Toolchain:  toolchain.New(res.DistroManifest.Tools, flags.BinPath),
+		Dir:        filepath.Join(flags.Outdir, ".furyctl", res.MinimalConf.Metadata.Name),
+		Kubeconfig: kubeconfig,
+	}, nil
+}
+
+func addCommonFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(
+		"bin-path",
+		"b",
+		"",
+		"Path to the folder where all the dependencies' binaries are downloaded",
+	)
+
+	cmd.Flags().StringP(
+		"config",
+		"c",
+		"furyctl.yaml",
+		"Path to the configuration file",
+	)
+
+	cmd.Flags().StringP(
+		"distro-location",
+		"",
+		"",
+		"Location where to download schemas, defaults and the distribution manifests from. "+
+			"It can either be a local path (eg: /path/to/distribution) or "+
+			"a remote URL (eg: git::git@github.com:sighupio/distribution?depth=1&ref=BRANCH_NAME). "+
+			"Any format supported by hashicorp/go-getter can be used",
+	)
+
+	cmd.Flags().String(
+		"kubeconfig",
+		"",
+		"Path to the kubeconfig file of the cluster, defaults to the kubeconfig furyctl writes in the working directory, "+
+			"if any, or to the KUBECONFIG environment variable",
+	)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
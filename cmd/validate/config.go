@@ -59,6 +59,7 @@ func NewConfigCmd() *cobra.Command {
 			gitProtocol := viper.GetString("git-protocol")
 			outDir := viper.GetString("outdir")
 			distroPatchesLocation := viper.GetString("distro-patches")
+			bundle := viper.GetString("bundle")
 
 			typedGitProtocol, err := git.NewProtocol(gitProtocol)
 			if err != nil {
@@ -79,7 +80,22 @@ func NewConfigCmd() *cobra.Command {
 
 			var distrodl *dist.Downloader
 
-			client := netx.NewGoGetterClient()
+			var client netx.Client = netx.NewGoGetterClient()
+
+			if bundle != "" {
+				bundleClient, err := netx.NewBundleClient(bundle)
+				if err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while opening bundle: %w", err)
+				}
+
+				defer bundleClient.Close() //nolint:errcheck // ignore error
+
+				client = bundleClient
+			}
+
 			executor := execx.NewStdExecutor()
 			depsvl := dependencies.NewValidator(executor, "", furyctlPath, false)
 
@@ -160,5 +176,12 @@ func NewConfigCmd() *cobra.Command {
 			"must have the same structure as the distribution's repository",
 	)
 
+	configCmd.Flags().String(
+		"bundle",
+		"",
+		"Path to a bundle created with 'furyctl download bundle'. When set, the distribution and all the "+
+			"dependencies are taken from the bundle instead of being downloaded",
+	)
+
 	return configCmd
 }
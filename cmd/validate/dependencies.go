@@ -56,6 +56,7 @@ func NewDependenciesCmd() *cobra.Command {
 			furyctlPath := viper.GetString("config")
 			distroLocation := viper.GetString("distro-location")
 			distroPatchesLocation := viper.GetString("distro-patches")
+			bundle := viper.GetString("bundle")
 
 			outDir := viper.GetString("outdir")
 
@@ -92,7 +93,22 @@ func NewDependenciesCmd() *cobra.Command {
 
 			var distrodl *dist.Downloader
 
-			client := netx.NewGoGetterClient()
+			var client netx.Client = netx.NewGoGetterClient()
+
+			if bundle != "" {
+				bundleClient, err := netx.NewBundleClient(bundle)
+				if err != nil {
+					cmdEvent.AddErrorMessage(err)
+					tracker.Track(cmdEvent)
+
+					return fmt.Errorf("error while opening bundle: %w", err)
+				}
+
+				defer bundleClient.Close() //nolint:errcheck // ignore error
+
+				client = bundleClient
+			}
+
 			executor := execx.NewStdExecutor()
 			depsvl := dependencies.NewValidator(executor, "", furyctlPath, false)
 
@@ -126,7 +142,7 @@ func NewDependenciesCmd() *cobra.Command {
 			})
 
 			toolsValidator := tools.NewValidator(executor, binPath, furyctlPath, false)
-			envVarsValidator := envvars.NewValidator()
+			envVarsValidator := envvars.NewValidator(executor, furyctlPath)
 			errs := make([]error, 0)
 
 			logrus.Info("Validating tools...")
@@ -136,6 +152,13 @@ func NewDependenciesCmd() *cobra.Command {
 				dres.MinimalConf,
 			)
 
+			logrus.Info("Validating tools checksums...")
+
+			csts, cerrs := toolsValidator.ValidateChecksums(
+				dres.DistroManifest,
+				dres.MinimalConf,
+			)
+
 			logrus.Info("Validating environment variables...")
 
 			eoks, eerrs := envVarsValidator.Validate(dres.MinimalConf.Kind)
@@ -143,14 +166,25 @@ func NewDependenciesCmd() *cobra.Command {
 			logrus.Info("Validating tools configuration...")
 
 			errs = append(errs, terrs...)
+			errs = append(errs, cerrs...)
 			errs = append(errs, eerrs...)
 
 			for _, tok := range toks {
 				logrus.Infof("%s: binary found in vendor folder", tok)
 			}
 
+			for _, cst := range csts {
+				if cst.Source == "" {
+					logrus.Warnf("%s: checksum not verified", cst.Tool)
+
+					continue
+				}
+
+				logrus.Infof("%s: checksum verified against %s", cst.Tool, cst.Source)
+			}
+
 			for _, eok := range eoks {
-				logrus.Infof("%s: environment variable found", eok)
+				logrus.Infof("%s: credentials found", eok)
 			}
 
 			if len(errs) > 0 {
@@ -215,5 +249,12 @@ func NewDependenciesCmd() *cobra.Command {
 			"must have the same structure as the distribution's repository",
 	)
 
+	dependenciesCmd.Flags().String(
+		"bundle",
+		"",
+		"Path to a bundle created with 'furyctl download bundle'. When set, the distribution and all the "+
+			"dependencies are taken from the bundle instead of being downloaded",
+	)
+
 	return dependenciesCmd
 }
@@ -46,6 +46,34 @@ func (c CommandEvent) Name() string {
 	return c.name
 }
 
+// NewDownloadEvent creates the event reporting the outcome of a batch of downloads.
+func NewDownloadEvent(items []DownloadItem) Event {
+	e := CommandEvent{
+		name:       "download",
+		properties: make(map[string]any),
+	}
+
+	var bytes int64
+
+	failed := 0
+
+	for _, item := range items {
+		bytes += item.Bytes
+
+		if item.Error != "" {
+			failed++
+		}
+	}
+
+	e.properties["items"] = items
+	e.properties["count"] = len(items)
+	e.properties["failed"] = failed
+	e.properties["bytes"] = bytes
+	e.properties["success"] = failed == 0
+
+	return e
+}
+
 // NewStopEvent creates a new StopEvent. StopEvent is a special type of event used to close the events processing.
 func NewStopEvent() Event {
 	return StopEvent{
@@ -91,6 +119,15 @@ type CommandEvent struct {
 
 type properties map[string]any
 
+// DownloadItem is the outcome of the download of a dependency.
+type DownloadItem struct {
+	Name     string  `json:"name"`
+	Bytes    int64   `json:"bytes"`
+	Seconds  float64 `json:"seconds"`
+	Attempts int     `json:"attempts"`
+	Error    string  `json:"error,omitempty"`
+}
+
 type ClusterDetails struct {
 	Phase      string
 	Provider   string
@@ -16,6 +16,7 @@ import (
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
 	"github.com/sighupio/furyctl/internal/cluster"
 	"github.com/sighupio/furyctl/internal/distribution"
+	"github.com/sighupio/furyctl/internal/parser"
 	"github.com/sighupio/furyctl/internal/tool/helmfile"
 	"github.com/sighupio/furyctl/internal/tool/shell"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
@@ -74,6 +75,10 @@ func NewPlugins(
 func (p *Plugins) Exec() error {
 	logrus.Info("Applying plugins...")
 
+	if err := parser.TfOutputs.SetCurrentPhase(cluster.OperationPhasePlugins); err != nil {
+		return err
+	}
+
 	if err := p.CreateRootFolder(); err != nil {
 		return fmt.Errorf("error creating plugins phase folder: %w", err)
 	}
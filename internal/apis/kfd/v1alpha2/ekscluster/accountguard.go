@@ -0,0 +1,168 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ekscluster
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sighupio/furyctl/internal/tool/awscli"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+// AccountGuardField is the field of the metadata of the configuration file restricting the AWS accounts and regions
+// the cluster can be applied to.
+const AccountGuardField = "awsAccountGuard"
+
+var (
+	ErrInvalidAccountGuard  = errors.New("invalid AWS account guard")
+	ErrUnexpectedAWSAccount = errors.New("unexpected AWS account")
+	ErrUnexpectedAWSRegion  = errors.New("unexpected AWS region")
+)
+
+// AccountGuard holds the AWS account IDs and regions allowed by metadata.awsAccountGuard. An empty list allows any
+// value.
+type AccountGuard struct {
+	AccountIDs []string
+	Regions    []string
+}
+
+// NewAccountGuard reads the guard from the furyctl configuration conf. The block is not part of the distribution
+// schemas, so it is validated here: unknown keys and values other than lists of strings are rejected, as a typo
+// would silently disable the guard and account IDs written as numbers lose their leading zeros.
+func NewAccountGuard(conf map[string]any) (AccountGuard, error) {
+	metadata, _ := conf["metadata"].(map[string]any)
+
+	value, ok := metadata[AccountGuardField]
+	if !ok || value == nil {
+		return AccountGuard{AccountIDs: []string{}, Regions: []string{}}, nil
+	}
+
+	guard, ok := value.(map[string]any)
+	if !ok {
+		return AccountGuard{}, fmt.Errorf("%w: metadata.%s must be an object", ErrInvalidAccountGuard, AccountGuardField)
+	}
+
+	for key := range guard {
+		if key != "accountIds" && key != "regions" {
+			return AccountGuard{}, fmt.Errorf(
+				"%w: unknown field metadata.%s.%s, allowed fields are accountIds and regions",
+				ErrInvalidAccountGuard,
+				AccountGuardField,
+				key,
+			)
+		}
+	}
+
+	accountIDs, err := toStrings(guard, "accountIds")
+	if err != nil {
+		return AccountGuard{}, err
+	}
+
+	regions, err := toStrings(guard, "regions")
+	if err != nil {
+		return AccountGuard{}, err
+	}
+
+	return AccountGuard{
+		AccountIDs: accountIDs,
+		Regions:    regions,
+	}, nil
+}
+
+func (g AccountGuard) Empty() bool {
+	return len(g.AccountIDs) == 0 && len(g.Regions) == 0
+}
+
+// Check fails when the account of the AWS identity in use or the region of the cluster are not allowed.
+func (g AccountGuard) Check(account, region string) error {
+	if len(g.AccountIDs) > 0 && !slices.Contains(g.AccountIDs, account) {
+		return fmt.Errorf(
+			"%w: the AWS credentials in use belong to account %s, while metadata.%s.accountIds allows %s",
+			ErrUnexpectedAWSAccount,
+			account,
+			AccountGuardField,
+			strings.Join(g.AccountIDs, ", "),
+		)
+	}
+
+	if len(g.Regions) > 0 && !slices.Contains(g.Regions, region) {
+		return fmt.Errorf(
+			"%w: the cluster is in region %s, while metadata.%s.regions allows %s",
+			ErrUnexpectedAWSRegion,
+			region,
+			AccountGuardField,
+			strings.Join(g.Regions, ", "),
+		)
+	}
+
+	return nil
+}
+
+// checkAccountGuard resolves the AWS identity in use and checks it against the guard of the configuration file, if
+// any, before anything is done on the account.
+func checkAccountGuard(configPath, region, workDir string) error {
+	conf, err := yamlx.FromFileV3[map[string]any](configPath)
+	if err != nil {
+		return fmt.Errorf("error while reading config file: %w", err)
+	}
+
+	guard, err := NewAccountGuard(conf)
+	if err != nil {
+		return err
+	}
+
+	if guard.Empty() {
+		return nil
+	}
+
+	identity, err := awscli.NewRunner(execx.NewStdExecutor(), awscli.Paths{
+		Awscli:  "aws",
+		WorkDir: workDir,
+	}).CallerIdentity(region)
+	if err != nil {
+		return fmt.Errorf("error while resolving AWS identity: %w", err)
+	}
+
+	logrus.Infof("Using AWS identity %s in account %s", identity.Arn, identity.Account)
+
+	return guard.Check(identity.Account, region)
+}
+
+func toStrings(guard map[string]any, key string) ([]string, error) {
+	value, ok := guard[key]
+	if !ok || value == nil {
+		return []string{}, nil
+	}
+
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata.%s.%s must be a list", ErrInvalidAccountGuard, AccountGuardField, key)
+	}
+
+	res := make([]string, 0, len(items))
+
+	for i, item := range items {
+		s, ok := item.(string)
+		if !ok || s == "" {
+			return nil, fmt.Errorf(
+				"%w: metadata.%s.%s[%d] must be a non-empty string, quote account IDs to keep their leading zeros",
+				ErrInvalidAccountGuard,
+				AccountGuardField,
+				key,
+				i,
+			)
+		}
+
+		res = append(res, s)
+	}
+
+	return res, nil
+}
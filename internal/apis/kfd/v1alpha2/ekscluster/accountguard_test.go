@@ -0,0 +1,154 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package ekscluster_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sighupio/furyctl/internal/apis/kfd/v1alpha2/ekscluster"
+)
+
+func TestNewAccountGuard(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		guard   any
+		want    ekscluster.AccountGuard
+		wantErr string
+	}{
+		{
+			desc: "guard not set",
+			want: ekscluster.AccountGuard{AccountIDs: []string{}, Regions: []string{}},
+		},
+		{
+			desc: "guard set",
+			guard: map[string]any{
+				"accountIds": []any{"012345678901", "123456789012"},
+				"regions":    []any{"eu-west-1"},
+			},
+			want: ekscluster.AccountGuard{
+				AccountIDs: []string{"012345678901", "123456789012"},
+				Regions:    []string{"eu-west-1"},
+			},
+		},
+		{
+			desc:  "only regions set",
+			guard: map[string]any{"regions": []any{"eu-west-1"}},
+			want:  ekscluster.AccountGuard{AccountIDs: []string{}, Regions: []string{"eu-west-1"}},
+		},
+		{
+			desc:    "guard not an object",
+			guard:   "123456789012",
+			wantErr: "metadata.awsAccountGuard must be an object",
+		},
+		{
+			desc:    "misspelled key",
+			guard:   map[string]any{"accountId": []any{"123456789012"}},
+			wantErr: "unknown field metadata.awsAccountGuard.accountId",
+		},
+		{
+			desc:    "account ids not a list",
+			guard:   map[string]any{"accountIds": "123456789012"},
+			wantErr: "metadata.awsAccountGuard.accountIds must be a list",
+		},
+		{
+			desc:    "numeric account id",
+			guard:   map[string]any{"accountIds": []any{"012345678901", 123456789012}},
+			wantErr: "metadata.awsAccountGuard.accountIds[1] must be a non-empty string",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			metadata := map[string]any{"name": "test"}
+			if tc.guard != nil {
+				metadata[ekscluster.AccountGuardField] = tc.guard
+			}
+
+			got, err := ekscluster.NewAccountGuard(map[string]any{"metadata": metadata})
+			if tc.wantErr != "" {
+				require.ErrorIs(t, err, ekscluster.ErrInvalidAccountGuard)
+				require.ErrorContains(t, err, tc.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAccountGuard_Check(t *testing.T) {
+	t.Parallel()
+
+	guard := ekscluster.AccountGuard{
+		AccountIDs: []string{"123456789012"},
+		Regions:    []string{"eu-west-1", "eu-central-1"},
+	}
+
+	testCases := []struct {
+		desc    string
+		guard   ekscluster.AccountGuard
+		account string
+		region  string
+		wantErr error
+	}{
+		{
+			desc:    "empty guard",
+			account: "210987654321",
+			region:  "us-east-1",
+		},
+		{
+			desc:    "allowed account and region",
+			guard:   guard,
+			account: "123456789012",
+			region:  "eu-central-1",
+		},
+		{
+			desc:    "unexpected account",
+			guard:   guard,
+			account: "210987654321",
+			region:  "eu-west-1",
+			wantErr: ekscluster.ErrUnexpectedAWSAccount,
+		},
+		{
+			desc:    "unexpected region",
+			guard:   guard,
+			account: "123456789012",
+			region:  "us-east-1",
+			wantErr: ekscluster.ErrUnexpectedAWSRegion,
+		},
+		{
+			desc:    "only regions restricted",
+			guard:   ekscluster.AccountGuard{Regions: []string{"eu-west-1"}},
+			account: "210987654321",
+			region:  "eu-west-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.guard.Check(tc.account, tc.region)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -6,18 +6,252 @@ package ekscluster
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/sirupsen/logrus"
+
+	"github.com/sighupio/fury-distribution/pkg/apis/config"
+	"github.com/sighupio/fury-distribution/pkg/apis/ekscluster/v1alpha2/private"
+	"github.com/sighupio/furyctl/internal/apis/kfd/v1alpha2/ekscluster/common"
+	"github.com/sighupio/furyctl/internal/apis/kfd/v1alpha2/ekscluster/vpn"
 	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/clusterpki"
+	"github.com/sighupio/furyctl/internal/tool/terraform"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+var (
+	ErrVPNNotCreated = errors.New("VPN furyagent configuration not found, " +
+		"run furyctl apply to create the VPN first")
+	ErrVPNInstancesNotFound = errors.New("VPN instances not found in the infrastructure terraform state")
+
+	vpnInstanceAddressRegexp = regexp.MustCompile(`^module\.vpn(\[\d+\])?\.aws_instance\.vpn(\[\d+\])?$`)
 )
 
-var ErrRenewNotSupported = errors.New("you can't renew certificates for EKSCluster")
+type CertificatesRenewer struct {
+	furyctlConf private.EksclusterKfdV1Alpha2
+	kfdManifest config.KFD
+	distroPath  string
+	configPath  string
+	workDir     string
+	binPath     string
+	selective   bool
+}
+
+func (k *CertificatesRenewer) SetProperties(props []cluster.CertificatesRenewerProperty) {
+	for _, prop := range props {
+		k.SetProperty(prop.Name, prop.Value)
+	}
+}
+
+func (k *CertificatesRenewer) SetProperty(name string, value any) {
+	lcName := strings.ToLower(name)
+
+	switch lcName {
+	case cluster.CertificatesRenewerPropertyFuryctlConf:
+		if s, ok := value.(private.EksclusterKfdV1Alpha2); ok {
+			k.furyctlConf = s
+		}
+
+	case cluster.CertificatesRenewerPropertyConfigPath:
+		if s, ok := value.(string); ok {
+			k.configPath = s
+		}
+
+	case cluster.CertificatesRenewerPropertyKfdManifest:
+		if s, ok := value.(config.KFD); ok {
+			k.kfdManifest = s
+		}
+
+	case cluster.CertificatesRenewerPropertyDistroPath:
+		if s, ok := value.(string); ok {
+			k.distroPath = s
+		}
+
+	case cluster.CertificatesRenewerPropertyWorkDir:
+		if s, ok := value.(string); ok {
+			k.workDir = s
+		}
+
+	case cluster.CertificatesRenewerPropertyBinPath:
+		if s, ok := value.(string); ok {
+			k.binPath = s
+		}
+
+	case cluster.CertificatesRenewerPropertyOnlyExpiringWithin:
+		if d, ok := value.(time.Duration); ok && d != 0 {
+			k.selective = true
+		}
+
+	case cluster.CertificatesRenewerPropertyNodes:
+		if s, ok := value.([]string); ok && len(s) > 0 {
+			k.selective = true
+		}
+
+	case cluster.CertificatesRenewerPropertySerial:
+		if b, ok := value.(bool); ok && b {
+			k.selective = true
+		}
+	}
+}
+
+// Renew rotates the VPN certificates, the only ones furyctl provisions for EKSCluster: the control plane PKI is
+// managed by AWS and the other ones are managed in the cluster.
+func (k *CertificatesRenewer) Renew() error {
+	if k.selective {
+		return cluster.ErrRenewOptionsNotSupported
+	}
+
+	if k.vpnInstances() == 0 {
+		logrus.Info("No VPN configured, no certificates to renew")
+	} else if err := k.renewVPN(); err != nil {
+		return err
+	}
+
+	furyctlConf, err := yamlx.FromFileV3[map[string]any](k.configPath)
+	if err != nil {
+		return fmt.Errorf("error reading configuration file: %w", err)
+	}
+
+	logrus.Info("The following certificates are not renewed by furyctl:")
+	logrus.Info("- EKS control plane and kubelet certificates: managed by AWS")
+
+	for _, c := range clusterpki.ExternallyManaged(furyctlConf, time.Now()) {
+		logrus.Infof("- %s", c)
+	}
+
+	return nil
+}
+
+func (k *CertificatesRenewer) vpnInstances() int64 {
+	if k.furyctlConf.Spec.Infrastructure == nil || k.furyctlConf.Spec.Infrastructure.Vpn == nil {
+		return 0
+	}
+
+	if k.furyctlConf.Spec.Infrastructure.Vpn.Instances == nil {
+		return 1
+	}
+
+	return *k.furyctlConf.Spec.Infrastructure.Vpn.Instances
+}
+
+// renewVPN regenerates the VPN PKI with furyagent, replaces the VPN instances so that they load the new server
+// certificates and generates a new client profile.
+func (k *CertificatesRenewer) renewVPN() error {
+	if err := checkAccountGuard(k.configPath, string(k.furyctlConf.Spec.Region), k.workDir); err != nil {
+		return err
+	}
+
+	logrus.Info("Renewing VPN certificates...")
+
+	infra := &common.Infrastructure{
+		OperationPhase: cluster.NewOperationPhase(
+			path.Join(k.workDir, cluster.OperationPhaseInfrastructure),
+			k.kfdManifest.Tools,
+			k.binPath,
+		),
+		FuryctlConf: k.furyctlConf,
+		ConfigPath:  k.configPath,
+		DistroPath:  k.distroPath,
+	}
+
+	if err := infra.Prepare(); err != nil {
+		return fmt.Errorf("error preparing infrastructure phase: %w", err)
+	}
+
+	if _, err := os.Stat(path.Join(infra.TerraformSecretsPath, "furyagent.yml")); err != nil {
+		return ErrVPNNotCreated
+	}
+
+	vpnConnector, err := vpn.NewConnector(
+		k.furyctlConf.Metadata.Name,
+		infra.TerraformSecretsPath,
+		k.binPath,
+		k.kfdManifest.Tools.Common.Furyagent.Version,
+		false,
+		true,
+		k.furyctlConf.Spec.Infrastructure.Vpn,
+	)
+	if err != nil {
+		return fmt.Errorf("error while creating vpn connector: %w", err)
+	}
+
+	if err := vpnConnector.RenewServerCertificates(); err != nil {
+		return fmt.Errorf("error renewing vpn server certificates: %w", err)
+	}
+
+	tfRunner := terraform.NewRunner(
+		execx.NewStdExecutor(),
+		terraform.Paths{
+			Logs:      infra.TerraformLogsPath,
+			Outputs:   infra.TerraformOutputsPath,
+			WorkDir:   path.Join(infra.Path, "terraform"),
+			Plan:      infra.TerraformPlanPath,
+			Terraform: infra.TerraformPath,
+		},
+	)
+
+	if err := tfRunner.Init(); err != nil {
+		return fmt.Errorf("error running terraform/tofu init: %w", err)
+	}
+
+	instances, err := vpnInstanceAddresses(tfRunner)
+	if err != nil {
+		return err
+	}
+
+	// Only the VPN instances are replaced, any other drift of the infrastructure is left to furyctl apply.
+	params := []string{"-target=module.vpn"}
+	for _, instance := range instances {
+		params = append(params, "-replace="+instance)
+	}
+
+	timestamp := time.Now().Unix()
+
+	if _, err := tfRunner.Plan(timestamp, params...); err != nil {
+		return fmt.Errorf("error running terraform/tofu plan: %w", err)
+	}
+
+	logrus.Warn("Replacing VPN instances, this could take a while...")
+
+	if err := tfRunner.Apply(timestamp); err != nil {
+		return fmt.Errorf("error replacing vpn instances: %w", err)
+	}
+
+	if _, err := tfRunner.Output(); err != nil {
+		return fmt.Errorf("error getting terraform/tofu output: %w", err)
+	}
+
+	if err := vpnConnector.RenewClientCertificate(); err != nil {
+		return fmt.Errorf("error renewing vpn client certificate: %w", err)
+	}
+
+	logrus.Infof("VPN certificates renewed, connect using the new %s.ovpn profile", k.furyctlConf.Metadata.Name)
+
+	return nil
+}
 
-type CertificatesRenewer struct{}
+// vpnInstanceAddresses returns the addresses of the VPN instances in the terraform state.
+func vpnInstanceAddresses(tfRunner *terraform.Runner) ([]string, error) {
+	addresses, err := tfRunner.StateList("module.vpn")
+	if err != nil {
+		return nil, fmt.Errorf("error listing vpn resources: %w", err)
+	}
 
-func (*CertificatesRenewer) SetProperties(_ []cluster.CertificatesRenewerProperty) {}
+	instances := slices.DeleteFunc(addresses, func(address string) bool {
+		return !vpnInstanceAddressRegexp.MatchString(address)
+	})
 
-func (*CertificatesRenewer) SetProperty(_ string, _ any) {}
+	if len(instances) == 0 {
+		return nil, ErrVPNInstancesNotFound
+	}
 
-func (*CertificatesRenewer) Renew() error {
-	return ErrRenewNotSupported
+	return instances, nil
 }
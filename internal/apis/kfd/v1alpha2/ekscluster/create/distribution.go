@@ -5,6 +5,7 @@
 package create
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,6 +20,7 @@ import (
 	"github.com/sighupio/fury-distribution/pkg/apis/ekscluster/v1alpha2/private"
 	"github.com/sighupio/furyctl/internal/apis/kfd/v1alpha2/ekscluster/common"
 	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/parser"
 	"github.com/sighupio/furyctl/internal/state"
 	"github.com/sighupio/furyctl/internal/tool/kubectl"
 	"github.com/sighupio/furyctl/internal/tool/shell"
@@ -132,6 +134,7 @@ func (*Distribution) SupportsLifecycle(lifecycle string) bool {
 }
 
 func (d *Distribution) Exec(
+	ctx context.Context,
 	rdcs reducers.Reducers,
 	startFrom string,
 	upgradeState *upgrade.State,
@@ -140,6 +143,12 @@ func (d *Distribution) Exec(
 
 	logrus.Info("Installing SIGHUP Distribution...")
 
+	if err := parser.TfOutputs.SetCurrentPhase(cluster.OperationPhaseDistribution); err != nil {
+		return err
+	}
+
+	defer cluster.StopOnCancel(ctx, cluster.OperationPhaseDistribution, d.Stop)()
+
 	furyctlMerger, preTfMerger, tfCfg, err := d.PreparePreTerraform()
 	if err != nil {
 		return fmt.Errorf("error preparing distribution phase (pre terraform): %w", err)
@@ -153,10 +162,14 @@ func (d *Distribution) Exec(
 		return fmt.Errorf("error running terraform init: %w", err)
 	}
 
-	if err := d.preDistribution(startFrom, upgradeState); err != nil {
+	if err := d.preDistribution(ctx, startFrom, upgradeState); err != nil {
 		return fmt.Errorf("error running pre-distribution phase: %w", err)
 	}
 
+	if err := cluster.Interrupted(ctx); err != nil {
+		return fmt.Errorf("error before running core distribution phase: %w", err)
+	}
+
 	if err := d.coreDistribution(
 		rdcs,
 		tfCfg,
@@ -175,7 +188,7 @@ func (d *Distribution) Exec(
 		return nil
 	}
 
-	if err := d.postDistribution(upgradeState); err != nil {
+	if err := d.postDistribution(ctx, upgradeState); err != nil {
 		return fmt.Errorf("error running post-distribution phase: %w", err)
 	}
 
@@ -185,12 +198,13 @@ func (d *Distribution) Exec(
 }
 
 func (d *Distribution) preDistribution(
+	ctx context.Context,
 	startFrom string,
 	upgradeState *upgrade.State,
 ) error {
 	if !d.DryRun {
 		if startFrom == "" || startFrom == cluster.OperationSubPhasePreDistribution {
-			if err := d.upgrade.Exec(d.Path, "pre-distribution"); err != nil {
+			if err := d.upgrade.Exec(ctx, d.Path, "pre-distribution"); err != nil {
 				upgradeState.Phases.PreDistribution.Status = upgrade.PhaseStatusFailed
 
 				return fmt.Errorf("error running upgrade: %w", err)
@@ -312,9 +326,10 @@ func (d *Distribution) coreDistribution(
 }
 
 func (d *Distribution) postDistribution(
+	ctx context.Context,
 	upgradeState *upgrade.State,
 ) error {
-	if err := d.upgrade.Exec(d.Path, "post-distribution"); err != nil {
+	if err := d.upgrade.Exec(ctx, d.Path, "post-distribution"); err != nil {
 		upgradeState.Phases.PostDistribution.Status = upgrade.PhaseStatusFailed
 
 		return fmt.Errorf("error running upgrade: %w", err)
@@ -6,6 +6,7 @@ package create
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -82,9 +83,15 @@ func (i *Infrastructure) Self() *cluster.OperationPhase {
 	return i.OperationPhase
 }
 
-func (i *Infrastructure) Exec(startFrom string, upgradeState *upgrade.State) error {
+func (i *Infrastructure) Exec(ctx context.Context, startFrom string, upgradeState *upgrade.State) error {
 	logrus.Info("Creating infrastructure...")
 
+	if err := parser.TfOutputs.SetCurrentPhase(cluster.OperationPhaseInfrastructure); err != nil {
+		return err
+	}
+
+	defer cluster.StopOnCancel(ctx, cluster.OperationPhaseInfrastructure, i.Stop)()
+
 	timestamp := time.Now().Unix()
 
 	if err := i.Prepare(); err != nil {
@@ -95,10 +102,14 @@ func (i *Infrastructure) Exec(startFrom string, upgradeState *upgrade.State) err
 		return fmt.Errorf("error running terraform/tofu init: %w", err)
 	}
 
-	if err := i.preInfrastructure(startFrom, upgradeState); err != nil {
+	if err := i.preInfrastructure(ctx, startFrom, upgradeState); err != nil {
 		return fmt.Errorf("error running pre-infrastructure phase: %w", err)
 	}
 
+	if err := cluster.Interrupted(ctx); err != nil {
+		return fmt.Errorf("error before running core infrastructure phase: %w", err)
+	}
+
 	if err := i.coreInfrastructure(startFrom, upgradeState, timestamp); err != nil {
 		return fmt.Errorf("error running core infrastructure phase: %w", err)
 	}
@@ -107,7 +118,7 @@ func (i *Infrastructure) Exec(startFrom string, upgradeState *upgrade.State) err
 		return nil
 	}
 
-	if err := i.postInfrastructure(upgradeState); err != nil {
+	if err := i.postInfrastructure(ctx, upgradeState); err != nil {
 		return fmt.Errorf("error running post-infrastructure phase: %w", err)
 	}
 
@@ -117,11 +128,12 @@ func (i *Infrastructure) Exec(startFrom string, upgradeState *upgrade.State) err
 }
 
 func (i *Infrastructure) preInfrastructure(
+	ctx context.Context,
 	startFrom string,
 	upgradeState *upgrade.State,
 ) error {
 	if !i.dryRun && (startFrom == "" || startFrom == cluster.OperationSubPhasePreInfrastructure) {
-		if err := i.upgrade.Exec(i.Path, "pre-infrastructure"); err != nil {
+		if err := i.upgrade.Exec(ctx, i.Path, "pre-infrastructure"); err != nil {
 			upgradeState.Phases.PreInfrastructure.Status = upgrade.PhaseStatusFailed
 
 			return fmt.Errorf("error running upgrade: %w", err)
@@ -200,9 +212,10 @@ func (*Infrastructure) getCriticalTFResourceTypes() []string {
 }
 
 func (i *Infrastructure) postInfrastructure(
+	ctx context.Context,
 	upgradeState *upgrade.State,
 ) error {
-	if err := i.upgrade.Exec(i.Path, "post-infrastructure"); err != nil {
+	if err := i.upgrade.Exec(ctx, i.Path, "post-infrastructure"); err != nil {
 		upgradeState.Phases.PostInfrastructure.Status = upgrade.PhaseStatusFailed
 
 		return fmt.Errorf("error running upgrade: %w", err)
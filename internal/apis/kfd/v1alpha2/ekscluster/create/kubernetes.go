@@ -6,6 +6,7 @@ package create
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -107,11 +108,17 @@ func (k *Kubernetes) Self() *cluster.OperationPhase {
 	return k.OperationPhase
 }
 
-func (k *Kubernetes) Exec(startFrom string, upgradeState *upgrade.State) error {
+func (k *Kubernetes) Exec(ctx context.Context, startFrom string, upgradeState *upgrade.State) error {
 	timestamp := time.Now().Unix()
 
 	logrus.Info("Configuring SIGHUP Distribution cluster...")
 
+	if err := parser.TfOutputs.SetCurrentPhase(cluster.OperationPhaseKubernetes); err != nil {
+		return err
+	}
+
+	defer cluster.StopOnCancel(ctx, cluster.OperationPhaseKubernetes, k.Stop)()
+
 	if err := k.Prepare(); err != nil {
 		return fmt.Errorf("error preparing kubernetes phase: %w", err)
 	}
@@ -120,10 +127,14 @@ func (k *Kubernetes) Exec(startFrom string, upgradeState *upgrade.State) error {
 		return fmt.Errorf("error running terraform init: %w", err)
 	}
 
-	if err := k.preKubernetes(startFrom, upgradeState); err != nil {
+	if err := k.preKubernetes(ctx, startFrom, upgradeState); err != nil {
 		return fmt.Errorf("error running pre-kubernetes phase: %w", err)
 	}
 
+	if err := cluster.Interrupted(ctx); err != nil {
+		return fmt.Errorf("error before running core kubernetes phase: %w", err)
+	}
+
 	if err := k.coreKubernetes(startFrom, upgradeState, timestamp); err != nil {
 		return fmt.Errorf("error running core kubernetes phase: %w", err)
 	}
@@ -134,7 +145,7 @@ func (k *Kubernetes) Exec(startFrom string, upgradeState *upgrade.State) error {
 		return nil
 	}
 
-	if err := k.postKubernetes(upgradeState); err != nil {
+	if err := k.postKubernetes(ctx, upgradeState); err != nil {
 		return fmt.Errorf("error running post-kubernetes phase: %w", err)
 	}
 
@@ -144,11 +155,12 @@ func (k *Kubernetes) Exec(startFrom string, upgradeState *upgrade.State) error {
 }
 
 func (k *Kubernetes) preKubernetes(
+	ctx context.Context,
 	startFrom string,
 	upgradeState *upgrade.State,
 ) error {
 	if !k.DryRun && (startFrom == "" || startFrom == cluster.OperationSubPhasePreKubernetes) {
-		if err := k.upgrade.Exec(k.Path, "pre-kubernetes"); err != nil {
+		if err := k.upgrade.Exec(ctx, k.Path, "pre-kubernetes"); err != nil {
 			upgradeState.Phases.PreKubernetes.Status = upgrade.PhaseStatusFailed
 
 			return fmt.Errorf("error running upgrade: %w", err)
@@ -267,9 +279,10 @@ func (*Kubernetes) getCriticalTFResourceTypes() []string {
 }
 
 func (k *Kubernetes) postKubernetes(
+	ctx context.Context,
 	upgradeState *upgrade.State,
 ) error {
-	if err := k.upgrade.Exec(k.Path, "post-kubernetes"); err != nil {
+	if err := k.upgrade.Exec(ctx, k.Path, "post-kubernetes"); err != nil {
 		upgradeState.Phases.PostKubernetes.Status = upgrade.PhaseStatusFailed
 
 		return fmt.Errorf("error running upgrade: %w", err)
@@ -17,7 +17,9 @@ import (
 	"github.com/sighupio/furyctl/internal/apis/kfd/v1alpha2/ekscluster/common"
 	"github.com/sighupio/furyctl/internal/apis/kfd/v1alpha2/ekscluster/supported"
 	"github.com/sighupio/furyctl/internal/apis/kfd/v1alpha2/ekscluster/vpn"
+	"github.com/sighupio/furyctl/internal/atrest"
 	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/parser"
 	"github.com/sighupio/furyctl/internal/state"
 	"github.com/sighupio/furyctl/internal/tool/awscli"
 	"github.com/sighupio/furyctl/internal/tool/kubectl"
@@ -153,6 +155,10 @@ func (p *PreFlight) Exec(renderedConfig map[string]any) (*Status, error) {
 
 	logrus.Info("Ensure prerequisites are in place...")
 
+	if err := parser.TfOutputs.SetCurrentPhase(cluster.OperationPhasePreFlight); err != nil {
+		return status, err
+	}
+
 	if err := p.EnsureTerraformStateAWSS3Bucket(); err != nil {
 		return status, fmt.Errorf("error ensuring terraform state aws s3 bucket: %w", err)
 	}
@@ -177,6 +183,10 @@ func (p *PreFlight) Exec(renderedConfig map[string]any) (*Status, error) {
 		return status, nil //nolint:nilerr // we want to return nil here
 	}
 
+	if err := atrest.Default.Protect(path.Join(p.Path, "secrets")); err != nil {
+		return status, fmt.Errorf("error decrypting kubeconfig folder: %w", err)
+	}
+
 	kubeconfig := path.Join(p.Path, "secrets", "kubeconfig")
 
 	logrus.Info("Updating kubeconfig...")
@@ -5,6 +5,7 @@
 package ekscluster
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -189,7 +190,11 @@ func (*ClusterCreator) GetPhasePath(phase string) (string, error) {
 	}
 }
 
-func (v *ClusterCreator) Create(startFrom string, timeout, _ int) error {
+func (v *ClusterCreator) Create(ctx context.Context, startFrom string, timeout, _ int) error {
+	if err := checkAccountGuard(v.paths.ConfigPath, string(v.furyctlConf.Spec.Region), v.paths.WorkDir); err != nil {
+		return err
+	}
+
 	upgr := upgrade.New(v.paths, string(v.furyctlConf.Kind))
 
 	infra, kube, distro, plugins, preflight, err := v.setupPhases(upgr, v.upgrade)
@@ -197,6 +202,16 @@ func (v *ClusterCreator) Create(startFrom string, timeout, _ int) error {
 		return err
 	}
 
+	if err := registerTfOutputs(
+		v.paths.ConfigPath,
+		infra.Self().TerraformOutputsPath,
+		kube.Self().TerraformOutputsPath,
+		distro.Self().TerraformOutputsPath,
+		true,
+	); err != nil {
+		return err
+	}
+
 	var vpnConfig *private.SpecInfrastructureVpn
 
 	if v.furyctlConf.Spec.Infrastructure != nil {
@@ -220,6 +235,7 @@ func (v *ClusterCreator) Create(startFrom string, timeout, _ int) error {
 	doneCh := make(chan bool)
 
 	go v.CreateAsync(
+		ctx,
 		&Phases{
 			PreFlight:      preflight,
 			Infrastructure: infra,
@@ -315,6 +331,7 @@ func (*ClusterCreator) initUpgradeState() *upgrade.State {
 }
 
 func (v *ClusterCreator) CreateAsync(
+	ctx context.Context,
 	phases *Phases,
 	startFrom string,
 	vpnConnector *vpn.Connector,
@@ -383,12 +400,12 @@ func (v *ClusterCreator) CreateAsync(
 
 	switch v.phase {
 	case cluster.OperationPhaseInfrastructure:
-		if err := v.infraPhase(phases.Infrastructure, vpnConnector); err != nil {
+		if err := v.infraPhase(ctx, phases.Infrastructure, vpnConnector); err != nil {
 			errCh <- err
 		}
 
 	case cluster.OperationPhaseKubernetes:
-		if err := v.kubernetesPhase(phases.Kubernetes, vpnConnector, renderedConfig); err != nil {
+		if err := v.kubernetesPhase(ctx, phases.Kubernetes, vpnConnector, renderedConfig); err != nil {
 			errCh <- err
 		}
 
@@ -404,7 +421,7 @@ func (v *ClusterCreator) CreateAsync(
 			}
 		}
 
-		if err := v.distributionPhase(phases.Distribution, vpnConnector, rdcs, renderedConfig); err != nil {
+		if err := v.distributionPhase(ctx, phases.Distribution, vpnConnector, rdcs, renderedConfig); err != nil {
 			errCh <- err
 		}
 
@@ -430,6 +447,7 @@ func (v *ClusterCreator) CreateAsync(
 		}
 
 		errCh <- v.allPhases(
+			ctx,
 			startFrom,
 			phases,
 			vpnConnector,
@@ -443,7 +461,11 @@ func (v *ClusterCreator) CreateAsync(
 	}
 }
 
-func (v *ClusterCreator) infraPhase(infra upgrade.OperatorPhaseAsync, vpnConnector *vpn.Connector) error {
+func (v *ClusterCreator) infraPhase(
+	ctx context.Context,
+	infra upgrade.OperatorPhaseAsync,
+	vpnConnector *vpn.Connector,
+) error {
 	upgradeState := upgrade.State{
 		Phases: upgrade.Phases{
 			PreInfrastructure:  &upgrade.Phase{Status: upgrade.PhaseStatusPending},
@@ -463,7 +485,7 @@ func (v *ClusterCreator) infraPhase(infra upgrade.OperatorPhaseAsync, vpnConnect
 		return fmt.Errorf("%w: check at %s", ErrInfraNotPresent, absPath)
 	}
 
-	if err := infra.Exec(StartFromFlagNotSet, &upgradeState); err != nil {
+	if err := infra.Exec(ctx, StartFromFlagNotSet, &upgradeState); err != nil {
 		return fmt.Errorf("error while executing infrastructure phase: %w", err)
 	}
 
@@ -485,6 +507,7 @@ func (v *ClusterCreator) infraPhase(infra upgrade.OperatorPhaseAsync, vpnConnect
 }
 
 func (v *ClusterCreator) kubernetesPhase(
+	ctx context.Context,
 	kube upgrade.OperatorPhaseAsync,
 	vpnConnector *vpn.Connector,
 	renderedConfig map[string]any,
@@ -508,7 +531,7 @@ func (v *ClusterCreator) kubernetesPhase(
 	logrus.Warn("Please make sure that the Kubernetes API is reachable before continuing" +
 		" (e.g. check VPN connection is active`), otherwise the installation will fail.")
 
-	if err := kube.Exec(StartFromFlagNotSet, &upgradeState); err != nil {
+	if err := kube.Exec(ctx, StartFromFlagNotSet, &upgradeState); err != nil {
 		return fmt.Errorf("error while executing kubernetes phase: %w", err)
 	}
 
@@ -540,6 +563,7 @@ func (v *ClusterCreator) kubernetesPhase(
 }
 
 func (v *ClusterCreator) distributionPhase(
+	ctx context.Context,
 	distro upgrade.ReducersOperatorPhaseAsync[reducers.Reducers],
 	vpnConnector *vpn.Connector,
 	rdcs reducers.Reducers,
@@ -561,7 +585,7 @@ func (v *ClusterCreator) distributionPhase(
 		}
 	}
 
-	if err := distro.Exec(rdcs, StartFromFlagNotSet, &upgradeState); err != nil {
+	if err := distro.Exec(ctx, rdcs, StartFromFlagNotSet, &upgradeState); err != nil {
 		return fmt.Errorf("error while installing SIGHUP Distribution: %w", err)
 	}
 
@@ -589,6 +613,7 @@ func (v *ClusterCreator) distributionPhase(
 }
 
 func (v *ClusterCreator) allPhases(
+	ctx context.Context,
 	startFrom string,
 	phases *Phases,
 	vpnConnector *vpn.Connector,
@@ -634,6 +659,7 @@ func (v *ClusterCreator) allPhases(
 	logrus.Info("Creating cluster...")
 
 	if err := v.allPhasesExec(
+		ctx,
 		startFrom,
 		phases,
 		vpnConnector,
@@ -646,7 +672,7 @@ func (v *ClusterCreator) allPhases(
 	if len(v.postApplyPhases) > 0 {
 		logrus.Info("Executing extra phases...")
 
-		if err := v.extraPhases(phases, upgradeState, upgr); err != nil {
+		if err := v.extraPhases(ctx, phases, upgradeState, upgr); err != nil {
 			return fmt.Errorf("error while executing extra phases: %w", err)
 		}
 	}
@@ -684,7 +710,12 @@ func (v *ClusterCreator) allPhases(
 	return nil
 }
 
-func (v *ClusterCreator) extraPhases(phases *Phases, upgradeState *upgrade.State, upgr *upgrade.Upgrade) error {
+func (v *ClusterCreator) extraPhases(
+	ctx context.Context,
+	phases *Phases,
+	upgradeState *upgrade.State,
+	upgr *upgrade.Upgrade,
+) error {
 	initialUpgrade := upgr.Enabled
 
 	defer func() {
@@ -692,18 +723,22 @@ func (v *ClusterCreator) extraPhases(phases *Phases, upgradeState *upgrade.State
 	}()
 
 	for _, phase := range v.postApplyPhases {
+		if err := cluster.Interrupted(ctx); err != nil {
+			return fmt.Errorf("error before executing %s post-apply phase: %w", phase, err)
+		}
+
 		switch phase {
 		case cluster.OperationPhaseInfrastructure:
 			phases.Infrastructure.SetUpgrade(false)
 
-			if err := phases.Infrastructure.Exec(StartFromFlagNotSet, upgradeState); err != nil {
+			if err := phases.Infrastructure.Exec(ctx, StartFromFlagNotSet, upgradeState); err != nil {
 				return fmt.Errorf("error while executing post infrastructure phase: %w", err)
 			}
 
 		case cluster.OperationPhaseKubernetes:
 			phases.Kubernetes.SetUpgrade(false)
 
-			if err := phases.Kubernetes.Exec(StartFromFlagNotSet, upgradeState); err != nil {
+			if err := phases.Kubernetes.Exec(ctx, StartFromFlagNotSet, upgradeState); err != nil {
 				return fmt.Errorf("error while executing post kubernetes phase: %w", err)
 			}
 
@@ -711,6 +746,7 @@ func (v *ClusterCreator) extraPhases(phases *Phases, upgradeState *upgrade.State
 			phases.Distribution.SetUpgrade(false)
 
 			if err := phases.Distribution.Exec(
+				ctx,
 				reducers.Reducers{},
 				StartFromFlagNotSet,
 				upgradeState,
@@ -731,6 +767,7 @@ func (v *ClusterCreator) extraPhases(phases *Phases, upgradeState *upgrade.State
 }
 
 func (v *ClusterCreator) allPhasesExec(
+	ctx context.Context,
 	startFrom string,
 	phases *Phases,
 	vpnConnector *vpn.Connector,
@@ -742,7 +779,7 @@ func (v *ClusterCreator) allPhasesExec(
 			startFrom == cluster.OperationPhaseInfrastructure ||
 			startFrom == cluster.OperationSubPhasePreInfrastructure ||
 			startFrom == cluster.OperationSubPhasePostInfrastructure) {
-		if err := phases.Infrastructure.Exec(v.getInfrastructureSubPhase(startFrom), upgradeState); err != nil {
+		if err := phases.Infrastructure.Exec(ctx, v.getInfrastructureSubPhase(startFrom), upgradeState); err != nil {
 			return fmt.Errorf("error while executing infrastructure phase: %w", err)
 		}
 
@@ -765,17 +802,21 @@ func (v *ClusterCreator) allPhasesExec(
 		startFrom != cluster.OperationPhaseDistribution &&
 		startFrom != cluster.OperationSubPhasePostDistribution &&
 		startFrom != cluster.OperationPhasePlugins {
-		if err := phases.Kubernetes.Exec(v.getKubernetesSubPhase(startFrom), upgradeState); err != nil {
+		if err := phases.Kubernetes.Exec(ctx, v.getKubernetesSubPhase(startFrom), upgradeState); err != nil {
 			return fmt.Errorf("error while executing kubernetes phase: %w", err)
 		}
 	}
 
 	if startFrom != cluster.OperationPhasePlugins {
-		if err := phases.Distribution.Exec(rdcs, v.getDistributionSubPhase(startFrom), upgradeState); err != nil {
+		if err := phases.Distribution.Exec(ctx, rdcs, v.getDistributionSubPhase(startFrom), upgradeState); err != nil {
 			return fmt.Errorf("error while executing distribution phase: %w", err)
 		}
 	}
 
+	if err := cluster.Interrupted(ctx); err != nil {
+		return fmt.Errorf("error before executing plugins phase: %w", err)
+	}
+
 	if distribution.HasFeature(v.kfdManifest, distribution.FeaturePlugins) {
 		if err := phases.Plugins.Exec(); err != nil {
 			return fmt.Errorf("error while executing plugins phase: %w", err)
@@ -34,11 +34,12 @@ type Ingress struct {
 type Distribution struct {
 	*common.Distribution
 
-	awsRunner   *awscli.Runner
-	shellRunner *shell.Runner
-	kubeClient  *kubernetes.Client
-	dryRun      bool
-	paths       cluster.DeleterPaths
+	awsRunner    *awscli.Runner
+	shellRunner  *shell.Runner
+	kubeClient   *kubernetes.Client
+	dryRun       bool
+	paths        cluster.DeleterPaths
+	deletionPlan *cluster.DeletionPlan
 }
 
 func NewDistribution(
@@ -47,6 +48,7 @@ func NewDistribution(
 	infraOutputsPath string,
 	paths cluster.DeleterPaths,
 	furyctlConf private.EksclusterKfdV1Alpha2,
+	deletionPlan *cluster.DeletionPlan,
 ) *Distribution {
 	phase := cluster.NewOperationPhase(
 		path.Join(paths.WorkDir, cluster.OperationPhaseDistribution),
@@ -102,8 +104,9 @@ func NewDistribution(
 				WorkDir: path.Join(phase.Path, "manifests"),
 			},
 		),
-		dryRun: dryRun,
-		paths:  paths,
+		dryRun:       dryRun,
+		paths:        paths,
+		deletionPlan: deletionPlan,
 	}
 }
 
@@ -144,6 +147,18 @@ func (d *Distribution) Exec() error {
 			return fmt.Errorf("error running terraform plan: %w", err)
 		}
 
+		if err := d.deletionPlan.AddTerraformDestroyPlan(cluster.OperationPhaseDistribution, d.TFRunner); err != nil {
+			return fmt.Errorf("error reading terraform plan: %w", err)
+		}
+
+		if err := d.deletionPlan.AddKustomization(
+			cluster.OperationPhaseDistribution,
+			d.KustomizePath,
+			path.Join(d.Path, "manifests"),
+		); err != nil {
+			return fmt.Errorf("error reading distribution manifests: %w", err)
+		}
+
 		logrus.Info("The following resources, regardless of the built manifests, are going to be deleted:")
 
 		if _, err := d.kubeClient.ListNamespaceResources("ingress", "all"); err != nil {
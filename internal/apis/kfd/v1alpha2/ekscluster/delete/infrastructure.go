@@ -23,8 +23,9 @@ import (
 type Infrastructure struct {
 	*common.Infrastructure
 
-	tfRunner *terraform.Runner
-	dryRun   bool
+	tfRunner     *terraform.Runner
+	dryRun       bool
+	deletionPlan *cluster.DeletionPlan
 }
 
 func NewInfrastructure(
@@ -32,6 +33,7 @@ func NewInfrastructure(
 	dryRun bool,
 	kfdManifest config.KFD,
 	paths cluster.DeleterPaths,
+	deletionPlan *cluster.DeletionPlan,
 ) *Infrastructure {
 	phase := cluster.NewOperationPhase(
 		path.Join(paths.WorkDir, cluster.OperationPhaseInfrastructure),
@@ -56,7 +58,8 @@ func NewInfrastructure(
 				Terraform: phase.TerraformPath,
 			},
 		),
-		dryRun: dryRun,
+		dryRun:       dryRun,
+		deletionPlan: deletionPlan,
 	}
 }
 
@@ -76,6 +79,10 @@ func (i *Infrastructure) Exec() error {
 			return fmt.Errorf("error running terraform plan: %w", err)
 		}
 
+		if err := i.deletionPlan.AddTerraformDestroyPlan(cluster.OperationPhaseInfrastructure, i.tfRunner); err != nil {
+			return fmt.Errorf("error reading terraform plan: %w", err)
+		}
+
 		logrus.Info("Infrastructure deleted successfully (dry-run mode)")
 
 		return nil
@@ -41,8 +41,9 @@ var (
 type Kubernetes struct {
 	*common.Kubernetes
 
-	tfRunner  *terraform.Runner
-	awsRunner *awscli.Runner
+	tfRunner     *terraform.Runner
+	awsRunner    *awscli.Runner
+	deletionPlan *cluster.DeletionPlan
 }
 
 func NewKubernetes(
@@ -51,6 +52,7 @@ func NewKubernetes(
 	kfdManifest config.KFD,
 	infraOutputsPath string,
 	paths cluster.DeleterPaths,
+	deletionPlan *cluster.DeletionPlan,
 ) *Kubernetes {
 	phase := cluster.NewOperationPhase(
 		path.Join(paths.WorkDir, cluster.OperationPhaseKubernetes),
@@ -127,6 +129,10 @@ func (k *Kubernetes) Exec() error {
 			return fmt.Errorf("error running terraform plan: %w", err)
 		}
 
+		if err := k.deletionPlan.AddTerraformDestroyPlan(cluster.OperationPhaseKubernetes, k.tfRunner); err != nil {
+			return fmt.Errorf("error reading terraform plan: %w", err)
+		}
+
 		logrus.Info("Kubernetes cluster deleted successfully (dry-run mode)")
 
 		return nil
@@ -15,6 +15,7 @@ import (
 	"github.com/sighupio/fury-distribution/pkg/apis/ekscluster/v1alpha2/private"
 	"github.com/sighupio/furyctl/internal/apis/kfd/v1alpha2/ekscluster/common"
 	"github.com/sighupio/furyctl/internal/apis/kfd/v1alpha2/ekscluster/vpn"
+	"github.com/sighupio/furyctl/internal/atrest"
 	"github.com/sighupio/furyctl/internal/cluster"
 	"github.com/sighupio/furyctl/internal/tool/awscli"
 	"github.com/sighupio/furyctl/internal/tool/kubectl"
@@ -132,6 +133,10 @@ func (p *PreFlight) Exec() error {
 		return nil //nolint:nilerr // we want to return nil here
 	}
 
+	if err := atrest.Default.Protect(path.Join(p.Path, "secrets")); err != nil {
+		return fmt.Errorf("error decrypting kubeconfig folder: %w", err)
+	}
+
 	kubeconfig := path.Join(p.Path, "secrets", "kubeconfig")
 
 	logrus.Info("Updating kubeconfig...")
@@ -25,6 +25,8 @@ type ClusterDeleter struct {
 	skipVpn        bool
 	vpnAutoConnect bool
 	dryRun         bool
+	deletionPlan   *cluster.DeletionPlan
+	preDelete      cluster.PreDeleteFunc
 }
 
 func (d *ClusterDeleter) SetProperties(props []cluster.DeleterProperty) {
@@ -86,15 +88,30 @@ func (d *ClusterDeleter) SetProperty(name string, value any) {
 		if b, ok := value.(bool); ok {
 			d.dryRun = b
 		}
+
+	case cluster.DeleterPropertyDeletionPlan:
+		if p, ok := value.(*cluster.DeletionPlan); ok {
+			d.deletionPlan = p
+		}
+
+	case cluster.DeleterPropertyPreDelete:
+		if f, ok := value.(cluster.PreDeleteFunc); ok {
+			d.preDelete = f
+		}
 	}
 }
 
 func (d *ClusterDeleter) Delete() error {
+	if err := checkAccountGuard(d.paths.ConfigPath, string(d.furyctlConf.Spec.Region), d.paths.WorkDir); err != nil {
+		return err
+	}
+
 	infra := del.NewInfrastructure(
 		d.furyctlConf,
 		d.dryRun,
 		d.kfdManifest,
 		d.paths,
+		d.deletionPlan,
 	)
 
 	distro := del.NewDistribution(d.dryRun,
@@ -102,6 +119,7 @@ func (d *ClusterDeleter) Delete() error {
 		infra.Self().TerraformOutputsPath,
 		d.paths,
 		d.furyctlConf,
+		d.deletionPlan,
 	)
 
 	kube := del.NewKubernetes(d.furyctlConf,
@@ -109,8 +127,19 @@ func (d *ClusterDeleter) Delete() error {
 		d.kfdManifest,
 		infra.Self().TerraformOutputsPath,
 		d.paths,
+		d.deletionPlan,
 	)
 
+	if err := registerTfOutputs(
+		d.paths.ConfigPath,
+		infra.Self().TerraformOutputsPath,
+		kube.TerraformOutputsPath,
+		distro.TerraformOutputsPath,
+		false,
+	); err != nil {
+		return err
+	}
+
 	var vpnConfig *private.SpecInfrastructureVpn
 
 	if d.furyctlConf.Spec.Infrastructure != nil {
@@ -146,6 +175,12 @@ func (d *ClusterDeleter) Delete() error {
 		return fmt.Errorf("error while executing preflight phase: %w", err)
 	}
 
+	if d.preDelete != nil {
+		if err := d.preDelete(); err != nil {
+			return fmt.Errorf("error while preparing the deletion: %w", err)
+		}
+	}
+
 	switch d.phase {
 	case cluster.OperationPhaseInfrastructure:
 		if err := infra.Exec(); err != nil {
@@ -12,6 +12,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/sighupio/fury-distribution/pkg/apis/ekscluster/v1alpha2/private"
+	"github.com/sighupio/furyctl/internal/atrest"
 	"github.com/sighupio/furyctl/internal/cluster"
 	"github.com/sighupio/furyctl/internal/tool/awscli"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
@@ -55,6 +56,10 @@ func (k *KubeconfigGetter) Get() error {
 
 	kubeconfigPath := path.Join(k.workDir, "kubeconfig")
 
+	if err := atrest.Default.ProtectFile(kubeconfigPath); err != nil {
+		return fmt.Errorf("error protecting kubeconfig file: %w", err)
+	}
+
 	awsRunner := awscli.NewRunner(
 		execx.NewStdExecutor(),
 		awscli.Paths{
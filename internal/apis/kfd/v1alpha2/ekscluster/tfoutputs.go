@@ -0,0 +1,74 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ekscluster
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/parser"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+var ErrTfOutputOutsidePhase = errors.New("terraform output references can only be used inside a phase section")
+
+// registerTfOutputs makes the outputs of the terraform phases available as {tfoutput://<phase>/<output-name>}
+// dynamic values and, when checkOrder is true, verifies that no section references an output produced by
+// itself or by a phase that runs after it.
+func registerTfOutputs(configPath, infraOutputsPath, kubeOutputsPath, distroOutputsPath string, checkOrder bool) error {
+	parser.TfOutputs.Register(
+		parser.TfOutputPhase{Name: cluster.OperationPhasePreFlight},
+		parser.TfOutputPhase{Name: cluster.OperationPhaseInfrastructure, OutputsPath: infraOutputsPath},
+		parser.TfOutputPhase{Name: cluster.OperationPhaseKubernetes, OutputsPath: kubeOutputsPath},
+		parser.TfOutputPhase{Name: cluster.OperationPhaseDistribution, OutputsPath: distroOutputsPath},
+		parser.TfOutputPhase{Name: cluster.OperationPhasePlugins},
+	)
+
+	if !checkOrder {
+		return nil
+	}
+
+	rawConf, err := yamlx.FromFileV3[map[string]any](configPath)
+	if err != nil {
+		return fmt.Errorf("error reading configuration file: %w", err)
+	}
+
+	refs, err := parser.FindTfOutputReferences(rawConf)
+	if err != nil {
+		return fmt.Errorf("error looking for terraform output references: %w", err)
+	}
+
+	for _, ref := range refs {
+		consumer, err := tfOutputConsumerPhase(ref.Path)
+		if err != nil {
+			return err
+		}
+
+		if err := parser.TfOutputs.CheckOrder(consumer, ref); err != nil {
+			return fmt.Errorf("error validating terraform output references: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func tfOutputConsumerPhase(path string) (string, error) {
+	phases := map[string]string{
+		InfrastructurePhaseSchemaPath: cluster.OperationPhaseInfrastructure,
+		KubernetesPhaseSchemaPath:     cluster.OperationPhaseKubernetes,
+		DistributionPhaseSchemaPath:   cluster.OperationPhaseDistribution,
+		PluginsPhaseSchemaPath:        cluster.OperationPhasePlugins,
+	}
+
+	for schemaPath, phase := range phases {
+		if path == schemaPath || strings.HasPrefix(path, schemaPath+".") || strings.HasPrefix(path, schemaPath+"[") {
+			return phase, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w, found one at '%s'", ErrTfOutputOutsidePhase, path)
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/sighupio/fury-distribution/pkg/apis/ekscluster/v1alpha2/private"
+	"github.com/sighupio/furyctl/internal/atrest"
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/tool/awscli"
 	"github.com/sighupio/furyctl/internal/tool/furyagent"
 	"github.com/sighupio/furyctl/internal/tool/openvpn"
@@ -57,6 +59,12 @@ func NewConnector(
 		return nil, fmt.Errorf("error getting current working directory: %w", err)
 	}
 
+	if config != nil {
+		if err := atrest.Default.Protect(certDir); err != nil {
+			return nil, fmt.Errorf("error decrypting vpn certificates: %w", err)
+		}
+	}
+
 	return &Connector{
 		clusterName: clusterName,
 		certDir:     certDir,
@@ -68,7 +76,7 @@ func NewConnector(
 			Openvpn: "openvpn",
 		}),
 		faRunner: furyagent.NewRunner(executor, furyagent.Paths{
-			Furyagent: path.Join(binPath, "furyagent", faVersion, "furyagent"),
+			Furyagent: path.Join(platform.ToolPath(binPath, "furyagent", faVersion), "furyagent"),
 			WorkDir:   certDir,
 		}),
 		awsRunner: awscli.NewRunner(
@@ -132,6 +140,32 @@ func (v *Connector) GenerateCertificates() error {
 	return nil
 }
 
+// RenewServerCertificates regenerates the CA and the server certificates of the VPN, storing them in the furyagent
+// bucket. The VPN instances read them only on boot, so they have to be replaced afterwards.
+func (v *Connector) RenewServerCertificates() error {
+	logrus.Info("Generating VPN server certificates...")
+
+	if _, err := v.faRunner.Init("openvpn", "--config=furyagent.yml", "--overwrite=true"); err != nil {
+		return fmt.Errorf("error initializing openvpn certificates: %w", err)
+	}
+
+	return nil
+}
+
+// RenewClientCertificate removes the current client profile and generates a new one, signed by the current CA.
+func (v *Connector) RenewClientCertificate() error {
+	for _, p := range []string{
+		filepath.Join(v.certDir, v.clusterName+".ovpn"),
+		filepath.Join(v.workDir, v.clusterName+".ovpn"),
+	} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing openvpn file %s: %w", p, err)
+		}
+	}
+
+	return v.GenerateCertificates()
+}
+
 func (v *Connector) writeOVPNFileToDisk(certName string, cert []byte) error {
 	err := os.WriteFile(
 		filepath.Join(
@@ -5,19 +5,72 @@
 package kfddistribution
 
 import (
-	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
 
 	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/clusterpki"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
 )
 
-var ErrRenewNotSupported = errors.New("you can't renew certificates for KFDDistribution")
+type CertificatesRenewer struct {
+	configPath string
+	selective  bool
+}
+
+func (k *CertificatesRenewer) SetProperties(props []cluster.CertificatesRenewerProperty) {
+	for _, prop := range props {
+		k.SetProperty(prop.Name, prop.Value)
+	}
+}
+
+func (k *CertificatesRenewer) SetProperty(name string, value any) {
+	lcName := strings.ToLower(name)
+
+	switch lcName {
+	case cluster.CertificatesRenewerPropertyConfigPath:
+		if s, ok := value.(string); ok {
+			k.configPath = s
+		}
+
+	case cluster.CertificatesRenewerPropertyOnlyExpiringWithin:
+		if d, ok := value.(time.Duration); ok && d != 0 {
+			k.selective = true
+		}
+
+	case cluster.CertificatesRenewerPropertyNodes:
+		if s, ok := value.([]string); ok && len(s) > 0 {
+			k.selective = true
+		}
+
+	case cluster.CertificatesRenewerPropertySerial:
+		if b, ok := value.(bool); ok && b {
+			k.selective = true
+		}
+	}
+}
+
+// Renew reports the certificates of the cluster and who renews them: furyctl provisions none for KFDDistribution, the
+// Kubernetes PKI belongs to the existing cluster and the distribution ones are managed in the cluster.
+func (k *CertificatesRenewer) Renew() error {
+	if k.selective {
+		return cluster.ErrRenewOptionsNotSupported
+	}
 
-type CertificatesRenewer struct{}
+	furyctlConf, err := yamlx.FromFileV3[map[string]any](k.configPath)
+	if err != nil {
+		return fmt.Errorf("error reading configuration file: %w", err)
+	}
 
-func (*CertificatesRenewer) SetProperties(_ []cluster.CertificatesRenewerProperty) {}
+	logrus.Info("No certificates provisioned by furyctl to renew, the following ones are managed elsewhere:")
+	logrus.Info("- Kubernetes control plane and kubelet certificates: managed by the provider of the cluster")
 
-func (*CertificatesRenewer) SetProperty(_ string, _ any) {}
+	for _, c := range clusterpki.ExternallyManaged(furyctlConf, time.Now()) {
+		logrus.Infof("- %s", c)
+	}
 
-func (*CertificatesRenewer) Renew() error {
-	return ErrRenewNotSupported
+	return nil
 }
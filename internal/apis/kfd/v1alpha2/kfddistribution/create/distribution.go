@@ -5,6 +5,7 @@
 package create
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -107,9 +108,16 @@ func (*Distribution) SupportsLifecycle(lifecycle string) bool {
 	}
 }
 
-func (d *Distribution) Exec(rdcs reducers.Reducers, startFrom string, upgradeState *upgrade.State) error {
+func (d *Distribution) Exec(
+	ctx context.Context,
+	rdcs reducers.Reducers,
+	startFrom string,
+	upgradeState *upgrade.State,
+) error {
 	logrus.Info("Installing SIGHUP Distribution...")
 
+	defer cluster.StopOnCancel(ctx, cluster.OperationPhaseDistribution, d.Stop)()
+
 	mCfg, err := d.prepare()
 	if err != nil {
 		return fmt.Errorf("error preparing distribution phase: %w", err)
@@ -122,15 +130,19 @@ func (d *Distribution) Exec(rdcs reducers.Reducers, startFrom string, upgradeSta
 		return nil
 	}
 
-	if err := d.preDistribution(startFrom, upgradeState); err != nil {
+	if err := d.preDistribution(ctx, startFrom, upgradeState); err != nil {
 		return fmt.Errorf("error running pre-distribution phase: %w", err)
 	}
 
+	if err := cluster.Interrupted(ctx); err != nil {
+		return fmt.Errorf("error before running core distribution phase: %w", err)
+	}
+
 	if err := d.coreDistribution(rdcs, startFrom, upgradeState, mCfg); err != nil {
 		return fmt.Errorf("error running core distribution phase: %w", err)
 	}
 
-	if err := d.postDistribution(upgradeState); err != nil {
+	if err := d.postDistribution(ctx, upgradeState); err != nil {
 		return fmt.Errorf("error running post-distribution phase: %w", err)
 	}
 
@@ -254,11 +266,12 @@ func (d *Distribution) prepare() (template.Config, error) {
 }
 
 func (d *Distribution) preDistribution(
+	ctx context.Context,
 	startFrom string,
 	upgradeState *upgrade.State,
 ) error {
 	if startFrom == "" || startFrom == cluster.OperationSubPhasePreDistribution {
-		if err := d.upgrade.Exec(d.Path, "pre-distribution"); err != nil {
+		if err := d.upgrade.Exec(ctx, d.Path, "pre-distribution"); err != nil {
 			upgradeState.Phases.PreDistribution.Status = upgrade.PhaseStatusFailed
 
 			return fmt.Errorf("error running upgrade: %w", err)
@@ -316,9 +329,10 @@ func (d *Distribution) coreDistribution(
 }
 
 func (d *Distribution) postDistribution(
+	ctx context.Context,
 	upgradeState *upgrade.State,
 ) error {
-	if err := d.upgrade.Exec(d.Path, "post-distribution"); err != nil {
+	if err := d.upgrade.Exec(ctx, d.Path, "post-distribution"); err != nil {
 		upgradeState.Phases.PostDistribution.Status = upgrade.PhaseStatusFailed
 
 		return fmt.Errorf("error running upgrade: %w", err)
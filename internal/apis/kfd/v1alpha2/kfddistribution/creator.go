@@ -5,6 +5,7 @@
 package kfddistribution
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path"
@@ -152,7 +153,7 @@ func (*ClusterCreator) GetPhasePath(phase string) (string, error) {
 	}
 }
 
-func (c *ClusterCreator) Create(startFrom string, _, _ int) error {
+func (c *ClusterCreator) Create(ctx context.Context, startFrom string, _, _ int) error {
 	upgr := upgrade.New(c.paths, string(c.furyctlConf.Kind))
 	distributionPhase := upgrade.NewReducerOperatorPhaseDecorator[reducers.Reducers](
 		c.upgradeStateStore,
@@ -261,7 +262,7 @@ func (c *ClusterCreator) Create(startFrom string, _, _ int) error {
 			},
 		}
 
-		if err := distributionPhase.Exec(rdcs, StartFromFlagNotSet, &upgradeState); err != nil {
+		if err := distributionPhase.Exec(ctx, rdcs, StartFromFlagNotSet, &upgradeState); err != nil {
 			return fmt.Errorf("error while executing distribution phase: %w", err)
 		}
 
@@ -276,6 +277,7 @@ func (c *ClusterCreator) Create(startFrom string, _, _ int) error {
 
 	case cluster.OperationPhaseAll:
 		if err := c.allPhases(
+			ctx,
 			startFrom,
 			rdcs,
 			unsafeReducers,
@@ -312,6 +314,7 @@ func (c *ClusterCreator) Create(startFrom string, _, _ int) error {
 }
 
 func (c *ClusterCreator) allPhases(
+	ctx context.Context,
 	startFrom string,
 	rdcs reducers.Reducers,
 	unsafeReducers []distrorules.Rule,
@@ -361,11 +364,15 @@ func (c *ClusterCreator) allPhases(
 			}
 		}
 
-		if err := distributionPhase.Exec(rdcs, c.getDistributionSubPhase(startFrom), upgradeState); err != nil {
+		if err := distributionPhase.Exec(ctx, rdcs, c.getDistributionSubPhase(startFrom), upgradeState); err != nil {
 			return fmt.Errorf("error while executing distribution phase: %w", err)
 		}
 	}
 
+	if err := cluster.Interrupted(ctx); err != nil {
+		return fmt.Errorf("error before executing plugins phase: %w", err)
+	}
+
 	if distribution.HasFeature(c.kfdManifest, distribution.FeaturePlugins) {
 		if err := pluginsPhase.Exec(); err != nil {
 			return fmt.Errorf("error while executing plugins phase: %w", err)
@@ -375,7 +382,7 @@ func (c *ClusterCreator) allPhases(
 	if len(c.postApplyPhases) > 0 {
 		logrus.Info("Executing extra phases...")
 
-		if err := c.extraPhases(distributionPhase, pluginsPhase, upgradeState, upgr); err != nil {
+		if err := c.extraPhases(ctx, distributionPhase, pluginsPhase, upgradeState, upgr); err != nil {
 			return fmt.Errorf("error while executing extra phases: %w", err)
 		}
 	}
@@ -384,6 +391,7 @@ func (c *ClusterCreator) allPhases(
 }
 
 func (c *ClusterCreator) extraPhases(
+	ctx context.Context,
 	distributionPhase upgrade.ReducersOperatorPhase[reducers.Reducers],
 	pluginsPhase *commcreate.Plugins,
 	upgradeState *upgrade.State,
@@ -396,11 +404,15 @@ func (c *ClusterCreator) extraPhases(
 	}()
 
 	for _, phase := range c.postApplyPhases {
+		if err := cluster.Interrupted(ctx); err != nil {
+			return fmt.Errorf("error before executing %s post-apply phase: %w", phase, err)
+		}
+
 		switch phase {
 		case cluster.OperationPhaseDistribution:
 			distributionPhase.SetUpgrade(false)
 
-			if err := distributionPhase.Exec(reducers.Reducers{}, StartFromFlagNotSet, upgradeState); err != nil {
+			if err := distributionPhase.Exec(ctx, reducers.Reducers{}, StartFromFlagNotSet, upgradeState); err != nil {
 				return fmt.Errorf("error while executing distribution phase: %w", err)
 			}
 
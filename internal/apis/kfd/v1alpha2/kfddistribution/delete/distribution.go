@@ -31,12 +31,13 @@ type Ingress struct {
 type Distribution struct {
 	*cluster.OperationPhase
 
-	furyctlConf public.KfddistributionKfdV1Alpha2
-	kubeRunner  *kubectl.Runner
-	shellRunner *shell.Runner
-	dryRun      bool
-	paths       cluster.DeleterPaths
-	stateStore  state.Storer
+	furyctlConf  public.KfddistributionKfdV1Alpha2
+	kubeRunner   *kubectl.Runner
+	shellRunner  *shell.Runner
+	dryRun       bool
+	paths        cluster.DeleterPaths
+	stateStore   state.Storer
+	deletionPlan *cluster.DeletionPlan
 }
 
 func NewDistribution(
@@ -44,6 +45,7 @@ func NewDistribution(
 	dryRun bool,
 	kfdManifest config.KFD,
 	paths cluster.DeleterPaths,
+	deletionPlan *cluster.DeletionPlan,
 ) *Distribution {
 	phaseOp := cluster.NewOperationPhase(
 		path.Join(paths.WorkDir, cluster.OperationPhaseDistribution),
@@ -80,6 +82,7 @@ func NewDistribution(
 			kfdManifest.Tools.Common.Kubectl.Version,
 			paths.BinPath,
 		),
+		deletionPlan: deletionPlan,
 	}
 }
 
@@ -156,6 +159,14 @@ func (d *Distribution) Exec() error {
 	}
 
 	if d.dryRun {
+		if err := d.deletionPlan.AddKustomization(
+			cluster.OperationPhaseDistribution,
+			d.KustomizePath,
+			path.Join(d.Path, "manifests"),
+		); err != nil {
+			return fmt.Errorf("error reading distribution manifests: %w", err)
+		}
+
 		logrus.Info("SIGHUP Distribution deleted successfully (dry-run mode)")
 
 		return nil
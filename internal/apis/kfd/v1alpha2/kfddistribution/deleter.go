@@ -15,11 +15,13 @@ import (
 )
 
 type ClusterDeleter struct {
-	paths       cluster.DeleterPaths
-	kfdManifest config.KFD
-	furyctlConf public.KfddistributionKfdV1Alpha2
-	phase       string
-	dryRun      bool
+	paths        cluster.DeleterPaths
+	kfdManifest  config.KFD
+	furyctlConf  public.KfddistributionKfdV1Alpha2
+	phase        string
+	dryRun       bool
+	deletionPlan *cluster.DeletionPlan
+	preDelete    cluster.PreDeleteFunc
 }
 
 func (d *ClusterDeleter) SetProperties(props []cluster.DeleterProperty) {
@@ -71,6 +73,16 @@ func (d *ClusterDeleter) SetProperty(name string, value any) {
 		if b, ok := value.(bool); ok {
 			d.dryRun = b
 		}
+
+	case cluster.DeleterPropertyDeletionPlan:
+		if p, ok := value.(*cluster.DeletionPlan); ok {
+			d.deletionPlan = p
+		}
+
+	case cluster.DeleterPropertyPreDelete:
+		if f, ok := value.(cluster.PreDeleteFunc); ok {
+			d.preDelete = f
+		}
 	}
 }
 
@@ -85,7 +97,13 @@ func (d *ClusterDeleter) Delete() error {
 		return fmt.Errorf("error while executing preflight phase: %w", err)
 	}
 
-	distro := del.NewDistribution(d.furyctlConf, d.dryRun, d.kfdManifest, d.paths)
+	if d.preDelete != nil {
+		if err := d.preDelete(); err != nil {
+			return fmt.Errorf("error while preparing the deletion: %w", err)
+		}
+	}
+
+	distro := del.NewDistribution(d.furyctlConf, d.dryRun, d.kfdManifest, d.paths, d.deletionPlan)
 
 	if err := distro.Exec(); err != nil {
 		return fmt.Errorf("error while deleting distribution: %w", err)
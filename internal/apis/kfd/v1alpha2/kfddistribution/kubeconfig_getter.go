@@ -15,6 +15,7 @@ import (
 
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
 	"github.com/sighupio/fury-distribution/pkg/apis/kfddistribution/v1alpha2/public"
+	"github.com/sighupio/furyctl/internal/atrest"
 	"github.com/sighupio/furyctl/internal/cluster"
 	"github.com/sighupio/furyctl/internal/distribution"
 	"github.com/sighupio/furyctl/internal/parser"
@@ -89,6 +90,10 @@ func (k *KubeconfigGetter) Get() error {
 
 	kubeconfigPath = path.Join(k.workDir, "kubeconfig")
 
+	if err := atrest.Default.ProtectFile(kubeconfigPath); err != nil {
+		return fmt.Errorf("error protecting kubeconfig file: %w", err)
+	}
+
 	if err := os.WriteFile(kubeconfigPath, kubeconfig, iox.FullRWPermAccess); err != nil {
 		return fmt.Errorf("error writing kubeconfig file: %w", err)
 	}
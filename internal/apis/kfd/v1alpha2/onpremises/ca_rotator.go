@@ -0,0 +1,582 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package onpremises
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/sighupio/fury-distribution/pkg/apis/config"
+	"github.com/sighupio/fury-distribution/pkg/apis/onpremises/v1alpha2/public"
+	"github.com/sighupio/furyctl/internal/atrest"
+	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/clusterpki"
+	"github.com/sighupio/furyctl/internal/tool/ansible"
+	"github.com/sighupio/furyctl/internal/upgrade"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+)
+
+const (
+	// updateKubeconfigsCAScript embeds the CA certificates of the node into the kubeconfig files of the components.
+	updateKubeconfigsCAScript = `for f in /etc/kubernetes/*.conf; do ` +
+		`c=$(kubectl config view --kubeconfig="$f" -o jsonpath='{.clusters[0].name}') && ` +
+		`kubectl config set-cluster "$c" --kubeconfig="$f" ` +
+		`--certificate-authority=/etc/kubernetes/pki/ca.crt --embed-certs || exit 1; done`
+
+	restartEtcdScript = "systemctl restart etcd && systemctl is-active --quiet etcd"
+
+	// restartControlPlaneScript stops the containers of the control plane, that the kubelet starts again, and waits
+	// for the API server to be running.
+	restartControlPlaneScript = `crictl ps -q --name 'kube-(apiserver|controller-manager|scheduler)' | ` +
+		`xargs -r crictl stop && timeout 300 sh -c ` +
+		`'until crictl ps -q --state running --name kube-apiserver | grep -q .; do sleep 5; done'`
+
+	restartKubeletScript = "systemctl restart kubelet"
+
+	// kubeletNodeNameScript prints the name the node is registered with, read from its kubelet client certificate.
+	kubeletNodeNameScript = `openssl x509 -in /var/lib/kubelet/pki/kubelet-client-current.pem -noout -subject ` +
+		`-nameopt RFC2253 | sed -n 's/.*CN=system:node:\([^,]*\).*/\1/p'`
+
+	// generateKubeletCSRScript generates on the node a new key for the kubelet of the node named %[1]s, kept at
+	// %[2]s, and prints the certificate signing request only, base64 encoded.
+	generateKubeletCSRScript = `umask 077 && openssl req -new -newkey rsa:2048 -nodes -keyout %[2]s ` +
+		`-subj "/O=system:nodes/CN=system:node:%[1]s" 2>/dev/null | base64 -w0`
+
+	// signKubeletCSRScript signs the base64 encoded certificate signing request %[1]s with the Kubernetes CA,
+	// printing the certificate base64 encoded.
+	signKubeletCSRScript = `d=$(mktemp -d) && echo '%[1]s' | base64 -d > "$d/kubelet.csr" && ` +
+		`openssl x509 -req -in "$d/kubelet.csr" -CA /etc/kubernetes/pki/ca.crt -CAkey /etc/kubernetes/pki/ca.key ` +
+		`-set_serial "0x$(openssl rand -hex 16)" -days 365 -out "$d/kubelet.crt" 2>/dev/null && ` +
+		`base64 -w0 "$d/kubelet.crt"; rc=$?; rm -rf "$d"; exit $rc`
+
+	// installKubeletCertScript joins the certificate %[1]s and the key %[2]s in the kubelet client file %[3]s, that
+	// the kubelet uses through the %[4]s link.
+	installKubeletCertScript = `umask 077 && cat %[1]s %[2]s > %[3]s && rm -f %[1]s %[2]s && ln -sf %[3]s %[4]s`
+
+	kubeletClientCertPath        = "/var/lib/kubelet/pki/kubelet-client-current.pem"
+	kubeletClientRotatedCertPath = "/var/lib/kubelet/pki/kubelet-client-rotated.pem"
+	kubeletClientRotatedCrtPath  = "/var/lib/kubelet/pki/kubelet-client-rotated.crt"
+	kubeletClientRotatedKeyPath  = "/var/lib/kubelet/pki/kubelet-client-rotated.key"
+)
+
+var ErrNodeNameNotFound = errors.New("node name not found in the kubelet client certificate")
+
+// caTarget is where the certificate and the key of a CA are copied on the nodes.
+type caTarget struct {
+	name     string
+	hosts    string
+	crtPath  string
+	keyHosts string
+	keyPath  string
+}
+
+// caTargets lists the nodes' CAs, in the same order as the rotations returned by clusterpki.CARotations.
+//
+//nolint:gochecknoglobals // Read-only table.
+var caTargets = []caTarget{
+	{
+		name:     "kubernetes",
+		hosts:    "master,nodes",
+		crtPath:  "/etc/kubernetes/pki/ca.crt",
+		keyHosts: "master",
+		keyPath:  "/etc/kubernetes/pki/ca.key",
+	},
+	{
+		name:     "etcd",
+		hosts:    "master,etcd",
+		crtPath:  "/etc/etcd/pki/etcd/ca.crt",
+		keyHosts: "etcd",
+		keyPath:  "/etc/etcd/pki/etcd/ca.key",
+	},
+	{
+		name:     "front-proxy",
+		hosts:    "master",
+		crtPath:  "/etc/kubernetes/pki/front-proxy-ca.crt",
+		keyHosts: "master",
+		keyPath:  "/etc/kubernetes/pki/front-proxy-ca.key",
+	},
+}
+
+type CARotator struct {
+	*cluster.OperationPhase
+	furyctlConf public.OnpremisesKfdV1Alpha2
+	kfdManifest config.KFD
+	distroPath  string
+	configPath  string
+	workDir     string
+
+	ansibleRunner *ansible.Runner
+	tmpDir        string
+	rotations     []clusterpki.CARotation
+}
+
+func (k *CARotator) SetProperties(props []cluster.CARotatorProperty) {
+	for _, prop := range props {
+		k.SetProperty(prop.Name, prop.Value)
+	}
+
+	k.OperationPhase = &cluster.OperationPhase{}
+}
+
+func (k *CARotator) SetProperty(name string, value any) {
+	lcName := strings.ToLower(name)
+
+	switch lcName {
+	case cluster.CARotatorPropertyFuryctlConf:
+		if s, ok := value.(public.OnpremisesKfdV1Alpha2); ok {
+			k.furyctlConf = s
+		}
+
+	case cluster.CARotatorPropertyConfigPath:
+		if s, ok := value.(string); ok {
+			k.configPath = s
+		}
+
+	case cluster.CARotatorPropertyKfdManifest:
+		if s, ok := value.(config.KFD); ok {
+			k.kfdManifest = s
+		}
+
+	case cluster.CARotatorPropertyDistroPath:
+		if s, ok := value.(string); ok {
+			k.distroPath = s
+		}
+
+	case cluster.CARotatorPropertyWorkDir:
+		if s, ok := value.(string); ok {
+			k.workDir = s
+		}
+	}
+}
+
+// Rotate replaces the Kubernetes, etcd and front-proxy CAs in four steps: the nodes trust both CAs while the
+// certificates are re-issued, so that the cluster keeps working during the rotation. The state of each step is stored
+// in the cluster and a failed rotation is resumed from the first step not completed when run again.
+func (k *CARotator) Rotate() error {
+	pkiFolder, err := resolvePKIFolder(k.furyctlConf.Spec.Kubernetes.PkiFolder, k.configPath)
+	if err != nil {
+		return err
+	}
+
+	if err := atrest.Default.Protect(pkiFolder); err != nil {
+		return fmt.Errorf("error while decrypting PKI folder: %w", err)
+	}
+
+	k.rotations = clusterpki.CARotations(pkiFolder, clusterpki.DefaultCertConfig())
+
+	k.tmpDir, err = os.MkdirTemp("", "fury-ca-rotator-*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary directory: %w", err)
+	}
+
+	defer os.RemoveAll(k.tmpDir)
+
+	k.ansibleRunner = ansible.NewRunner(
+		execx.NewStdExecutor(),
+		ansible.Paths{
+			Ansible:         "ansible",
+			AnsiblePlaybook: "ansible-playbook",
+			WorkDir:         k.tmpDir,
+		},
+	)
+
+	if err := renderKubernetesTemplates(
+		k.OperationPhase,
+		k.kfdManifest,
+		k.distroPath,
+		k.configPath,
+		k.tmpDir,
+	); err != nil {
+		return err
+	}
+
+	if _, err := k.ansibleRunner.Exec("all", "-m", "ping"); err != nil {
+		return fmt.Errorf("error checking hosts: %w", err)
+	}
+
+	client, err := adminClient(k.ansibleRunner, k.tmpDir)
+	if err != nil {
+		return err
+	}
+
+	rotationState, err := upgrade.NewCARotationStore(client).Get()
+	if err != nil {
+		return fmt.Errorf("error while getting CA rotation state: %w", err)
+	}
+
+	steps := []struct {
+		description string
+		phase       *upgrade.Phase
+		run         func() error
+	}{
+		{"generating the new CAs", rotationState.Steps.GenerateCA, k.generateCA},
+		{"distributing the old and new CAs", rotationState.Steps.DistributeTrustBundle, k.distributeTrustBundle},
+		{"re-issuing the certificates with the new CAs", rotationState.Steps.ReissueCertificates, k.reissueCertificates},
+		{"removing the old CAs", rotationState.Steps.RemoveOldCA, k.removeOldCA},
+	}
+
+	for i, step := range steps {
+		if step.phase.Status == upgrade.PhaseStatusSuccess {
+			logrus.Infof("Step %d of %d, %s, already completed, skipping", i+1, len(steps), step.description)
+
+			continue
+		}
+
+		logrus.Infof("Step %d of %d, %s...", i+1, len(steps), step.description)
+
+		stepErr := step.run()
+
+		step.phase.Status = upgrade.PhaseStatusSuccess
+		if stepErr != nil {
+			step.phase.Status = upgrade.PhaseStatusFailed
+		}
+
+		if err := k.storeState(rotationState); err != nil {
+			if stepErr != nil {
+				return fmt.Errorf("%w, %w", stepErr, err)
+			}
+
+			return err
+		}
+
+		if stepErr != nil {
+			return stepErr
+		}
+	}
+
+	// The API server is now serving with the new CA, a new client is needed.
+	client, err = adminClient(k.ansibleRunner, k.tmpDir)
+	if err != nil {
+		return err
+	}
+
+	if err := upgrade.NewCARotationStore(client).Delete(); err != nil {
+		return fmt.Errorf("error while deleting CA rotation state: %w", err)
+	}
+
+	return nil
+}
+
+// storeState stores the state of the rotation through a new client, since the CA of the cluster changes from one
+// step to the other.
+func (k *CARotator) storeState(rotationState *upgrade.CARotationState) error {
+	client, err := adminClient(k.ansibleRunner, k.tmpDir)
+	if err != nil {
+		return err
+	}
+
+	if err := upgrade.NewCARotationStore(client).Store(rotationState); err != nil {
+		return fmt.Errorf("error while storing CA rotation state: %w", err)
+	}
+
+	return nil
+}
+
+func (k *CARotator) generateCA() error {
+	for _, r := range k.rotations {
+		if err := r.Generate(); err != nil {
+			return fmt.Errorf("error while generating CA: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// distributeTrustBundle makes the nodes and the kubeconfigs trust both the old and the new CAs, the old one still
+// signing.
+func (k *CARotator) distributeTrustBundle() error {
+	if err := k.copyCAs(clusterpki.CARotation.Bundle, false); err != nil {
+		return err
+	}
+
+	return k.restartComponents()
+}
+
+// reissueCertificates makes the new CAs the signing ones and re-issues the certificates of the control plane, etcd
+// and the kubelets with them.
+func (k *CARotator) reissueCertificates() error {
+	for _, r := range k.rotations {
+		if err := r.Promote(); err != nil {
+			return fmt.Errorf("error while promoting CA: %w", err)
+		}
+	}
+
+	if err := k.copyCAs(clusterpki.CARotation.Bundle, true); err != nil {
+		return err
+	}
+
+	if _, err := k.ansibleRunner.Playbook("98.cluster-certificates-renewal.yaml"); err != nil {
+		return fmt.Errorf("error re-issuing certificates: %w", err)
+	}
+
+	if err := waitForAPIServer(k.ansibleRunner); err != nil {
+		return err
+	}
+
+	return k.reissueKubeletCertificates()
+}
+
+// removeOldCA makes the nodes and the kubeconfigs trust only the new CAs, then removes the old ones from the PKI
+// folder.
+func (k *CARotator) removeOldCA() error {
+	if err := k.updateLocalKubeconfigs(); err != nil {
+		return err
+	}
+
+	if err := k.copyCAs(clusterpki.CARotation.Current, false); err != nil {
+		return err
+	}
+
+	if err := k.restartComponents(); err != nil {
+		return err
+	}
+
+	for _, r := range k.rotations {
+		if err := r.Complete(); err != nil {
+			return fmt.Errorf("error while completing CA rotation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// updateLocalKubeconfigs replaces the admin.conf of the kubernetes phase folder and the kubeconfig of the working
+// directory, when they exist, with the admin kubeconfig re-issued on the nodes, trusting only the new Kubernetes CA,
+// as the old one is about to be removed.
+func (k *CARotator) updateLocalKubeconfigs() error {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("error getting current dir: %w", err)
+	}
+
+	paths := []string{}
+
+	for _, p := range []string{
+		filepath.Join(k.workDir, cluster.OperationPhaseKubernetes, "admin.conf"),
+		filepath.Join(currentDir, "kubeconfig"),
+	} {
+		if fileExists(p) || fileExists(p+atrest.FileExt) {
+			paths = append(paths, p)
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil
+	}
+
+	fetchedPath := filepath.Join(k.tmpDir, "admin-rotated.conf")
+
+	if err := fetchAdminKubeconfig(k.ansibleRunner, fetchedPath); err != nil {
+		return err
+	}
+
+	kubeconfig, err := clientcmd.LoadFromFile(fetchedPath)
+	if err != nil {
+		return fmt.Errorf("error loading kubeconfig: %w", err)
+	}
+
+	ca, err := k.rotations[0].Current()
+	if err != nil {
+		return err
+	}
+
+	for _, c := range kubeconfig.Clusters {
+		c.CertificateAuthorityData = ca
+	}
+
+	out, err := clientcmd.Write(*kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error encoding kubeconfig: %w", err)
+	}
+
+	for _, p := range paths {
+		logrus.Infof("Updating %s with the new CA...", p)
+
+		if err := atrest.Default.ProtectFile(p); err != nil {
+			return fmt.Errorf("error protecting kubeconfig file: %w", err)
+		}
+
+		if err := os.WriteFile(p, out, 0o600); err != nil {
+			return fmt.Errorf("error writing kubeconfig file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// copyCAs copies the CA certificates returned by crtFn to the nodes, along with the keys when withKeys is set, and
+// embeds the Kubernetes ones into the kubeconfigs of the nodes.
+func (k *CARotator) copyCAs(crtFn func(clusterpki.CARotation) ([]byte, error), withKeys bool) error {
+	for i, target := range caTargets {
+		crt, err := crtFn(k.rotations[i])
+		if err != nil {
+			return err
+		}
+
+		crtPath := filepath.Join(k.tmpDir, target.name+"-"+clusterpki.CaCrt)
+		if err := os.WriteFile(crtPath, crt, 0o600); err != nil {
+			return fmt.Errorf("error writing %s CA certificate: %w", target.name, err)
+		}
+
+		if err := k.copyFile(target.hosts, crtPath, target.crtPath, "0644"); err != nil {
+			return err
+		}
+
+		if withKeys {
+			if err := k.copyFile(target.keyHosts, k.rotations[i].KeyPath(), target.keyPath, "0600"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := k.ansibleRunner.Exec(
+		"master,nodes",
+		"--become",
+		"-m",
+		"shell",
+		"-a",
+		updateKubeconfigsCAScript,
+	); err != nil {
+		return fmt.Errorf("error updating CA certificates in kubeconfigs: %w", err)
+	}
+
+	return nil
+}
+
+// execOneline runs script on hosts, that must match a single host, and returns its trimmed output.
+func (k *CARotator) execOneline(hosts, script string) (string, error) {
+	out, err := k.ansibleRunner.Exec(hosts, "--become", "--one-line", "-m", "shell", "-a", script)
+	if err != nil {
+		return "", fmt.Errorf("error running script on %s: %w", hosts, err)
+	}
+
+	results := ansible.ParseOneline(out)
+	if len(results) == 0 || strings.TrimSpace(results[0].Stdout) == "" {
+		return "", fmt.Errorf("%w %s", ErrMalformedNodeOutput, hosts)
+	}
+
+	return strings.TrimSpace(results[0].Stdout), nil
+}
+
+func (k *CARotator) copyFile(hosts, src, dest, mode string) error {
+	if _, err := k.ansibleRunner.Exec(
+		hosts,
+		"--become",
+		"-m",
+		"copy",
+		"-a",
+		fmt.Sprintf("src=%s dest=%s owner=root group=root mode=%s", src, dest, mode),
+	); err != nil {
+		return fmt.Errorf("error copying %s to %s: %w", filepath.Base(src), dest, err)
+	}
+
+	return nil
+}
+
+// restartComponents restarts etcd, the control plane and the kubelets one node at a time, so that they load the
+// CA certificates copied on the nodes.
+func (k *CARotator) restartComponents() error {
+	for _, restart := range []struct {
+		hosts  string
+		script string
+	}{
+		{hosts: "etcd", script: restartEtcdScript},
+		{hosts: "master", script: restartControlPlaneScript},
+		{hosts: "master,nodes", script: restartKubeletScript},
+	} {
+		if _, err := k.ansibleRunner.Exec(
+			restart.hosts,
+			"--become",
+			"--forks",
+			"1",
+			"-m",
+			"shell",
+			"-a",
+			restart.script,
+		); err != nil {
+			return fmt.Errorf("error restarting components on %s: %w", restart.hosts, err)
+		}
+	}
+
+	return waitForAPIServer(k.ansibleRunner)
+}
+
+// reissueKubeletCertificates signs a new client certificate for the kubelet of every node on the first control plane
+// node, since the kubelets would otherwise keep authenticating with a certificate of the old CA until its rotation.
+func (k *CARotator) reissueKubeletCertificates() error {
+	out, err := k.ansibleRunner.Exec("master,nodes", "--become", "--one-line", "-m", "shell", "-a", kubeletNodeNameScript)
+	if err != nil {
+		return fmt.Errorf("error reading kubelet client certificates: %w", err)
+	}
+
+	for _, res := range ansible.ParseOneline(out) {
+		nodeName := strings.TrimSpace(res.Stdout)
+		if nodeName == "" {
+			return fmt.Errorf("%w on host %s", ErrNodeNameNotFound, res.Host)
+		}
+
+		logrus.Infof("Re-issuing kubelet client certificate of node %s...", nodeName)
+
+		// The key never leaves the node: only the certificate signing request and the certificate go through furyctl.
+		csr, err := k.execOneline(
+			res.Host,
+			fmt.Sprintf(generateKubeletCSRScript, nodeName, kubeletClientRotatedKeyPath),
+		)
+		if err != nil {
+			return fmt.Errorf("error generating kubelet client key of node %s: %w", nodeName, err)
+		}
+
+		crt, err := k.execOneline("master[0]", fmt.Sprintf(signKubeletCSRScript, csr))
+		if err != nil {
+			return fmt.Errorf("error signing kubelet client certificate of node %s: %w", nodeName, err)
+		}
+
+		pemData, err := base64.StdEncoding.DecodeString(crt)
+		if err != nil {
+			return fmt.Errorf("%w %s: %w", ErrMalformedNodeOutput, res.Host, err)
+		}
+
+		pemPath := filepath.Join(k.tmpDir, res.Host+"-kubelet-client.crt")
+		if err := os.WriteFile(pemPath, pemData, 0o600); err != nil {
+			return fmt.Errorf("error writing kubelet client certificate of node %s: %w", nodeName, err)
+		}
+
+		if err := k.copyFile(res.Host, pemPath, kubeletClientRotatedCrtPath, "0600"); err != nil {
+			return err
+		}
+
+		if _, err := k.ansibleRunner.Exec(
+			res.Host,
+			"--become",
+			"-m",
+			"shell",
+			"-a",
+			fmt.Sprintf(
+				installKubeletCertScript,
+				kubeletClientRotatedCrtPath,
+				kubeletClientRotatedKeyPath,
+				kubeletClientRotatedCertPath,
+				kubeletClientCertPath,
+			)+" && "+restartKubeletScript,
+		); err != nil {
+			return fmt.Errorf("error switching kubelet client certificate of node %s: %w", nodeName, err)
+		}
+	}
+
+	return nil
+}
+
+func fileExists(p string) bool {
+	_, err := os.Stat(p)
+
+	return err == nil
+}
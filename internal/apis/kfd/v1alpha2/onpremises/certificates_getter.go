@@ -0,0 +1,201 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package onpremises
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sighupio/fury-distribution/pkg/apis/config"
+	"github.com/sighupio/fury-distribution/pkg/apis/onpremises/v1alpha2/public"
+	"github.com/sighupio/furyctl/internal/atrest"
+	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/clusterpki"
+	"github.com/sighupio/furyctl/internal/tool/ansible"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+)
+
+const (
+	// nodeCertificatesScript prints the path and the base64 encoded content of every certificate of the control
+	// plane, etcd and kubelet, one per line. The kubelet client certificate is a link to the last rotated one, so
+	// links are followed.
+	nodeCertificatesScript = `find -L /etc/kubernetes/pki /etc/etcd/pki /var/lib/kubelet/pki -type f ` +
+		`\( -name '*.crt' -o -name 'kubelet-client-current.pem' \) 2>/dev/null | sort | ` +
+		`while read -r f; do echo "$f $(base64 -w0 "$f")"; done`
+
+	// nodeGroups is the pattern matching the control plane, etcd and worker nodes of the inventory.
+	nodeGroups = "master,etcd,nodes"
+)
+
+var ErrMalformedNodeOutput = errors.New("malformed output while reading certificates from node")
+
+type CertificatesGetter struct {
+	*cluster.OperationPhase
+	furyctlConf public.OnpremisesKfdV1Alpha2
+	kfdManifest config.KFD
+	distroPath  string
+	configPath  string
+	skipNodes   bool
+}
+
+func (k *CertificatesGetter) SetProperties(props []cluster.CertificatesGetterProperty) {
+	for _, prop := range props {
+		k.SetProperty(prop.Name, prop.Value)
+	}
+
+	k.OperationPhase = &cluster.OperationPhase{}
+}
+
+func (k *CertificatesGetter) SetProperty(name string, value any) {
+	lcName := strings.ToLower(name)
+
+	switch lcName {
+	case cluster.CertificatesGetterPropertyFuryctlConf:
+		if s, ok := value.(public.OnpremisesKfdV1Alpha2); ok {
+			k.furyctlConf = s
+		}
+
+	case cluster.CertificatesGetterPropertyConfigPath:
+		if s, ok := value.(string); ok {
+			k.configPath = s
+		}
+
+	case cluster.CertificatesGetterPropertyKfdManifest:
+		if s, ok := value.(config.KFD); ok {
+			k.kfdManifest = s
+		}
+
+	case cluster.CertificatesGetterPropertyDistroPath:
+		if s, ok := value.(string); ok {
+			k.distroPath = s
+		}
+
+	case cluster.CertificatesGetterPropertySkipNodes:
+		if b, ok := value.(bool); ok {
+			k.skipNodes = b
+		}
+	}
+}
+
+func (k *CertificatesGetter) Get() ([]clusterpki.Certificate, error) {
+	now := time.Now()
+	certs := []clusterpki.Certificate{}
+
+	if k.furyctlConf.Spec.Kubernetes.PkiFolder != "" {
+		pkiFolder, err := resolvePKIFolder(k.furyctlConf.Spec.Kubernetes.PkiFolder, k.configPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := atrest.Default.Protect(pkiFolder); err != nil {
+			return nil, fmt.Errorf("error while decrypting PKI folder: %w", err)
+		}
+
+		logrus.Infof("Inspecting local PKI folder %s...", pkiFolder)
+
+		localCerts, err := clusterpki.Inspect(pkiFolder, now)
+		if err != nil {
+			return nil, fmt.Errorf("error while inspecting local PKI: %w", err)
+		}
+
+		certs = append(certs, localCerts...)
+	}
+
+	if k.skipNodes {
+		return certs, nil
+	}
+
+	nodeCerts, err := k.getNodesCertificates(now)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(certs, nodeCerts...), nil
+}
+
+// getNodesCertificates reads the certificates of the control plane, etcd and worker nodes through the Ansible
+// inventory.
+func (k *CertificatesGetter) getNodesCertificates(now time.Time) ([]clusterpki.Certificate, error) {
+	tmpDir, err := os.MkdirTemp("", "fury-certificates-getter-*")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary directory: %w", err)
+	}
+
+	defer os.RemoveAll(tmpDir)
+
+	if err := renderKubernetesTemplates(k.OperationPhase, k.kfdManifest, k.distroPath, k.configPath, tmpDir); err != nil {
+		return nil, err
+	}
+
+	ansibleRunner := ansible.NewRunner(
+		execx.NewStdExecutor(),
+		ansible.Paths{
+			Ansible:         "ansible",
+			AnsiblePlaybook: "ansible-playbook",
+			WorkDir:         tmpDir,
+		},
+	)
+
+	return readNodesCertificates(ansibleRunner, now)
+}
+
+// readNodesCertificates reads the certificates of the control plane, etcd and worker nodes of the inventory in the
+// working directory of the runner.
+func readNodesCertificates(ansibleRunner *ansible.Runner, now time.Time) ([]clusterpki.Certificate, error) {
+	logrus.Info("Reading certificates from the control plane, etcd and worker nodes...")
+
+	out, err := ansibleRunner.Exec(nodeGroups, "--become", "--one-line", "-m", "shell", "-a", nodeCertificatesScript)
+	if err != nil {
+		return nil, fmt.Errorf("error reading certificates from nodes: %w", err)
+	}
+
+	return ParseNodesCertificates(ansible.ParseOneline(out), now)
+}
+
+// ParseNodesCertificates parses the certificates printed by each node, deduplicating the nodes that are both in the
+// control plane, etcd and worker groups.
+func ParseNodesCertificates(results []ansible.HostResult, now time.Time) ([]clusterpki.Certificate, error) {
+	certs := []clusterpki.Certificate{}
+	seen := map[string]bool{}
+
+	for _, res := range results {
+		if seen[res.Host] {
+			continue
+		}
+
+		seen[res.Host] = true
+
+		for _, line := range strings.Split(res.Stdout, "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+
+			certPath, encoded, ok := strings.Cut(line, " ")
+			if !ok {
+				return nil, fmt.Errorf("%w %s: %q", ErrMalformedNodeOutput, res.Host, line)
+			}
+
+			data, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("%w %s: %w", ErrMalformedNodeOutput, res.Host, err)
+			}
+
+			nodeCerts, err := clusterpki.ParseCertificates(data, res.Host, certPath, now)
+			if err != nil {
+				return nil, fmt.Errorf("error while reading certificates from node %s: %w", res.Host, err)
+			}
+
+			certs = append(certs, nodeCerts...)
+		}
+	}
+
+	return certs, nil
+}
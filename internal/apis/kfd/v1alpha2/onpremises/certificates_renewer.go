@@ -5,27 +5,45 @@
 package onpremises
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
 	"github.com/sighupio/fury-distribution/pkg/apis/onpremises/v1alpha2/public"
 	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/clusterpki"
+	"github.com/sighupio/furyctl/internal/state"
 	"github.com/sighupio/furyctl/internal/tool/ansible"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
+	kubex "github.com/sighupio/furyctl/internal/x/kube"
 	"github.com/sighupio/furyctl/pkg/template"
 )
 
+const (
+	apiServerHealthRetries  = 30
+	apiServerHealthInterval = 10 * time.Second
+)
+
+var ErrAPIServerNotHealthy = errors.New("API server is not healthy")
+
 type CertificatesRenewer struct {
 	*cluster.OperationPhase
 	furyctlConf public.OnpremisesKfdV1Alpha2
 	kfdManifest config.KFD
 	distroPath  string
 	configPath  string
+
+	onlyExpiringWithin time.Duration
+	nodes              []string
+	serial             bool
 }
 
 func (k *CertificatesRenewer) SetProperties(props []cluster.CertificatesRenewerProperty) {
@@ -59,6 +77,21 @@ func (k *CertificatesRenewer) SetProperty(name string, value any) {
 		if s, ok := value.(string); ok {
 			k.distroPath = s
 		}
+
+	case cluster.CertificatesRenewerPropertyOnlyExpiringWithin:
+		if d, ok := value.(time.Duration); ok {
+			k.onlyExpiringWithin = d
+		}
+
+	case cluster.CertificatesRenewerPropertyNodes:
+		if s, ok := value.([]string); ok {
+			k.nodes = s
+		}
+
+	case cluster.CertificatesRenewerPropertySerial:
+		if b, ok := value.(bool); ok {
+			k.serial = b
+		}
 	}
 }
 
@@ -81,9 +114,234 @@ func (k *CertificatesRenewer) Renew() error {
 		},
 	)
 
-	furyctlMerger, err := k.CreateFuryctlMerger(
-		k.distroPath,
-		k.configPath,
+	if err := renderKubernetesTemplates(k.OperationPhase, k.kfdManifest, k.distroPath, k.configPath, tmpDir); err != nil {
+		return err
+	}
+
+	if _, err := ansibleRunner.Exec("all", "-m", "ping"); err != nil {
+		return fmt.Errorf("error checking hosts: %w", err)
+	}
+
+	before, err := readNodesCertificates(ansibleRunner, time.Now())
+	if err != nil {
+		return err
+	}
+
+	nodes := k.targetNodes(before)
+	if nodes == nil && k.serial {
+		if nodes, err = listHosts(ansibleRunner); err != nil {
+			return err
+		}
+	}
+
+	if nodes != nil && len(nodes) == 0 {
+		logrus.Info("No certificates to renew")
+
+		return nil
+	}
+
+	if err := k.renewNodes(ansibleRunner, nodes); err != nil {
+		return err
+	}
+
+	after, err := readNodesCertificates(ansibleRunner, time.Now())
+	if err != nil {
+		return err
+	}
+
+	renewal := state.CertificatesRenewal{
+		Date:         time.Now().UTC(),
+		Nodes:        nodes,
+		Certificates: renewedCertificates(before, after),
+	}
+
+	logrus.Infof("%d certificates renewed", len(renewal.Certificates))
+
+	if err := recordRenewal(ansibleRunner, tmpDir, k.configPath, renewal); err != nil {
+		logrus.Warnf("Certificates renewed, but the renewal could not be recorded in the cluster: %v", err)
+	}
+
+	return nil
+}
+
+// targetNodes returns the nodes whose certificates have to be renewed, or nil when all of them have to.
+func (k *CertificatesRenewer) targetNodes(certs []clusterpki.Certificate) []string {
+	if k.onlyExpiringWithin == 0 {
+		if len(k.nodes) == 0 {
+			return nil
+		}
+
+		return k.nodes
+	}
+
+	expiring := clusterpki.NodesExpiringWithin(certs, k.onlyExpiringWithin, time.Now())
+	if len(k.nodes) == 0 {
+		return expiring
+	}
+
+	return slices.DeleteFunc(expiring, func(node string) bool {
+		return !slices.Contains(k.nodes, node)
+	})
+}
+
+// renewNodes runs the renewal playbook limited to nodes, all of them when nodes is nil. In serial mode the nodes are
+// renewed one at a time, waiting for the API server to be healthy before moving to the next one.
+func (k *CertificatesRenewer) renewNodes(ansibleRunner *ansible.Runner, nodes []string) error {
+	if nodes == nil {
+		if _, err := ansibleRunner.Playbook("98.cluster-certificates-renewal.yaml"); err != nil {
+			return fmt.Errorf("error renewing certificates: %w", err)
+		}
+
+		return nil
+	}
+
+	if !k.serial {
+		logrus.Infof("Renewing certificates on nodes %s...", strings.Join(nodes, ", "))
+
+		if _, err := ansibleRunner.Playbook(
+			"98.cluster-certificates-renewal.yaml",
+			"--limit",
+			strings.Join(nodes, ","),
+		); err != nil {
+			return fmt.Errorf("error renewing certificates: %w", err)
+		}
+
+		return nil
+	}
+
+	for _, node := range nodes {
+		logrus.Infof("Renewing certificates on node %s...", node)
+
+		if _, err := ansibleRunner.Playbook("98.cluster-certificates-renewal.yaml", "--limit", node); err != nil {
+			return fmt.Errorf("error renewing certificates on node %s: %w", node, err)
+		}
+
+		if err := waitForAPIServer(ansibleRunner); err != nil {
+			return fmt.Errorf("error after renewing certificates on node %s: %w", node, err)
+		}
+	}
+
+	return nil
+}
+
+// listHosts returns the control plane, etcd and worker nodes of the inventory.
+func listHosts(ansibleRunner *ansible.Runner) ([]string, error) {
+	out, err := ansibleRunner.Exec(nodeGroups, "--list-hosts")
+	if err != nil {
+		return nil, fmt.Errorf("error listing hosts: %w", err)
+	}
+
+	return ansible.ParseListHosts(out), nil
+}
+
+// waitForAPIServer waits for the API server to be ready on every control plane node.
+func waitForAPIServer(ansibleRunner *ansible.Runner) error {
+	logrus.Info("Waiting for the API server to be healthy...")
+
+	var err error
+
+	for range apiServerHealthRetries {
+		if _, err = ansibleRunner.Exec(
+			"master",
+			"--become",
+			"--one-line",
+			"-m",
+			"shell",
+			"-a",
+			"kubectl --kubeconfig=/etc/kubernetes/admin.conf get --raw=/readyz",
+		); err == nil {
+			return nil
+		}
+
+		time.Sleep(apiServerHealthInterval)
+	}
+
+	return fmt.Errorf("%w: %w", ErrAPIServerNotHealthy, err)
+}
+
+// renewedCertificates compares the certificates read before and after the renewal, returning the ones that changed.
+func renewedCertificates(before, after []clusterpki.Certificate) []state.RenewedCertificate {
+	renewed := []state.RenewedCertificate{}
+
+	for _, a := range after {
+		for _, b := range before {
+			if a.Node != b.Node || a.Path != b.Path || a.NotAfter.Equal(b.NotAfter) {
+				continue
+			}
+
+			renewed = append(renewed, state.RenewedCertificate{
+				Node:             a.Node,
+				Path:             a.Path,
+				PreviousNotAfter: b.NotAfter,
+				NotAfter:         a.NotAfter,
+			})
+		}
+	}
+
+	return renewed
+}
+
+// recordRenewal stores the renewal in the cluster.
+func recordRenewal(ansibleRunner *ansible.Runner, tmpDir, configPath string, renewal state.CertificatesRenewal) error {
+	client, err := adminClient(ansibleRunner, tmpDir)
+	if err != nil {
+		return err
+	}
+
+	if err := state.NewKubeStore("", configPath, client).StoreCertificatesRenewal(renewal); err != nil {
+		return fmt.Errorf("error while storing certificates renewal: %w", err)
+	}
+
+	return nil
+}
+
+// adminClient returns a client using the admin kubeconfig of the first control plane node, fetched into tmpDir.
+func adminClient(ansibleRunner *ansible.Runner, tmpDir string) (kubernetes.Interface, error) {
+	kubeconfigPath := path.Join(tmpDir, "admin.conf")
+
+	if err := fetchAdminKubeconfig(ansibleRunner, kubeconfigPath); err != nil {
+		return nil, err
+	}
+
+	if err := kubex.SetConfigEnv(kubeconfigPath); err != nil {
+		return nil, fmt.Errorf("error setting kubeconfig env: %w", err)
+	}
+
+	client, err := kubex.NewClient()
+	if err != nil {
+		return nil, fmt.Errorf("error creating kubernetes client: %w", err)
+	}
+
+	return client, nil
+}
+
+// fetchAdminKubeconfig copies the admin kubeconfig of the first control plane node to dest.
+func fetchAdminKubeconfig(ansibleRunner *ansible.Runner, dest string) error {
+	if _, err := ansibleRunner.Exec(
+		"master[0]",
+		"--become",
+		"-m",
+		"fetch",
+		"-a",
+		fmt.Sprintf("src=/etc/kubernetes/admin.conf dest=%s flat=yes", dest),
+	); err != nil {
+		return fmt.Errorf("error fetching kubeconfig: %w", err)
+	}
+
+	return nil
+}
+
+// renderKubernetesTemplates renders the templates of the kubernetes phase, Ansible inventory included, into dir.
+func renderKubernetesTemplates(
+	phase *cluster.OperationPhase,
+	kfdManifest config.KFD,
+	distroPath,
+	configPath,
+	dir string,
+) error {
+	furyctlMerger, err := phase.CreateFuryctlMerger(
+		distroPath,
+		configPath,
 		"kfd-v1alpha2",
 		"onpremises",
 	)
@@ -97,7 +355,7 @@ func (k *CertificatesRenewer) Renew() error {
 	}
 
 	mCfg.Data["kubernetes"] = map[any]any{
-		"version": k.kfdManifest.Kubernetes.OnPremises.Version,
+		"version": kfdManifest.Kubernetes.OnPremises.Version,
 	}
 
 	mCfg.Data["paths"] = map[any]any{
@@ -115,23 +373,15 @@ func (k *CertificatesRenewer) Renew() error {
 		"podRunningTimeout":    "",
 	}
 
-	if err := k.CopyFromTemplate(
+	if err := phase.CopyFromTemplate(
 		mCfg,
 		"kubernetes",
-		path.Join(k.distroPath, "templates", cluster.OperationPhaseKubernetes, "onpremises"),
-		tmpDir,
-		k.configPath,
+		path.Join(distroPath, "templates", cluster.OperationPhaseKubernetes, "onpremises"),
+		dir,
+		configPath,
 	); err != nil {
 		return fmt.Errorf("error copying from template: %w", err)
 	}
 
-	if _, err := ansibleRunner.Exec("all", "-m", "ping"); err != nil {
-		return fmt.Errorf("error checking hosts: %w", err)
-	}
-
-	if _, err := ansibleRunner.Playbook("98.cluster-certificates-renewal.yaml"); err != nil {
-		return fmt.Errorf("error renewing certificates: %w", err)
-	}
-
 	return nil
 }
@@ -5,10 +5,12 @@
 package create
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path"
 	"strings"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 
@@ -49,9 +51,16 @@ func (d *Distribution) Self() *cluster.OperationPhase {
 	return d.OperationPhase
 }
 
-func (d *Distribution) Exec(rdcs reducers.Reducers, startFrom string, upgradeState *upgrade.State) error {
+func (d *Distribution) Exec(
+	ctx context.Context,
+	rdcs reducers.Reducers,
+	startFrom string,
+	upgradeState *upgrade.State,
+) error {
 	logrus.Info("Installing SIGHUP Distribution...")
 
+	defer cluster.StopOnCancel(ctx, cluster.OperationPhaseDistribution, d.Stop)()
+
 	mCfg, err := d.prepare()
 	if err != nil {
 		return fmt.Errorf("error preparing distribution phase: %w", err)
@@ -63,15 +72,19 @@ func (d *Distribution) Exec(rdcs reducers.Reducers, startFrom string, upgradeSta
 		return nil
 	}
 
-	if err := d.preDistribution(startFrom, upgradeState); err != nil {
+	if err := d.preDistribution(ctx, startFrom, upgradeState); err != nil {
 		return fmt.Errorf("error running pre-distribution phase: %w", err)
 	}
 
+	if err := cluster.Interrupted(ctx); err != nil {
+		return fmt.Errorf("error before running core distribution phase: %w", err)
+	}
+
 	if err := d.coreDistribution(startFrom, upgradeState, rdcs, mCfg); err != nil {
 		return fmt.Errorf("error running core distribution phase: %w", err)
 	}
 
-	if err := d.postDistribution(upgradeState); err != nil {
+	if err := d.postDistribution(ctx, upgradeState); err != nil {
 		return fmt.Errorf("error running post-distribution phase: %w", err)
 	}
 
@@ -172,12 +185,13 @@ func (d *Distribution) prepare() (template.Config, error) {
 }
 
 func (d *Distribution) preDistribution(
+	ctx context.Context,
 	startFrom string,
 	upgradeState *upgrade.State,
 ) error {
 	if startFrom == "" || startFrom == cluster.OperationSubPhasePreDistribution {
 		// Run upgrade script if needed.
-		if err := d.upgrade.Exec(d.Path, "pre-distribution"); err != nil {
+		if err := d.upgrade.Exec(ctx, d.Path, "pre-distribution"); err != nil {
 			upgradeState.Phases.PreDistribution.Status = upgrade.PhaseStatusFailed
 
 			return fmt.Errorf("error running upgrade: %w", err)
@@ -235,9 +249,10 @@ func (d *Distribution) coreDistribution(
 }
 
 func (d *Distribution) postDistribution(
+	ctx context.Context,
 	upgradeState *upgrade.State,
 ) error {
-	if err := d.upgrade.Exec(d.Path, "post-distribution"); err != nil {
+	if err := d.upgrade.Exec(ctx, d.Path, "post-distribution"); err != nil {
 		upgradeState.Phases.PostDistribution.Status = upgrade.PhaseStatusFailed
 
 		return fmt.Errorf("error running upgrade: %w", err)
@@ -353,3 +368,49 @@ func NewDistribution(
 		upgrade: upgr,
 	}
 }
+
+func (d *Distribution) Stop() error {
+	errCh := make(chan error)
+	doneCh := make(chan bool)
+
+	var wg sync.WaitGroup
+
+	//nolint:mnd // ignore magic number linters
+	wg.Add(2)
+
+	go func() {
+		logrus.Debug("Stopping shell...")
+
+		if err := d.shellRunner.Stop(); err != nil {
+			errCh <- fmt.Errorf("error stopping shell: %w", err)
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		logrus.Debug("Stopping kubectl...")
+
+		if err := d.kubeRunner.Stop(); err != nil {
+			errCh <- fmt.Errorf("error stopping kubectl: %w", err)
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		wg.Wait()
+		close(doneCh)
+	}()
+
+	select {
+	case <-doneCh:
+
+	case err := <-errCh:
+		close(errCh)
+
+		return err
+	}
+
+	return nil
+}
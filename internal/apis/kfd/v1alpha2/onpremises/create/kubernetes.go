@@ -5,6 +5,7 @@
 package create
 
 import (
+	"context"
 	"fmt"
 	"path"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
 	"github.com/sighupio/fury-distribution/pkg/apis/onpremises/v1alpha2/public"
+	"github.com/sighupio/furyctl/internal/atrest"
 	"github.com/sighupio/furyctl/internal/cluster"
 	"github.com/sighupio/furyctl/internal/tool/ansible"
 	"github.com/sighupio/furyctl/internal/upgrade"
@@ -40,9 +42,11 @@ func (k *Kubernetes) Self() *cluster.OperationPhase {
 	return k.OperationPhase
 }
 
-func (k *Kubernetes) Exec(startFrom string, upgradeState *upgrade.State) error {
+func (k *Kubernetes) Exec(ctx context.Context, startFrom string, upgradeState *upgrade.State) error {
 	logrus.Info("Configuring SIGHUP Distribution cluster...")
 
+	defer cluster.StopOnCancel(ctx, cluster.OperationPhaseKubernetes, k.Stop)()
+
 	if err := k.prepare(); err != nil {
 		return fmt.Errorf("error preparing kubernetes phase: %w", err)
 	}
@@ -61,15 +65,19 @@ func (k *Kubernetes) Exec(startFrom string, upgradeState *upgrade.State) error {
 		return nil
 	}
 
-	if err := k.preKubernetes(startFrom, upgradeState); err != nil {
+	if err := k.preKubernetes(ctx, startFrom, upgradeState); err != nil {
 		return fmt.Errorf("error running pre-kubernetes phase: %w", err)
 	}
 
+	if err := cluster.Interrupted(ctx); err != nil {
+		return fmt.Errorf("error before running core kubernetes phase: %w", err)
+	}
+
 	if err := k.coreKubernetes(startFrom, upgradeState); err != nil {
 		return fmt.Errorf("error running core kubernetes phase: %w", err)
 	}
 
-	if err := k.postKubernetes(upgradeState); err != nil {
+	if err := k.postKubernetes(ctx, upgradeState); err != nil {
 		return fmt.Errorf("error running post-kubernetes phase: %w", err)
 	}
 
@@ -135,12 +143,13 @@ func (k *Kubernetes) prepare() error {
 }
 
 func (k *Kubernetes) preKubernetes(
+	ctx context.Context,
 	startFrom string,
 	upgradeState *upgrade.State,
 ) error {
 	if startFrom == "" || startFrom == cluster.OperationSubPhasePreKubernetes {
 		// Run upgrade script if needed.
-		if err := k.upgrade.Exec(k.Path, "pre-kubernetes"); err != nil {
+		if err := k.upgrade.Exec(ctx, k.Path, "pre-kubernetes"); err != nil {
 			upgradeState.Phases.PreKubernetes.Status = upgrade.PhaseStatusFailed
 
 			return fmt.Errorf("error running upgrade: %w", err)
@@ -161,6 +170,10 @@ func (k *Kubernetes) coreKubernetes(
 	if startFrom != cluster.OperationSubPhasePostKubernetes {
 		logrus.Info("Applying cluster configuration...")
 
+		if err := k.protectKubeconfigs(); err != nil {
+			return err
+		}
+
 		// Apply create playbook.
 		if !k.upgrade.Enabled {
 			if _, err := k.ansibleRunner.Playbook("create-playbook.yaml"); err != nil {
@@ -196,10 +209,31 @@ func (k *Kubernetes) coreKubernetes(
 	return nil
 }
 
+// protectKubeconfigs encrypts at rest, when enabled, the admin.conf and the user kubeconfigs fetched by the create
+// playbook into the phase folder.
+func (k *Kubernetes) protectKubeconfigs() error {
+	names := []string{"admin.conf"}
+
+	if k.furyctlConf.Spec.Kubernetes.Advanced != nil && k.furyctlConf.Spec.Kubernetes.Advanced.Users != nil {
+		for _, username := range k.furyctlConf.Spec.Kubernetes.Advanced.Users.Names {
+			names = append(names, username+".kubeconfig")
+		}
+	}
+
+	for _, name := range names {
+		if err := atrest.Default.ProtectFile(path.Join(k.OperationPhase.Path, name)); err != nil {
+			return fmt.Errorf("error protecting %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
 func (k *Kubernetes) postKubernetes(
+	ctx context.Context,
 	upgradeState *upgrade.State,
 ) error {
-	if err := k.upgrade.Exec(k.Path, "post-kubernetes"); err != nil {
+	if err := k.upgrade.Exec(ctx, k.Path, "post-kubernetes"); err != nil {
 		upgradeState.Phases.PostKubernetes.Status = upgrade.PhaseStatusFailed
 
 		return fmt.Errorf("error running upgrade: %w", err)
@@ -252,3 +286,13 @@ func NewKubernetes(
 		podRunningTimeout: podRunningTimeout,
 	}
 }
+
+func (k *Kubernetes) Stop() error {
+	logrus.Debug("Stopping ansible...")
+
+	if err := k.ansibleRunner.Stop(); err != nil {
+		return fmt.Errorf("error stopping ansible: %w", err)
+	}
+
+	return nil
+}
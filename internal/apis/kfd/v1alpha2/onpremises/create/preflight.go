@@ -15,6 +15,7 @@ import (
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
 	"github.com/sighupio/fury-distribution/pkg/apis/onpremises/v1alpha2/public"
 	"github.com/sighupio/furyctl/internal/apis/kfd/v1alpha2/onpremises/supported"
+	"github.com/sighupio/furyctl/internal/atrest"
 	"github.com/sighupio/furyctl/internal/cluster"
 	distrib "github.com/sighupio/furyctl/internal/distribution"
 	"github.com/sighupio/furyctl/internal/state"
@@ -145,6 +146,11 @@ func (p *PreFlight) Exec(renderedConfig map[string]any) (*Status, error) {
 		return status, fmt.Errorf("error checking hosts: %w", err)
 	}
 
+	// The verify playbook fetches the admin.conf of the cluster, which is encrypted at rest when enabled.
+	if err := atrest.Default.ProtectFile(path.Join(p.Path, "admin.conf")); err != nil {
+		return status, fmt.Errorf("error protecting admin.conf: %w", err)
+	}
+
 	if _, err := p.ansibleRunner.Playbook("verify-playbook.yaml"); err != nil {
 		status.Success = true
 
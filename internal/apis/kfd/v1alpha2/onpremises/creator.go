@@ -5,9 +5,11 @@
 package onpremises
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -16,8 +18,10 @@ import (
 	"github.com/sighupio/fury-distribution/pkg/apis/onpremises/v1alpha2/public"
 	commcreate "github.com/sighupio/furyctl/internal/apis/kfd/v1alpha2/common/create"
 	"github.com/sighupio/furyctl/internal/apis/kfd/v1alpha2/onpremises/create"
+	"github.com/sighupio/furyctl/internal/atrest"
 	"github.com/sighupio/furyctl/internal/cluster"
 	"github.com/sighupio/furyctl/internal/distribution"
+	"github.com/sighupio/furyctl/internal/parser"
 	"github.com/sighupio/furyctl/internal/state"
 	"github.com/sighupio/furyctl/internal/upgrade"
 	"github.com/sighupio/furyctl/pkg/reducers"
@@ -168,7 +172,11 @@ func (*ClusterCreator) GetPhasePath(phase string) (string, error) {
 	}
 }
 
-func (c *ClusterCreator) Create(startFrom string, _, podRunningCheckTimeout int) error {
+func (c *ClusterCreator) Create(ctx context.Context, startFrom string, _, podRunningCheckTimeout int) error {
+	if err := c.protectPKI(); err != nil {
+		return err
+	}
+
 	upgr := upgrade.New(c.paths, string(c.furyctlConf.Kind))
 
 	kubernetesPhase := upgrade.NewOperatorPhaseDecorator(
@@ -283,7 +291,7 @@ func (c *ClusterCreator) Create(startFrom string, _, podRunningCheckTimeout int)
 			},
 		}
 
-		if err := kubernetesPhase.Exec(StartFromFlagNotSet, &upgradeState); err != nil {
+		if err := kubernetesPhase.Exec(ctx, StartFromFlagNotSet, &upgradeState); err != nil {
 			return fmt.Errorf("error while executing kubernetes phase: %w", err)
 		}
 
@@ -307,7 +315,7 @@ func (c *ClusterCreator) Create(startFrom string, _, podRunningCheckTimeout int)
 			},
 		}
 
-		if err := distributionPhase.Exec(rdcs, StartFromFlagNotSet, &upgradeState); err != nil {
+		if err := distributionPhase.Exec(ctx, rdcs, StartFromFlagNotSet, &upgradeState); err != nil {
 			return fmt.Errorf("error while executing distribution phase: %w", err)
 		}
 
@@ -322,6 +330,7 @@ func (c *ClusterCreator) Create(startFrom string, _, podRunningCheckTimeout int)
 
 	case cluster.OperationPhaseAll:
 		if err := c.allPhases(
+			ctx,
 			startFrom,
 			kubernetesPhase,
 			distributionPhase,
@@ -359,6 +368,7 @@ func (c *ClusterCreator) Create(startFrom string, _, podRunningCheckTimeout int)
 }
 
 func (c *ClusterCreator) allPhases(
+	ctx context.Context,
 	startFrom string,
 	kubernetesPhase upgrade.OperatorPhase,
 	distributionPhase upgrade.ReducersOperatorPhase[reducers.Reducers],
@@ -401,7 +411,7 @@ func (c *ClusterCreator) allPhases(
 		startFrom != cluster.OperationPhaseDistribution &&
 		startFrom != cluster.OperationSubPhasePostDistribution &&
 		startFrom != cluster.OperationPhasePlugins {
-		if err := kubernetesPhase.Exec(c.getKubernetesSubPhase(startFrom), upgradeState); err != nil {
+		if err := kubernetesPhase.Exec(ctx, c.getKubernetesSubPhase(startFrom), upgradeState); err != nil {
 			return fmt.Errorf("error while executing kubernetes phase: %w", err)
 		}
 
@@ -422,11 +432,15 @@ func (c *ClusterCreator) allPhases(
 			}
 		}
 
-		if err := distributionPhase.Exec(rdcs, c.getDistributionSubPhase(startFrom), upgradeState); err != nil {
+		if err := distributionPhase.Exec(ctx, rdcs, c.getDistributionSubPhase(startFrom), upgradeState); err != nil {
 			return fmt.Errorf("error while executing distribution phase: %w", err)
 		}
 	}
 
+	if err := cluster.Interrupted(ctx); err != nil {
+		return fmt.Errorf("error before executing plugins phase: %w", err)
+	}
+
 	if distribution.HasFeature(c.kfdManifest, distribution.FeaturePlugins) {
 		if err := pluginsPhase.Exec(); err != nil {
 			return fmt.Errorf("error while executing plugins phase: %w", err)
@@ -437,6 +451,7 @@ func (c *ClusterCreator) allPhases(
 		logrus.Info("Executing extra phases...")
 
 		if err := c.extraPhases(
+			ctx,
 			kubernetesPhase,
 			distributionPhase,
 			pluginsPhase,
@@ -451,6 +466,7 @@ func (c *ClusterCreator) allPhases(
 }
 
 func (c *ClusterCreator) extraPhases(
+	ctx context.Context,
 	kubernetesPhase upgrade.OperatorPhase,
 	distributionPhase upgrade.ReducersOperatorPhase[reducers.Reducers],
 	pluginsPhase *commcreate.Plugins,
@@ -464,18 +480,22 @@ func (c *ClusterCreator) extraPhases(
 	}()
 
 	for _, phase := range c.postApplyPhases {
+		if err := cluster.Interrupted(ctx); err != nil {
+			return fmt.Errorf("error before executing %s post-apply phase: %w", phase, err)
+		}
+
 		switch phase {
 		case cluster.OperationPhaseKubernetes:
 			kubernetesPhase.SetUpgrade(false)
 
-			if err := kubernetesPhase.Exec(StartFromFlagNotSet, upgradeState); err != nil {
+			if err := kubernetesPhase.Exec(ctx, StartFromFlagNotSet, upgradeState); err != nil {
 				return fmt.Errorf("error while executing kubernetes phase: %w", err)
 			}
 
 		case cluster.OperationPhaseDistribution:
 			distributionPhase.SetUpgrade(false)
 
-			if err := distributionPhase.Exec(nil, StartFromFlagNotSet, upgradeState); err != nil {
+			if err := distributionPhase.Exec(ctx, nil, StartFromFlagNotSet, upgradeState); err != nil {
 				return fmt.Errorf("error while executing distribution phase: %w", err)
 			}
 
@@ -558,3 +578,37 @@ func (c *ClusterCreator) RenderConfig() (map[string]any, error) {
 
 	return specMap, nil
 }
+
+// protectPKI decrypts the PKI folder for the duration of the command, when the encryption at rest is enabled.
+func (c *ClusterCreator) protectPKI() error {
+	if c.furyctlConf.Spec.Kubernetes.PkiFolder == "" {
+		return nil
+	}
+
+	pkiFolder, err := resolvePKIFolder(c.furyctlConf.Spec.Kubernetes.PkiFolder, c.paths.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	if err := atrest.Default.Protect(pkiFolder); err != nil {
+		return fmt.Errorf("error while decrypting PKI folder: %w", err)
+	}
+
+	return nil
+}
+
+// resolvePKIFolder returns the absolute path of the PKI folder set in the configuration file at configPath.
+func resolvePKIFolder(pkiFolder, configPath string) (string, error) {
+	configDir := filepath.Dir(configPath)
+
+	folder, err := parser.NewConfigParser(configDir).ParseMultipleDynamicValues(pkiFolder)
+	if err != nil {
+		return "", fmt.Errorf("error while parsing PKI folder path: %w", err)
+	}
+
+	if !filepath.IsAbs(folder) {
+		folder = filepath.Join(configDir, folder)
+	}
+
+	return folder, nil
+}
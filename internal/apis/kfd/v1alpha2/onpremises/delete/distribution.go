@@ -27,13 +27,14 @@ import (
 type Distribution struct {
 	*cluster.OperationPhase
 
-	furyctlConf public.OnpremisesKfdV1Alpha2
-	kfdManifest config.KFD
-	paths       cluster.DeleterPaths
-	dryRun      bool
-	shellRunner *shell.Runner
-	kubeRunner  *kubectl.Runner
-	stateStore  state.Storer
+	furyctlConf  public.OnpremisesKfdV1Alpha2
+	kfdManifest  config.KFD
+	paths        cluster.DeleterPaths
+	dryRun       bool
+	shellRunner  *shell.Runner
+	kubeRunner   *kubectl.Runner
+	stateStore   state.Storer
+	deletionPlan *cluster.DeletionPlan
 }
 
 func (d *Distribution) Exec() error {
@@ -108,6 +109,14 @@ func (d *Distribution) Exec() error {
 	}
 
 	if d.dryRun {
+		if err := d.deletionPlan.AddKustomization(
+			cluster.OperationPhaseDistribution,
+			d.KustomizePath,
+			path.Join(d.Path, "manifests"),
+		); err != nil {
+			return fmt.Errorf("error reading distribution manifests: %w", err)
+		}
+
 		logrus.Info("SIGHUP Distribution deleted successfully (dry-run mode)")
 
 		return nil
@@ -149,6 +158,7 @@ func NewDistribution(
 	kfdManifest config.KFD,
 	paths cluster.DeleterPaths,
 	dryRun bool,
+	deletionPlan *cluster.DeletionPlan,
 ) *Distribution {
 	phase := cluster.NewOperationPhase(
 		path.Join(paths.WorkDir, cluster.OperationPhaseDistribution),
@@ -186,5 +196,6 @@ func NewDistribution(
 			kfdManifest.Tools.Common.Kubectl.Version,
 			paths.BinPath,
 		),
+		deletionPlan: deletionPlan,
 	}
 }
@@ -27,6 +27,7 @@ type Kubernetes struct {
 	paths         cluster.DeleterPaths
 	dryRun        bool
 	ansibleRunner *ansible.Runner
+	deletionPlan  *cluster.DeletionPlan
 }
 
 func (k *Kubernetes) Exec() error {
@@ -70,6 +71,15 @@ func (k *Kubernetes) Exec() error {
 	}
 
 	if k.dryRun {
+		if k.deletionPlan != nil {
+			out, err := k.ansibleRunner.Exec("all", "--list-hosts")
+			if err != nil {
+				return fmt.Errorf("error listing hosts: %w", err)
+			}
+
+			k.deletionPlan.AddNodes(cluster.OperationPhaseKubernetes, ansible.ParseListHosts(out))
+		}
+
 		logrus.Info("Kubernetes cluster deleted successfully (dry-run mode)")
 
 		return nil
@@ -99,6 +109,7 @@ func NewKubernetes(
 	kfdManifest config.KFD,
 	paths cluster.DeleterPaths,
 	dryRun bool,
+	deletionPlan *cluster.DeletionPlan,
 ) *Kubernetes {
 	phase := cluster.NewOperationPhase(
 		path.Join(paths.WorkDir, cluster.OperationPhaseKubernetes),
@@ -112,6 +123,7 @@ func NewKubernetes(
 		kfdManifest:    kfdManifest,
 		paths:          paths,
 		dryRun:         dryRun,
+		deletionPlan:   deletionPlan,
 		ansibleRunner: ansible.NewRunner(
 			execx.NewStdExecutor(),
 			ansible.Paths{
@@ -13,6 +13,7 @@ import (
 
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
 	"github.com/sighupio/fury-distribution/pkg/apis/onpremises/v1alpha2/public"
+	"github.com/sighupio/furyctl/internal/atrest"
 	"github.com/sighupio/furyctl/internal/cluster"
 	"github.com/sighupio/furyctl/internal/tool/ansible"
 	"github.com/sighupio/furyctl/internal/tool/kubectl"
@@ -113,6 +114,11 @@ func (p *PreFlight) Exec() error {
 		return fmt.Errorf("error checking hosts: %w", err)
 	}
 
+	// The verify playbook fetches the admin.conf of the cluster, which is encrypted at rest when enabled.
+	if err := atrest.Default.ProtectFile(path.Join(p.Path, "admin.conf")); err != nil {
+		return fmt.Errorf("error protecting admin.conf: %w", err)
+	}
+
 	if _, err := p.ansibleRunner.Playbook("verify-playbook.yaml"); err != nil {
 		logrus.Debug("Cluster does not exist, skipping state checks")
 
@@ -17,11 +17,13 @@ import (
 )
 
 type ClusterDeleter struct {
-	paths       cluster.DeleterPaths
-	furyctlConf public.OnpremisesKfdV1Alpha2
-	kfdManifest config.KFD
-	phase       string
-	dryRun      bool
+	paths        cluster.DeleterPaths
+	furyctlConf  public.OnpremisesKfdV1Alpha2
+	kfdManifest  config.KFD
+	phase        string
+	dryRun       bool
+	deletionPlan *cluster.DeletionPlan
+	preDelete    cluster.PreDeleteFunc
 }
 
 func (d *ClusterDeleter) SetProperties(props []cluster.DeleterProperty) {
@@ -71,6 +73,16 @@ func (d *ClusterDeleter) SetProperty(name string, value any) {
 		if b, ok := value.(bool); ok {
 			d.dryRun = b
 		}
+
+	case cluster.DeleterPropertyDeletionPlan:
+		if p, ok := value.(*cluster.DeletionPlan); ok {
+			d.deletionPlan = p
+		}
+
+	case cluster.DeleterPropertyPreDelete:
+		if f, ok := value.(cluster.PreDeleteFunc); ok {
+			d.preDelete = f
+		}
 	}
 }
 
@@ -83,6 +95,7 @@ func (d *ClusterDeleter) Delete() error {
 		d.kfdManifest,
 		d.paths,
 		d.dryRun,
+		d.deletionPlan,
 	)
 
 	distributionPhase := del.NewDistribution(
@@ -90,6 +103,7 @@ func (d *ClusterDeleter) Delete() error {
 		d.kfdManifest,
 		d.paths,
 		d.dryRun,
+		d.deletionPlan,
 	)
 
 	preflight := del.NewPreFlight(d.furyctlConf, d.kfdManifest, d.paths, d.dryRun)
@@ -98,6 +112,12 @@ func (d *ClusterDeleter) Delete() error {
 		return fmt.Errorf("error while executing preflight phase: %w", err)
 	}
 
+	if d.preDelete != nil {
+		if err := d.preDelete(); err != nil {
+			return fmt.Errorf("error while preparing the deletion: %w", err)
+		}
+	}
+
 	switch d.phase {
 	case cluster.OperationPhaseKubernetes:
 		if err := kubernetesPhase.Exec(); err != nil {
@@ -34,4 +34,22 @@ func init() {
 		"OnPremises",
 		cluster.NewCertificatesRenewerFactory[*CertificatesRenewer, public.OnpremisesKfdV1Alpha2](&CertificatesRenewer{}),
 	)
+
+	cluster.RegisterCertificatesGetterFactory(
+		"kfd.sighup.io/v1alpha2",
+		"OnPremises",
+		cluster.NewCertificatesGetterFactory[*CertificatesGetter, public.OnpremisesKfdV1Alpha2](&CertificatesGetter{}),
+	)
+
+	cluster.RegisterCARotatorFactory(
+		"kfd.sighup.io/v1alpha2",
+		"OnPremises",
+		cluster.NewCARotatorFactory[*CARotator, public.OnpremisesKfdV1Alpha2](&CARotator{}),
+	)
+
+	cluster.RegisterKubeconfigCreatorFactory(
+		"kfd.sighup.io/v1alpha2",
+		"OnPremises",
+		cluster.NewKubeconfigCreatorFactory[*KubeconfigCreator, public.OnpremisesKfdV1Alpha2](&KubeconfigCreator{}),
+	)
 }
@@ -0,0 +1,274 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package onpremises
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sighupio/fury-distribution/pkg/apis/config"
+	"github.com/sighupio/fury-distribution/pkg/apis/onpremises/v1alpha2/public"
+	"github.com/sighupio/furyctl/internal/atrest"
+	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/clusterpki"
+	"github.com/sighupio/furyctl/internal/tool/ansible"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+var ErrControlPlaneAddressMissing = errors.New("control plane address not found " +
+	"in .spec.kubernetes.controlPlaneAddress")
+
+type KubeconfigCreator struct {
+	*cluster.OperationPhase
+	furyctlConf public.OnpremisesKfdV1Alpha2
+	kfdManifest config.KFD
+	distroPath  string
+	configPath  string
+	workDir     string
+
+	user      string
+	groups    []string
+	ttl       time.Duration
+	auditFile string
+
+	allowSystemMasters bool
+}
+
+func (k *KubeconfigCreator) SetProperties(props []cluster.KubeconfigCreatorProperty) {
+	for _, prop := range props {
+		k.SetProperty(prop.Name, prop.Value)
+	}
+
+	k.OperationPhase = &cluster.OperationPhase{}
+}
+
+func (k *KubeconfigCreator) SetProperty(name string, value any) {
+	lcName := strings.ToLower(name)
+
+	switch lcName {
+	case cluster.KubeconfigCreatorPropertyFuryctlConf:
+		if s, ok := value.(public.OnpremisesKfdV1Alpha2); ok {
+			k.furyctlConf = s
+		}
+
+	case cluster.KubeconfigCreatorPropertyConfigPath:
+		if s, ok := value.(string); ok {
+			k.configPath = s
+		}
+
+	case cluster.KubeconfigCreatorPropertyKfdManifest:
+		if s, ok := value.(config.KFD); ok {
+			k.kfdManifest = s
+		}
+
+	case cluster.KubeconfigCreatorPropertyDistroPath:
+		if s, ok := value.(string); ok {
+			k.distroPath = s
+		}
+
+	case cluster.KubeconfigCreatorPropertyWorkDir:
+		if s, ok := value.(string); ok {
+			k.workDir = s
+		}
+
+	case cluster.KubeconfigCreatorPropertyUser:
+		if s, ok := value.(string); ok {
+			k.user = s
+		}
+
+	case cluster.KubeconfigCreatorPropertyGroups:
+		if s, ok := value.([]string); ok {
+			k.groups = s
+		}
+
+	case cluster.KubeconfigCreatorPropertyTTL:
+		if d, ok := value.(time.Duration); ok {
+			k.ttl = d
+		}
+
+	case cluster.KubeconfigCreatorPropertyAuditFile:
+		if s, ok := value.(string); ok {
+			k.auditFile = s
+		}
+
+	case cluster.KubeconfigCreatorPropertyAllowSystemMasters:
+		if b, ok := value.(bool); ok {
+			k.allowSystemMasters = b
+		}
+	}
+}
+
+// Create issues a kubeconfig for the user, signed with the Kubernetes CA of the PKI folder or, when it is not
+// available locally, with the one of the first control plane node.
+func (k *KubeconfigCreator) Create() error {
+	now := time.Now()
+
+	if slices.Contains(k.groups, clusterpki.SystemMastersGroup) {
+		if !k.allowSystemMasters {
+			return fmt.Errorf("%w with --allow-system-masters", clusterpki.ErrSystemMastersUser)
+		}
+
+		logrus.Warn("The system:masters group bypasses RBAC and cannot be revoked before the kubeconfig expires")
+	}
+
+	furyctlConf, err := yamlx.FromFileV3[map[string]any](k.configPath)
+	if err != nil {
+		return fmt.Errorf("error reading configuration file: %w", err)
+	}
+
+	server, err := apiServerURL(furyctlConf)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "fury-kubeconfig-creator-*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary directory: %w", err)
+	}
+
+	defer os.RemoveAll(tmpDir)
+
+	ca, err := k.loadCA(tmpDir, now)
+	if err != nil {
+		return err
+	}
+
+	kubeconfig, cert, err := clusterpki.UserKubeconfig{
+		ClusterName:        k.furyctlConf.Metadata.Name,
+		Server:             server,
+		User:               k.user,
+		Groups:             k.groups,
+		TTL:                k.ttl,
+		AllowSystemMasters: k.allowSystemMasters,
+	}.Issue(ca, now)
+	if err != nil {
+		return fmt.Errorf("error while issuing kubeconfig: %w", err)
+	}
+
+	kubeconfigPath := filepath.Join(k.workDir, "kubeconfig-"+k.user)
+
+	if err := atrest.Default.ProtectFile(kubeconfigPath); err != nil {
+		return fmt.Errorf("error protecting kubeconfig file: %w", err)
+	}
+
+	if err := os.WriteFile(kubeconfigPath, kubeconfig, 0o600); err != nil {
+		return fmt.Errorf("error writing kubeconfig file: %w", err)
+	}
+
+	issuedBy := "unknown"
+	if u, err := user.Current(); err == nil {
+		issuedBy = u.Username
+	}
+
+	if err := clusterpki.AppendKubeconfigIssuance(
+		k.auditFile,
+		clusterpki.NewKubeconfigIssuance(k.furyctlConf.Metadata.Name, issuedBy, cert, now),
+	); err != nil {
+		return fmt.Errorf("error while recording kubeconfig issuance: %w", err)
+	}
+
+	logrus.Infof(
+		"Kubeconfig for user %s issued at %s, it expires on %s",
+		k.user,
+		kubeconfigPath,
+		cert.NotAfter.Format(time.RFC3339),
+	)
+
+	return nil
+}
+
+// loadCA loads the Kubernetes CA from the PKI folder, fetching it from the first control plane node into tmpDir when
+// the folder is not set or does not hold the CA key.
+func (k *KubeconfigCreator) loadCA(tmpDir string, now time.Time) (*clusterpki.CA, error) {
+	if k.furyctlConf.Spec.Kubernetes.PkiFolder != "" {
+		pkiFolder, err := resolvePKIFolder(k.furyctlConf.Spec.Kubernetes.PkiFolder, k.configPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := atrest.Default.Protect(pkiFolder); err != nil {
+			return nil, fmt.Errorf("error while decrypting PKI folder: %w", err)
+		}
+
+		caDir := filepath.Join(pkiFolder, clusterpki.ControlPlanePath)
+		keyPath := filepath.Join(caDir, clusterpki.ControlPlaneCaKey)
+
+		if _, err := os.Stat(keyPath); err == nil {
+			logrus.Infof("Signing with the CA of the PKI folder %s...", pkiFolder)
+
+			ca, err := clusterpki.LoadCA(filepath.Join(caDir, clusterpki.ControlPlaneCaCrt), keyPath, now)
+			if err != nil {
+				return nil, fmt.Errorf("error while loading CA from PKI folder: %w", err)
+			}
+
+			return ca, nil
+		}
+	}
+
+	logrus.Info("Signing with the CA of the first control plane node...")
+
+	if err := renderKubernetesTemplates(k.OperationPhase, k.kfdManifest, k.distroPath, k.configPath, tmpDir); err != nil {
+		return nil, err
+	}
+
+	ansibleRunner := ansible.NewRunner(
+		execx.NewStdExecutor(),
+		ansible.Paths{
+			Ansible:         "ansible",
+			AnsiblePlaybook: "ansible-playbook",
+			WorkDir:         tmpDir,
+		},
+	)
+
+	for _, name := range []string{clusterpki.ControlPlaneCaCrt, clusterpki.ControlPlaneCaKey} {
+		if _, err := ansibleRunner.Exec(
+			"master[0]",
+			"--become",
+			"-m",
+			"fetch",
+			"-a",
+			fmt.Sprintf("src=/etc/kubernetes/pki/%s dest=%s flat=yes", name, filepath.Join(tmpDir, name)),
+		); err != nil {
+			return nil, fmt.Errorf("error fetching CA from control plane node: %w", err)
+		}
+	}
+
+	ca, err := clusterpki.LoadCA(
+		filepath.Join(tmpDir, clusterpki.ControlPlaneCaCrt),
+		filepath.Join(tmpDir, clusterpki.ControlPlaneCaKey),
+		now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error while loading CA fetched from control plane node: %w", err)
+	}
+
+	return ca, nil
+}
+
+// apiServerURL returns the URL of the API server, built from the control plane address of the configuration file.
+func apiServerURL(furyctlConf map[string]any) (string, error) {
+	spec, _ := furyctlConf["spec"].(map[string]any)
+	kubernetes, _ := spec["kubernetes"].(map[string]any)
+
+	address, _ := kubernetes["controlPlaneAddress"].(string)
+	if address == "" {
+		return "", ErrControlPlaneAddressMissing
+	}
+
+	if strings.Contains(address, "://") {
+		return address, nil
+	}
+
+	return "https://" + address, nil
+}
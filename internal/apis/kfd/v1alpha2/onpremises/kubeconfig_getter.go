@@ -14,6 +14,7 @@ import (
 
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
 	"github.com/sighupio/fury-distribution/pkg/apis/onpremises/v1alpha2/public"
+	"github.com/sighupio/furyctl/internal/atrest"
 	"github.com/sighupio/furyctl/internal/cluster"
 	"github.com/sighupio/furyctl/internal/tool/ansible"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
@@ -132,6 +133,10 @@ func (k *KubeconfigGetter) Get() error {
 		return fmt.Errorf("error reading kubeconfig file: %w", err)
 	}
 
+	if err := atrest.Default.ProtectFile(kubeconfigPath); err != nil {
+		return fmt.Errorf("error protecting kubeconfig file: %w", err)
+	}
+
 	if err := os.WriteFile(kubeconfigPath, kubeconfig, iox.FullRWPermAccess); err != nil {
 		return fmt.Errorf("error writing kubeconfig file: %w", err)
 	}
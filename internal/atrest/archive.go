@@ -0,0 +1,155 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atrest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"filippo.io/age"
+
+	iox "github.com/sighupio/furyctl/internal/x/io"
+)
+
+var (
+	ErrCannotEncrypt = errors.New("cannot encrypt archive")
+	ErrCannotDecrypt = errors.New("cannot decrypt archive")
+)
+
+// EncryptArchive writes the content of dir to archivePath as a tarball encrypted to the recipients of the session.
+func (s *Session) EncryptArchive(dir, archivePath string) error {
+	s.mu.Lock()
+	enabled, recipients := s.enabled, s.recipients
+	s.mu.Unlock()
+
+	if !enabled {
+		return ErrNoRecipient
+	}
+
+	return encryptArchive(dir, archivePath, recipients)
+}
+
+// encryptArchive writes the content of dir as an age-encrypted tarball.
+func encryptArchive(dir, archivePath string, recipients []age.Recipient) error {
+	return writeEncrypted(archivePath, recipients, func(w io.Writer) error {
+		return iox.WriteTar(w, dir)
+	})
+}
+
+// encryptFile writes the content of the file at src as an age-encrypted file.
+func encryptFile(src, dst string, recipients []age.Recipient) error {
+	return writeEncrypted(dst, recipients, func(w io.Writer) error {
+		f, err := os.Open(src)
+		if err != nil {
+			return fmt.Errorf("error while opening %s: %w", src, err)
+		}
+
+		defer f.Close()
+
+		if _, err := io.Copy(w, f); err != nil {
+			return fmt.Errorf("error while reading %s: %w", src, err)
+		}
+
+		return nil
+	})
+}
+
+// writeEncrypted writes to path the content produced by write, encrypted to the recipients. The content is written
+// next to its destination and renamed at the end, so a failure never leaves a truncated file behind.
+func writeEncrypted(path string, recipients []age.Recipient, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotEncrypt, err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if err := encryptTo(tmp, recipients, write); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("%w: %w", ErrCannotEncrypt, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotEncrypt, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotEncrypt, err)
+	}
+
+	return nil
+}
+
+func encryptTo(w io.Writer, recipients []age.Recipient, write func(io.Writer) error) error {
+	ew, err := age.Encrypt(w, recipients...)
+	if err != nil {
+		return fmt.Errorf("error while initializing encryption: %w", err)
+	}
+
+	if err := write(ew); err != nil {
+		return err
+	}
+
+	if err := ew.Close(); err != nil {
+		return fmt.Errorf("error while closing encryption: %w", err)
+	}
+
+	return nil
+}
+
+func decryptArchive(archivePath, dir string, identities []age.Identity) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotDecrypt, err)
+	}
+
+	defer f.Close()
+
+	r, err := age.Decrypt(f, identities...)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrCannotDecrypt, archivePath, err)
+	}
+
+	if err := iox.ExtractTar(r, dir); err != nil {
+		return fmt.Errorf("%w %s: %w", ErrCannotDecrypt, archivePath, err)
+	}
+
+	return nil
+}
+
+func decryptFile(src, dst string, identities []age.Identity) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotDecrypt, err)
+	}
+
+	defer f.Close()
+
+	r, err := age.Decrypt(f, identities...)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrCannotDecrypt, src, err)
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, iox.FullRWPermAccess)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrCannotDecrypt, src, err)
+	}
+
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+
+		return fmt.Errorf("%w %s: %w", ErrCannotDecrypt, src, err)
+	}
+
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("%w %s: %w", ErrCannotDecrypt, src, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,448 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atrest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"filippo.io/age"
+	"github.com/sirupsen/logrus"
+
+	iox "github.com/sighupio/furyctl/internal/x/io"
+)
+
+const (
+	// ArchiveExt is appended to the path of a protected folder to obtain the path of its encrypted archive.
+	ArchiveExt = ".tar.age"
+
+	// FileExt is appended to the path of a protected file to obtain the path of its encrypted copy.
+	FileExt = ".age"
+
+	// Linux mounts a tmpfs on this folder by default, so its content is never written to disk.
+	memoryBackedTempDir = "/dev/shm"
+)
+
+var (
+	ErrPassphraseAndRecipients = errors.New("passphrase and recipients cannot be used together")
+	ErrNoIdentity              = errors.New("an identity or a passphrase is needed to decrypt")
+	ErrNoRecipient             = errors.New("a recipient, an identity or a passphrase is needed to encrypt")
+	ErrInvalidRecipient        = errors.New("invalid recipient")
+	ErrInvalidIdentity         = errors.New("invalid identity")
+
+	// Default is the session shared by all the commands, it is configured from the root command flags.
+	Default = NewSession() //nolint:gochecknoglobals // Shared between all the commands and phases.
+)
+
+// Config selects how the protected folders are encrypted. Recipients are age public keys, IdentityPath is an age
+// identity file used to decrypt, and Passphrase replaces both of them with a scrypt-derived key.
+type Config struct {
+	Recipients   []string
+	IdentityPath string
+	Passphrase   string
+}
+
+func (c Config) Enabled() bool {
+	return len(c.Recipients) > 0 || c.IdentityPath != "" || c.Passphrase != ""
+}
+
+type mount struct {
+	path string
+	dir  string
+	// file is the name of the decrypted file in dir for the protected files, empty for the folders.
+	file string
+}
+
+// Session keeps the sensitive folders of the current command decrypted in a memory-backed temporary folder,
+// linked from their original location, and encrypts them back when the command ends.
+type Session struct {
+	mu         sync.Mutex
+	recipients []age.Recipient
+	identities []age.Identity
+	enabled    bool
+	mounts     map[string]mount
+}
+
+func NewSession() *Session {
+	return &Session{
+		mounts: map[string]mount{},
+	}
+}
+
+// Configure enables the encryption at rest, it is a no-op if the config does not enable it.
+func (s *Session) Configure(cfg Config) error {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	if cfg.Passphrase != "" && len(cfg.Recipients) > 0 {
+		return ErrPassphraseAndRecipients
+	}
+
+	recipients := []age.Recipient{}
+	identities := []age.Identity{}
+
+	if cfg.Passphrase != "" {
+		r, err := age.NewScryptRecipient(cfg.Passphrase)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRecipient, err)
+		}
+
+		i, err := age.NewScryptIdentity(cfg.Passphrase)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidIdentity, err)
+		}
+
+		recipients = append(recipients, r)
+		identities = append(identities, i)
+	}
+
+	for _, rs := range cfg.Recipients {
+		r, err := age.ParseX25519Recipient(strings.TrimSpace(rs))
+		if err != nil {
+			return fmt.Errorf("%w '%s': %w", ErrInvalidRecipient, rs, err)
+		}
+
+		recipients = append(recipients, r)
+	}
+
+	if cfg.IdentityPath != "" {
+		ids, err := readIdentities(cfg.IdentityPath)
+		if err != nil {
+			return err
+		}
+
+		identities = append(identities, ids...)
+
+		// When no recipient is given, encrypt to the identities themselves.
+		if len(cfg.Recipients) == 0 && cfg.Passphrase == "" {
+			for _, id := range ids {
+				if x, ok := id.(*age.X25519Identity); ok {
+					recipients = append(recipients, x.Recipient())
+				}
+			}
+		}
+	}
+
+	if len(recipients) == 0 {
+		return ErrNoRecipient
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.recipients = recipients
+	s.identities = identities
+	s.enabled = true
+
+	return nil
+}
+
+func (s *Session) Enabled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.enabled
+}
+
+// Protect makes the folder at the given path available in clear text for the duration of the command. Its content
+// is taken from the encrypted archive next to it and from any plaintext left in the folder, which gets removed.
+// It is a no-op when the encryption at rest is not enabled or the folder is already protected.
+func (s *Session) Protect(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.enabled {
+		return nil
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("error while getting absolute path of %s: %w", path, err)
+	}
+
+	if _, ok := s.mounts[absPath]; ok {
+		return nil
+	}
+
+	dir, err := makeTempDir()
+	if err != nil {
+		return err
+	}
+
+	if err := s.populate(absPath, dir); err != nil {
+		os.RemoveAll(dir)
+
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(absPath), iox.FullPermAccess); err != nil {
+		os.RemoveAll(dir)
+
+		return fmt.Errorf("error while creating folder %s: %w", filepath.Dir(absPath), err)
+	}
+
+	if err := os.Symlink(dir, absPath); err != nil {
+		os.RemoveAll(dir)
+
+		return fmt.Errorf("error while linking %s to %s: %w", absPath, dir, err)
+	}
+
+	logrus.Debugf("Decrypted %s in %s", absPath, dir)
+
+	s.mounts[absPath] = mount{path: absPath, dir: dir}
+
+	return nil
+}
+
+// ProtectFile is the same as Protect for a single file, such as a kubeconfig, which is encrypted to a file with the
+// FileExt extension next to it. The link is left dangling until the file is written when it does not exist yet.
+func (s *Session) ProtectFile(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.enabled {
+		return nil
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("error while getting absolute path of %s: %w", path, err)
+	}
+
+	if _, ok := s.mounts[absPath]; ok {
+		return nil
+	}
+
+	dir, err := makeTempDir()
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Base(absPath)
+
+	if err := s.populateFile(absPath, filepath.Join(dir, name)); err != nil {
+		os.RemoveAll(dir)
+
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(absPath), iox.FullPermAccess); err != nil {
+		os.RemoveAll(dir)
+
+		return fmt.Errorf("error while creating folder %s: %w", filepath.Dir(absPath), err)
+	}
+
+	if err := os.Symlink(filepath.Join(dir, name), absPath); err != nil {
+		os.RemoveAll(dir)
+
+		return fmt.Errorf("error while linking %s to %s: %w", absPath, dir, err)
+	}
+
+	logrus.Debugf("Decrypted %s in %s", absPath, dir)
+
+	s.mounts[absPath] = mount{path: absPath, dir: dir, file: name}
+
+	return nil
+}
+
+// populateFile writes to dst the content of the encrypted file or of the plaintext file at path, if any.
+func (s *Session) populateFile(path, dst string) error {
+	encPath := path + FileExt
+
+	if _, err := os.Stat(encPath); err == nil {
+		if len(s.identities) == 0 {
+			return fmt.Errorf("%w %s", ErrNoIdentity, encPath)
+		}
+
+		if err := decryptFile(encPath, dst, s.identities); err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Lstat(path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	// A plaintext file, or a link left behind by an interrupted command, takes precedence over the encrypted copy.
+	if content, err := os.ReadFile(path); err == nil {
+		logrus.Infof("Encrypting plaintext file %s...", path)
+
+		if err := os.WriteFile(dst, content, iox.FullRWPermAccess); err != nil {
+			return fmt.Errorf("error while copying %s: %w", path, err)
+		}
+
+		if target, err := os.Readlink(path); err == nil {
+			if err := os.Remove(target); err != nil {
+				return fmt.Errorf("error while removing plaintext file %s: %w", target, err)
+			}
+		}
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("error while removing %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// populate fills dir with the content of the archive and of the plaintext folder at path, if any.
+func (s *Session) populate(path, dir string) error {
+	archivePath := path + ArchiveExt
+
+	if _, err := os.Stat(archivePath); err == nil {
+		if len(s.identities) == 0 {
+			return fmt.Errorf("%w %s", ErrNoIdentity, archivePath)
+		}
+
+		if err := decryptArchive(archivePath, dir, s.identities); err != nil {
+			return err
+		}
+	}
+
+	fi, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error while reading %s: %w", path, err)
+	}
+
+	plainPath := path
+
+	// A link left behind by an interrupted command, its target may contain changes that were never encrypted.
+	if fi.Mode()&os.ModeSymlink != 0 {
+		if plainPath, err = os.Readlink(path); err != nil {
+			return fmt.Errorf("error while reading link %s: %w", path, err)
+		}
+	}
+
+	if _, err := os.Stat(plainPath); err == nil {
+		logrus.Infof("Encrypting plaintext files found in %s...", plainPath)
+
+		if err := iox.CopyRecursive(os.DirFS(plainPath), dir); err != nil {
+			return fmt.Errorf("error while copying %s: %w", plainPath, err)
+		}
+
+		if err := os.RemoveAll(plainPath); err != nil {
+			return fmt.Errorf("error while removing plaintext folder %s: %w", plainPath, err)
+		}
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("error while removing %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Seal encrypts all the protected folders back to their archives and removes their clear text content.
+// Folders that cannot be encrypted are left in place, so that their content is not lost.
+func (s *Session) Seal() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	paths := make([]string, 0, len(s.mounts))
+
+	for p := range s.mounts {
+		paths = append(paths, p)
+	}
+
+	sort.Strings(paths)
+
+	var errs []error
+
+	for _, p := range paths {
+		m := s.mounts[p]
+
+		if err := m.encrypt(s.recipients); err != nil {
+			errs = append(errs, fmt.Errorf("error while encrypting %s, its content is still available in %s: %w", m.path, m.dir, err))
+
+			continue
+		}
+
+		if err := os.Remove(m.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("error while removing link %s: %w", m.path, err))
+		}
+
+		if err := os.RemoveAll(m.dir); err != nil {
+			errs = append(errs, fmt.Errorf("error while removing decrypted folder %s: %w", m.dir, err))
+		}
+
+		logrus.Debugf("Encrypted %s", m.path)
+
+		delete(s.mounts, p)
+	}
+
+	return errors.Join(errs...)
+}
+
+// encrypt writes the decrypted content of m back to its encrypted file or archive. The encrypted copy of a file that
+// has been removed during the command is removed as well, while a file replaced by a tool, e.g. with an atomic rename
+// over the link, is encrypted from its new content.
+func (m mount) encrypt(recipients []age.Recipient) error {
+	if m.file == "" {
+		return encryptArchive(m.dir, m.path+ArchiveExt, recipients)
+	}
+
+	src := filepath.Join(m.dir, m.file)
+
+	fi, linkErr := os.Lstat(m.path)
+	if linkErr == nil && fi.Mode()&os.ModeSymlink == 0 {
+		src = m.path
+	}
+
+	_, srcErr := os.Stat(src)
+
+	if errors.Is(linkErr, os.ErrNotExist) || errors.Is(srcErr, os.ErrNotExist) {
+		if err := os.Remove(m.path + FileExt); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error while removing %s: %w", m.path+FileExt, err)
+		}
+
+		return nil
+	}
+
+	return encryptFile(src, m.path+FileExt, recipients)
+}
+
+func makeTempDir() (string, error) {
+	base := ""
+
+	if fi, err := os.Stat(memoryBackedTempDir); runtime.GOOS == "linux" && err == nil && fi.IsDir() {
+		base = memoryBackedTempDir
+	} else {
+		logrus.Debugf("%s is not available, decrypted files will be stored in %s", memoryBackedTempDir, os.TempDir())
+	}
+
+	dir, err := os.MkdirTemp(base, "furyctl-atrest-")
+	if err != nil {
+		return "", fmt.Errorf("error while creating temporary folder: %w", err)
+	}
+
+	return dir, nil
+}
+
+func readIdentities(path string) ([]age.Identity, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIdentity, err)
+	}
+
+	defer f.Close()
+
+	ids, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrInvalidIdentity, path, err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package atrest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sighupio/furyctl/internal/atrest"
+)
+
+func newIdentityFile(t *testing.T) string {
+	t.Helper()
+
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	idPath := filepath.Join(t.TempDir(), "key.txt")
+
+	require.NoError(t, os.WriteFile(idPath, []byte(id.String()+"\n"), 0o600))
+
+	return idPath
+}
+
+func TestSession_Disabled(t *testing.T) {
+	t.Parallel()
+
+	s := atrest.NewSession()
+
+	require.NoError(t, s.Configure(atrest.Config{}))
+
+	secrets := filepath.Join(t.TempDir(), "secrets")
+
+	require.NoError(t, s.Protect(secrets))
+	require.NoError(t, s.Seal())
+
+	assert.False(t, s.Enabled())
+	assert.NoFileExists(t, secrets+atrest.ArchiveExt)
+}
+
+func TestSession_ProtectAndSeal(t *testing.T) {
+	t.Parallel()
+
+	idPath := newIdentityFile(t)
+
+	secrets := filepath.Join(t.TempDir(), "secrets")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(secrets, "nested"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(secrets, "nested", "kubeconfig"), []byte("plaintext"), 0o600))
+
+	s := atrest.NewSession()
+
+	require.NoError(t, s.Configure(atrest.Config{IdentityPath: idPath}))
+	require.NoError(t, s.Protect(secrets))
+
+	fi, err := os.Lstat(secrets)
+	require.NoError(t, err)
+	assert.NotZero(t, fi.Mode()&os.ModeSymlink, "protected folder should be a link to the decrypted folder")
+
+	content, err := os.ReadFile(filepath.Join(secrets, "nested", "kubeconfig"))
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", string(content))
+
+	require.NoError(t, os.WriteFile(filepath.Join(secrets, "ca.key"), []byte("new key"), 0o600))
+	require.NoError(t, s.Seal())
+
+	assert.NoFileExists(t, secrets)
+	assert.FileExists(t, secrets+atrest.ArchiveExt)
+
+	archive, err := os.ReadFile(secrets + atrest.ArchiveExt)
+	require.NoError(t, err)
+	assert.NotContains(t, string(archive), "plaintext")
+
+	// A new session with the same identity gets the files back.
+	s2 := atrest.NewSession()
+
+	require.NoError(t, s2.Configure(atrest.Config{IdentityPath: idPath}))
+	require.NoError(t, s2.Protect(secrets))
+
+	content, err = os.ReadFile(filepath.Join(secrets, "ca.key"))
+	require.NoError(t, err)
+	assert.Equal(t, "new key", string(content))
+
+	require.NoError(t, s2.Seal())
+
+	// A session with a different identity cannot decrypt them.
+	s3 := atrest.NewSession()
+
+	require.NoError(t, s3.Configure(atrest.Config{IdentityPath: newIdentityFile(t)}))
+	assert.ErrorIs(t, s3.Protect(secrets), atrest.ErrCannotDecrypt)
+}
+
+func TestSession_ProtectFileAndSeal(t *testing.T) {
+	t.Parallel()
+
+	idPath := newIdentityFile(t)
+
+	workDir := t.TempDir()
+	kubeconfig := filepath.Join(workDir, "kubeconfig")
+	adminConf := filepath.Join(workDir, "admin.conf")
+
+	require.NoError(t, os.WriteFile(kubeconfig, []byte("plaintext"), 0o600))
+
+	s := atrest.NewSession()
+
+	require.NoError(t, s.Configure(atrest.Config{IdentityPath: idPath}))
+	require.NoError(t, s.ProtectFile(kubeconfig))
+	require.NoError(t, s.ProtectFile(adminConf))
+
+	content, err := os.ReadFile(kubeconfig)
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", string(content))
+
+	// Files that do not exist yet are written through the link.
+	_, err = os.Stat(adminConf)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	require.NoError(t, os.WriteFile(adminConf, []byte("admin"), 0o600))
+	require.NoError(t, s.Seal())
+
+	assert.NoFileExists(t, kubeconfig)
+	assert.NoFileExists(t, adminConf)
+
+	encrypted, err := os.ReadFile(kubeconfig + atrest.FileExt)
+	require.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "plaintext")
+
+	s2 := atrest.NewSession()
+
+	require.NoError(t, s2.Configure(atrest.Config{IdentityPath: idPath}))
+	require.NoError(t, s2.ProtectFile(kubeconfig))
+	require.NoError(t, s2.ProtectFile(adminConf))
+
+	content, err = os.ReadFile(adminConf)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", string(content))
+
+	// Removing a protected file removes its encrypted copy, replacing it encrypts the new content.
+	require.NoError(t, os.Remove(kubeconfig))
+	require.NoError(t, os.Remove(adminConf))
+	require.NoError(t, os.WriteFile(adminConf, []byte("replaced"), 0o600))
+	require.NoError(t, s2.Seal())
+
+	assert.NoFileExists(t, kubeconfig+atrest.FileExt)
+	assert.NoFileExists(t, adminConf)
+
+	s3 := atrest.NewSession()
+
+	require.NoError(t, s3.Configure(atrest.Config{IdentityPath: idPath}))
+	require.NoError(t, s3.ProtectFile(adminConf))
+
+	content, err = os.ReadFile(adminConf)
+	require.NoError(t, err)
+	assert.Equal(t, "replaced", string(content))
+
+	require.NoError(t, s3.Seal())
+}
+
+func TestSession_Configure(t *testing.T) {
+	t.Parallel()
+
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc    string
+		cfg     atrest.Config
+		wantErr error
+	}{
+		{
+			desc: "recipient only",
+			cfg:  atrest.Config{Recipients: []string{id.Recipient().String()}},
+		},
+		{
+			desc: "passphrase",
+			cfg:  atrest.Config{Passphrase: "passphrase"},
+		},
+		{
+			desc:    "passphrase and recipients",
+			cfg:     atrest.Config{Passphrase: "passphrase", Recipients: []string{id.Recipient().String()}},
+			wantErr: atrest.ErrPassphraseAndRecipients,
+		},
+		{
+			desc:    "invalid recipient",
+			cfg:     atrest.Config{Recipients: []string{"not-a-key"}},
+			wantErr: atrest.ErrInvalidRecipient,
+		},
+		{
+			desc:    "missing identity file",
+			cfg:     atrest.Config{IdentityPath: "/does/not/exist"},
+			wantErr: atrest.ErrInvalidIdentity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			s := atrest.NewSession()
+
+			err := s.Configure(tc.cfg)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.False(t, s.Enabled())
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, s.Enabled())
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sighupio/fury-distribution/pkg/apis/config"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+const (
+	CARotatorPropertyFuryctlConf = "furyctlconf"
+	CARotatorPropertyConfigPath  = "configpath"
+	CARotatorPropertyKfdManifest = "kfdmanifest"
+	CARotatorPropertyDistroPath  = "distropath"
+	CARotatorPropertyWorkDir     = "workdir"
+)
+
+var caRotatorFactories = make(map[string]map[string]CARotatorFactory) //nolint:gochecknoglobals, lll // This patterns requires caRotatorFactories as global to work with init function.
+
+type CARotatorFactory func(configPath string, props []CARotatorProperty) (CARotator, error)
+
+type CARotatorProperty struct {
+	Name  string
+	Value any
+}
+
+// CARotator replaces the CAs of the cluster's PKI with new ones, without downtime.
+type CARotator interface {
+	SetProperties(props []CARotatorProperty)
+	SetProperty(name string, value any)
+	Rotate() error
+}
+
+func NewCARotator(
+	minimalConf config.Furyctl,
+	kfdManifest config.KFD,
+	distroPath string,
+	configPath string,
+	workDir string,
+) (CARotator, error) {
+	lcAPIVersion := strings.ToLower(minimalConf.APIVersion)
+	lcResourceType := strings.ToLower(minimalConf.Kind)
+
+	if factoryFn, ok := caRotatorFactories[lcAPIVersion][lcResourceType]; ok {
+		return factoryFn(configPath, []CARotatorProperty{
+			{
+				Name:  CARotatorPropertyKfdManifest,
+				Value: kfdManifest,
+			},
+			{
+				Name:  CARotatorPropertyDistroPath,
+				Value: distroPath,
+			},
+			{
+				Name:  CARotatorPropertyWorkDir,
+				Value: workDir,
+			},
+		})
+	}
+
+	return nil, fmt.Errorf("%w -  type '%s' api version '%s'", errResourceNotSupported, lcResourceType, lcAPIVersion)
+}
+
+func RegisterCARotatorFactory(apiVersion, kind string, factory CARotatorFactory) {
+	lcAPIVersion := strings.ToLower(apiVersion)
+	lcKind := strings.ToLower(kind)
+
+	if _, ok := caRotatorFactories[lcAPIVersion]; !ok {
+		caRotatorFactories[lcAPIVersion] = make(map[string]CARotatorFactory)
+	}
+
+	caRotatorFactories[lcAPIVersion][lcKind] = factory
+}
+
+func NewCARotatorFactory[T CARotator, S any](cc T) CARotatorFactory {
+	return func(configPath string, props []CARotatorProperty) (CARotator, error) {
+		furyctlConf, err := yamlx.FromFileV3[S](configPath)
+		if err != nil {
+			return nil, err
+		}
+
+		cc.SetProperty(CARotatorPropertyConfigPath, configPath)
+		cc.SetProperty(CARotatorPropertyFuryctlConf, furyctlConf)
+		cc.SetProperties(props)
+
+		return cc, nil
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sighupio/fury-distribution/pkg/apis/config"
+	"github.com/sighupio/furyctl/internal/clusterpki"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+const (
+	CertificatesGetterPropertyFuryctlConf = "furyctlconf"
+	CertificatesGetterPropertyConfigPath  = "configpath"
+	CertificatesGetterPropertyKfdManifest = "kfdmanifest"
+	CertificatesGetterPropertyDistroPath  = "distropath"
+	CertificatesGetterPropertySkipNodes   = "skipnodes"
+)
+
+var certificatesGetterFactories = make(map[string]map[string]CertificatesGetterFactory) //nolint:gochecknoglobals, lll // This patterns requires certificatesGetterFactories as global to work with init function.
+
+type CertificatesGetterFactory func(configPath string, props []CertificatesGetterProperty) (CertificatesGetter, error) //nolint:lll // This pattern requires CertificatesGetterFactory as global to work with init function.
+
+type CertificatesGetterProperty struct {
+	Name  string
+	Value any
+}
+
+// CertificatesGetter lists the certificates of the cluster's PKI, from the local PKI folder and from the nodes.
+type CertificatesGetter interface {
+	SetProperties(props []CertificatesGetterProperty)
+	SetProperty(name string, value any)
+	Get() ([]clusterpki.Certificate, error)
+}
+
+func NewCertificatesGetter(
+	minimalConf config.Furyctl,
+	kfdManifest config.KFD,
+	distroPath string,
+	configPath string,
+	skipNodes bool,
+) (CertificatesGetter, error) {
+	lcAPIVersion := strings.ToLower(minimalConf.APIVersion)
+	lcResourceType := strings.ToLower(minimalConf.Kind)
+
+	if factoryFn, ok := certificatesGetterFactories[lcAPIVersion][lcResourceType]; ok {
+		return factoryFn(configPath, []CertificatesGetterProperty{
+			{
+				Name:  CertificatesGetterPropertyKfdManifest,
+				Value: kfdManifest,
+			},
+			{
+				Name:  CertificatesGetterPropertyDistroPath,
+				Value: distroPath,
+			},
+			{
+				Name:  CertificatesGetterPropertySkipNodes,
+				Value: skipNodes,
+			},
+		})
+	}
+
+	return nil, fmt.Errorf("%w -  type '%s' api version '%s'", errResourceNotSupported, lcResourceType, lcAPIVersion)
+}
+
+func RegisterCertificatesGetterFactory(apiVersion, kind string, factory CertificatesGetterFactory) {
+	lcAPIVersion := strings.ToLower(apiVersion)
+	lcKind := strings.ToLower(kind)
+
+	if _, ok := certificatesGetterFactories[lcAPIVersion]; !ok {
+		certificatesGetterFactories[lcAPIVersion] = make(map[string]CertificatesGetterFactory)
+	}
+
+	certificatesGetterFactories[lcAPIVersion][lcKind] = factory
+}
+
+func NewCertificatesGetterFactory[T CertificatesGetter, S any](cc T) CertificatesGetterFactory {
+	return func(configPath string, props []CertificatesGetterProperty) (CertificatesGetter, error) {
+		furyctlConf, err := yamlx.FromFileV3[S](configPath)
+		if err != nil {
+			return nil, err
+		}
+
+		cc.SetProperty(CertificatesGetterPropertyConfigPath, configPath)
+		cc.SetProperty(CertificatesGetterPropertyFuryctlConf, furyctlConf)
+		cc.SetProperties(props)
+
+		return cc, nil
+	}
+}
@@ -5,8 +5,10 @@
 package cluster
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
 	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
@@ -17,8 +19,17 @@ const (
 	CertificatesRenewerPropertyConfigPath  = "configpath"
 	CertificatesRenewerPropertyKfdManifest = "kfdmanifest"
 	CertificatesRenewerPropertyDistroPath  = "distropath"
+	CertificatesRenewerPropertyWorkDir     = "workdir"
+	CertificatesRenewerPropertyBinPath     = "binpath"
+
+	CertificatesRenewerPropertyOnlyExpiringWithin = "onlyexpiringwithin"
+	CertificatesRenewerPropertyNodes              = "nodes"
+	CertificatesRenewerPropertySerial             = "serial"
 )
 
+var ErrRenewOptionsNotSupported = errors.New("selecting the certificates or the nodes to renew, or renewing them " +
+	"one node at a time, is supported only for the OnPremises kind")
+
 var certificatesRenewerFactories = make(map[string]map[string]CertificatesRenewerFactory) //nolint:gochecknoglobals, lll // This patterns requires certificatesRenewerFactories as global to work with init function.
 
 type CertificatesRenewerFactory func(configPath string, props []CertificatesRenewerProperty) (CertificatesRenewer, error) //nolint:lll // This pattern requires CertificatesRenewerFactory as global to work with init function.
@@ -28,6 +39,16 @@ type CertificatesRenewerProperty struct {
 	Value any
 }
 
+// CertificatesRenewerOptions limits the renewal to the certificates close to their expiration and to some nodes.
+type CertificatesRenewerOptions struct {
+	// OnlyExpiringWithin renews only the certificates that expire within this duration, when not zero.
+	OnlyExpiringWithin time.Duration
+	// Nodes renews only the certificates of these nodes, when not empty.
+	Nodes []string
+	// Serial renews one node at a time, checking the health of the API server in between.
+	Serial bool
+}
+
 type CertificatesRenewer interface {
 	SetProperties(props []CertificatesRenewerProperty)
 	SetProperty(name string, value any)
@@ -39,6 +60,9 @@ func NewCertificatesRenewer(
 	kfdManifest config.KFD,
 	distroPath string,
 	configPath string,
+	workDir string,
+	binPath string,
+	opts CertificatesRenewerOptions,
 ) (CertificatesRenewer, error) {
 	lcAPIVersion := strings.ToLower(minimalConf.APIVersion)
 	lcResourceType := strings.ToLower(minimalConf.Kind)
@@ -53,6 +77,26 @@ func NewCertificatesRenewer(
 				Name:  CertificatesRenewerPropertyDistroPath,
 				Value: distroPath,
 			},
+			{
+				Name:  CertificatesRenewerPropertyWorkDir,
+				Value: workDir,
+			},
+			{
+				Name:  CertificatesRenewerPropertyBinPath,
+				Value: binPath,
+			},
+			{
+				Name:  CertificatesRenewerPropertyOnlyExpiringWithin,
+				Value: opts.OnlyExpiringWithin,
+			},
+			{
+				Name:  CertificatesRenewerPropertyNodes,
+				Value: opts.Nodes,
+			},
+			{
+				Name:  CertificatesRenewerPropertySerial,
+				Value: opts.Serial,
+			},
 		})
 	}
 
@@ -5,6 +5,7 @@
 package cluster
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -55,7 +56,9 @@ type CreatorProperty struct {
 type Creator interface {
 	SetProperties(props []CreatorProperty)
 	SetProperty(name string, value any)
-	Create(startFrom string, timeout, podRunningTimeout int) error
+	// Create creates or updates the cluster. When ctx is canceled, the running tools are asked to stop and Create
+	// returns as soon as the phases have persisted their state.
+	Create(ctx context.Context, startFrom string, timeout, podRunningTimeout int) error
 	GetPhasePath(phase string) (string, error)
 }
 
@@ -23,6 +23,8 @@ const (
 	DeleterPropertySkipVpn        = "skipvpn"
 	DeleterPropertyVpnAutoConnect = "vpnautoconnect"
 	DeleterPropertyDryRun         = "dryrun"
+	DeleterPropertyDeletionPlan   = "deletionplan"
+	DeleterPropertyPreDelete      = "predelete"
 )
 
 var delFactories = make(map[string]map[string]DeleterFactory) //nolint:gochecknoglobals, lll // This patterns requires factories
@@ -35,6 +37,10 @@ type DeleterPaths struct {
 	BinPath    string
 }
 
+// PreDeleteFunc is run by the deleters once the preflight phase has selected the kubeconfig of the cluster and
+// before anything is removed, it aborts the deletion when it fails.
+type PreDeleteFunc func() error
+
 type DeleterFactory func(configPath string, props []DeleterProperty) (Deleter, error)
 
 type DeleterProperty struct {
@@ -0,0 +1,320 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sighupio/fury-distribution/pkg/apis/config"
+	"github.com/sighupio/furyctl/internal/atrest"
+	"github.com/sighupio/furyctl/internal/distribution"
+	"github.com/sighupio/furyctl/internal/state"
+	"github.com/sighupio/furyctl/internal/tool/terraform"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+	iox "github.com/sighupio/furyctl/internal/x/io"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+// DeletionProtectionField is the field of the metadata of the configuration file enabling the deletion protection.
+const DeletionProtectionField = "deletionProtection"
+
+var (
+	ErrDeletionProtected         = errors.New("deletion protection is enabled")
+	ErrInvalidDeletionProtection = errors.New("invalid deletion protection")
+	ErrStoredConfigUnavailable   = errors.New("cannot read the configuration applied to the cluster")
+)
+
+// DeletionProtection returns true when the deletion protection is enabled in the furyctl configuration conf. The
+// field is not part of the distribution schemas, so it is validated here: values other than booleans, such as "true"
+// or "yes", are rejected instead of silently leaving the protection disabled.
+func DeletionProtection(conf map[string]any) (bool, error) {
+	metadata, _ := conf["metadata"].(map[string]any)
+
+	value, ok := metadata[DeletionProtectionField]
+	if !ok || value == nil {
+		return false, nil
+	}
+
+	enabled, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf(
+			"%w: metadata.%s must be a boolean, got '%v'",
+			ErrInvalidDeletionProtection,
+			DeletionProtectionField,
+			value,
+		)
+	}
+
+	return enabled, nil
+}
+
+// CheckDeletionProtection fails when the deletion protection is enabled either in the local configuration file or in
+// the configuration last applied to the cluster, so that it has to be turned off with an apply before deleting.
+// stored is nil when the configuration could not be read from the cluster.
+func CheckDeletionProtection(local, stored map[string]any) error {
+	localEnabled, err := DeletionProtection(local)
+	if err != nil {
+		return fmt.Errorf("error in the configuration file: %w", err)
+	}
+
+	if localEnabled {
+		return fmt.Errorf(
+			"%w in the configuration file: set metadata.%s to false and apply it before deleting the cluster",
+			ErrDeletionProtected,
+			DeletionProtectionField,
+		)
+	}
+
+	storedEnabled, err := DeletionProtection(stored)
+	if err != nil {
+		return fmt.Errorf("error in the configuration applied to the cluster: %w", err)
+	}
+
+	if storedEnabled {
+		return fmt.Errorf(
+			"%w in the configuration applied to the cluster: apply the configuration file with metadata.%s "+
+				"set to false before deleting the cluster",
+			ErrDeletionProtected,
+			DeletionProtectionField,
+		)
+	}
+
+	return nil
+}
+
+// ReadStoredConfig returns the configuration last applied to the cluster named clusterName, both raw and parsed. It
+// fails with ErrStoredConfigUnavailable when the configuration cannot be read or belongs to another cluster, e.g.
+// when the kubeconfig in use points to a different one.
+func ReadStoredConfig(store state.Storer, clusterName string) ([]byte, map[string]any, error) {
+	raw, err := store.GetConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrStoredConfigUnavailable, err)
+	}
+
+	var stored map[string]any
+
+	if err := yamlx.UnmarshalV3(raw, &stored); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrStoredConfigUnavailable, err)
+	}
+
+	metadata, _ := stored["metadata"].(map[string]any)
+	if name, _ := metadata["name"].(string); name != clusterName {
+		return nil, nil, fmt.Errorf(
+			"%w: the kubeconfig in use points to cluster '%s' instead of '%s'",
+			ErrStoredConfigUnavailable,
+			name,
+			clusterName,
+		)
+	}
+
+	return raw, stored, nil
+}
+
+// DeletionBackup exports what is needed to recover a cluster, or at least to know how it was configured, before it
+// is deleted.
+type DeletionBackup struct {
+	Kind        string
+	ClusterName string
+	ConfigPath  string
+	DistroPath  string
+	WorkDir     string
+	BinPath     string
+	KFDTools    config.KFDTools
+	StateStore  state.Storer
+	// AtRest encrypts the archive when the encryption at rest is enabled, the archive is a plain tar.gz otherwise.
+	AtRest *atrest.Session
+}
+
+// Create writes the backup archive to the backups folder of the work directory and returns its path. The archive
+// holds the configuration file and the distribution manifest, plus the configuration stored in the cluster, the
+// kubeconfigs and, for EKSCluster, the Terraform states when they can be retrieved. The rendered configuration is
+// left out, as it contains the resolved secrets.
+func (b DeletionBackup) Create(now time.Time) (string, error) {
+	dir, err := os.MkdirTemp("", "furyctl-backup-")
+	if err != nil {
+		return "", fmt.Errorf("error while creating temporary folder: %w", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	if err := copyToBackup(b.ConfigPath, filepath.Join(dir, "furyctl.yaml")); err != nil {
+		return "", err
+	}
+
+	if err := copyToBackup(filepath.Join(b.DistroPath, "kfd.yaml"), filepath.Join(dir, "kfd.yaml")); err != nil {
+		logrus.Warnf("Distribution manifest not added to the backup: %v", err)
+	}
+
+	if b.StateStore != nil {
+		if stored, _, err := ReadStoredConfig(b.StateStore, b.ClusterName); err != nil {
+			logrus.Warnf("Configuration stored in the cluster not added to the backup: %v", err)
+		} else if err := writeToBackup(filepath.Join(dir, "state", "furyctl.yaml"), stored); err != nil {
+			return "", fmt.Errorf("error while writing stored configuration: %w", err)
+		}
+	}
+
+	for name, src := range b.kubeconfigs() {
+		if err := copyToBackup(src, filepath.Join(dir, "kubeconfig", name)); err != nil {
+			logrus.Warnf("Kubeconfig %s not added to the backup: %v", src, err)
+		}
+	}
+
+	if b.Kind == distribution.EKSClusterKind {
+		b.exportTerraformStates(filepath.Join(dir, "terraform"))
+	}
+
+	archive := filepath.Join(b.WorkDir, "backups", now.Format("20060102150405"))
+
+	if b.AtRest != nil && b.AtRest.Enabled() {
+		archive += atrest.ArchiveExt
+
+		if err := os.MkdirAll(filepath.Dir(archive), iox.UserGroupPerm); err != nil {
+			return "", fmt.Errorf("error while creating backups folder: %w", err)
+		}
+
+		if err := b.AtRest.EncryptArchive(dir, archive); err != nil {
+			return "", fmt.Errorf("error while writing backup archive: %w", err)
+		}
+
+		return archive, nil
+	}
+
+	archive += ".tar.gz"
+
+	if err := writeBackupArchive(dir, archive); err != nil {
+		return "", err
+	}
+
+	return archive, nil
+}
+
+// kubeconfigs returns the kubeconfigs of the cluster that exist, keyed by their name in the archive.
+func (b DeletionBackup) kubeconfigs() map[string]string {
+	candidates := map[string]string{
+		"KUBECONFIG":            os.Getenv("KUBECONFIG"),
+		"kubeconfig":            "kubeconfig",
+		"eks-kubeconfig":        filepath.Join(b.WorkDir, OperationPhaseKubernetes, "secrets", "kubeconfig"),
+		"onpremises-kubeconfig": filepath.Join(b.WorkDir, OperationPhaseKubernetes, "admin.conf"),
+	}
+
+	res := make(map[string]string)
+	seen := make(map[string]bool)
+
+	for name, p := range candidates {
+		if p == "" {
+			continue
+		}
+
+		abs, err := filepath.Abs(p)
+		if err != nil || seen[abs] {
+			continue
+		}
+
+		if _, err := os.Stat(abs + atrest.FileExt); err == nil && b.AtRest != nil {
+			if err := b.AtRest.ProtectFile(abs); err != nil {
+				logrus.Warnf("Kubeconfig %s not added to the backup: %v", abs, err)
+
+				continue
+			}
+		}
+
+		if fi, err := os.Stat(abs); err != nil || !fi.Mode().IsRegular() {
+			continue
+		}
+
+		seen[abs] = true
+		res[name] = abs
+	}
+
+	return res
+}
+
+// exportTerraformStates pulls the state of every phase whose Terraform project has been initialized into dir.
+func (b DeletionBackup) exportTerraformStates(dir string) {
+	for _, phase := range []string{OperationPhaseInfrastructure, OperationPhaseKubernetes, OperationPhaseDistribution} {
+		op := NewOperationPhase(filepath.Join(b.WorkDir, phase), b.KFDTools, b.BinPath)
+		workDir := filepath.Join(op.Path, "terraform")
+
+		if _, err := os.Stat(filepath.Join(workDir, ".terraform")); err != nil {
+			continue
+		}
+
+		tfState, err := terraform.NewRunner(execx.NewStdExecutor(), terraform.Paths{
+			Logs:      op.TerraformLogsPath,
+			Outputs:   op.TerraformOutputsPath,
+			WorkDir:   workDir,
+			Plan:      op.TerraformPlanPath,
+			Terraform: op.TerraformPath,
+		}).StatePull()
+		if err != nil {
+			logrus.Warnf("Terraform state of the %s phase not added to the backup: %v", phase, err)
+
+			continue
+		}
+
+		if err := writeToBackup(filepath.Join(dir, phase+".tfstate"), tfState); err != nil {
+			logrus.Warnf("Terraform state of the %s phase not added to the backup: %v", phase, err)
+		}
+	}
+}
+
+func copyToBackup(src, dst string) error {
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("error while reading %s: %w", src, err)
+	}
+
+	return writeToBackup(dst, content)
+}
+
+func writeToBackup(dst string, content []byte) error {
+	if err := iox.EnsureDir(dst); err != nil {
+		return fmt.Errorf("error while creating directory of %s: %w", dst, err)
+	}
+
+	if err := iox.WriteFile(dst, content); err != nil {
+		return fmt.Errorf("error while writing %s: %w", dst, err)
+	}
+
+	return nil
+}
+
+func writeBackupArchive(dir, archive string) error {
+	if err := os.MkdirAll(filepath.Dir(archive), iox.UserGroupPerm); err != nil {
+		return fmt.Errorf("error while creating backups folder: %w", err)
+	}
+
+	f, err := os.OpenFile(archive, os.O_CREATE|os.O_EXCL|os.O_WRONLY, iox.FullRWPermAccess)
+	if err != nil {
+		return fmt.Errorf("error while creating backup archive: %w", err)
+	}
+
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+
+	if err := iox.WriteTar(zw, dir); err != nil {
+		zw.Close()
+
+		return fmt.Errorf("error while archiving %s: %w", dir, err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("error while closing compression: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error while closing backup archive: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sighupio/furyctl/internal/tool/kustomize"
+	"github.com/sighupio/furyctl/internal/tool/terraform"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+)
+
+const (
+	DeletionPlanSourceTerraform  = "terraform"
+	DeletionPlanSourceKubernetes = "kubernetes"
+	DeletionPlanSourceAnsible    = "ansible"
+)
+
+// DeletionPlanResource is a resource that would be removed by deleting the cluster.
+type DeletionPlanResource struct {
+	Phase     string `json:"phase"`
+	Source    string `json:"source"`
+	Type      string `json:"type"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// DeletionPlan collects the resources that the phases would remove when deleting the cluster in dry-run mode, so
+// that they can be reviewed before the actual deletion. Its methods can be called on a nil plan, which records
+// nothing.
+type DeletionPlan struct {
+	Cluster   string                 `json:"cluster"`
+	Kind      string                 `json:"kind"`
+	Resources []DeletionPlanResource `json:"resources"`
+}
+
+func NewDeletionPlan(cluster, kind string) *DeletionPlan {
+	return &DeletionPlan{
+		Cluster:   cluster,
+		Kind:      kind,
+		Resources: []DeletionPlanResource{},
+	}
+}
+
+func (p *DeletionPlan) Add(resources ...DeletionPlanResource) {
+	if p == nil {
+		return
+	}
+
+	p.Resources = append(p.Resources, resources...)
+}
+
+// AddTerraformPlan adds the managed resources that plan deletes or replaces.
+func (p *DeletionPlan) AddTerraformPlan(phase string, plan *tfjson.Plan) {
+	if p == nil || plan == nil {
+		return
+	}
+
+	for _, rc := range plan.ResourceChanges {
+		if rc.Mode == tfjson.DataResourceMode || rc.Change == nil {
+			continue
+		}
+
+		if !rc.Change.Actions.Delete() && !rc.Change.Actions.Replace() {
+			continue
+		}
+
+		p.Add(DeletionPlanResource{
+			Phase:  phase,
+			Source: DeletionPlanSourceTerraform,
+			Type:   rc.Type,
+			Name:   rc.Address,
+		})
+	}
+}
+
+// AddTerraformDestroyPlan adds the resources of the destroy plan last saved by tfRunner.
+func (p *DeletionPlan) AddTerraformDestroyPlan(phase string, tfRunner *terraform.Runner) error {
+	if p == nil {
+		return nil
+	}
+
+	plan, err := tfRunner.ShowPlan()
+	if err != nil {
+		return fmt.Errorf("error while reading %s destroy plan: %w", phase, err)
+	}
+
+	p.AddTerraformPlan(phase, plan)
+
+	return nil
+}
+
+// AddKustomization adds the Kubernetes objects built from the kustomization in dir, such as the rendered manifests of
+// the distribution phase.
+func (p *DeletionPlan) AddKustomization(phase, kustomizePath, dir string) error {
+	if p == nil {
+		return nil
+	}
+
+	manifests, err := kustomize.NewRunner(execx.NewStdExecutor(), kustomize.Paths{
+		Kustomize: kustomizePath,
+		WorkDir:   dir,
+	}).Build()
+	if err != nil {
+		return fmt.Errorf("error while building %s manifests: %w", phase, err)
+	}
+
+	return p.AddManifests(phase, manifests)
+}
+
+// AddManifests adds the Kubernetes objects of the multi-document YAML manifests.
+func (p *DeletionPlan) AddManifests(phase string, manifests []byte) error {
+	if p == nil {
+		return nil
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(manifests))
+
+	for {
+		var obj struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name      string `yaml:"name"`
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}
+
+		err := dec.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("error while parsing manifests: %w", err)
+		}
+
+		if obj.Kind == "" {
+			continue
+		}
+
+		p.Add(DeletionPlanResource{
+			Phase:     phase,
+			Source:    DeletionPlanSourceKubernetes,
+			Type:      obj.Kind,
+			Namespace: obj.Metadata.Namespace,
+			Name:      obj.Metadata.Name,
+		})
+	}
+}
+
+// AddNodes adds the hosts that would be reset.
+func (p *DeletionPlan) AddNodes(phase string, hosts []string) {
+	for _, host := range hosts {
+		p.Add(DeletionPlanResource{
+			Phase:  phase,
+			Source: DeletionPlanSourceAnsible,
+			Type:   "node",
+			Name:   host,
+		})
+	}
+}
+
+// Table returns the plan as a table, followed by the number of resources per phase.
+func (p *DeletionPlan) Table() string {
+	var sb strings.Builder
+
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0) //nolint:mnd // Padding.
+
+	fmt.Fprintln(w, "PHASE\tSOURCE\tTYPE\tNAMESPACE\tNAME")
+
+	counts := map[string]int{}
+	phases := []string{}
+
+	for _, r := range p.Resources {
+		namespace := r.Namespace
+		if namespace == "" {
+			namespace = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Phase, r.Source, r.Type, namespace, r.Name)
+
+		if _, ok := counts[r.Phase]; !ok {
+			phases = append(phases, r.Phase)
+		}
+
+		counts[r.Phase]++
+	}
+
+	w.Flush()
+
+	summary := make([]string, 0, len(phases))
+	for _, phase := range phases {
+		summary = append(summary, fmt.Sprintf("%s: %d", phase, counts[phase]))
+	}
+
+	fmt.Fprintf(&sb, "\n%d resources to be deleted", len(p.Resources))
+
+	if len(summary) > 0 {
+		fmt.Fprintf(&sb, " (%s)", strings.Join(summary, ", "))
+	}
+
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+func (p *DeletionPlan) JSON() ([]byte, error) {
+	out, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error while marshalling deletion plan: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package cluster_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sighupio/furyctl/internal/cluster"
+)
+
+func TestDeletionPlan_AddTerraformPlan(t *testing.T) {
+	t.Parallel()
+
+	plan := cluster.NewDeletionPlan("test", "EKSCluster")
+
+	plan.AddTerraformPlan(cluster.OperationPhaseInfrastructure, &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "module.vpc.aws_vpc.this",
+				Mode:    tfjson.ManagedResourceMode,
+				Type:    "aws_vpc",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete}},
+			},
+			{
+				Address: "data.aws_region.current",
+				Mode:    tfjson.DataResourceMode,
+				Type:    "aws_region",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete}},
+			},
+			{
+				Address: "aws_s3_bucket.logs",
+				Mode:    tfjson.ManagedResourceMode,
+				Type:    "aws_s3_bucket",
+				Change:  &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionNoop}},
+			},
+		},
+	})
+
+	assert.Equal(t, []cluster.DeletionPlanResource{
+		{
+			Phase:  cluster.OperationPhaseInfrastructure,
+			Source: cluster.DeletionPlanSourceTerraform,
+			Type:   "aws_vpc",
+			Name:   "module.vpc.aws_vpc.this",
+		},
+	}, plan.Resources)
+}
+
+func TestDeletionPlan_AddManifests(t *testing.T) {
+	t.Parallel()
+
+	plan := cluster.NewDeletionPlan("test", "KFDDistribution")
+
+	manifests := `apiVersion: v1
+kind: Namespace
+metadata:
+  name: monitoring
+---
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: grafana
+  namespace: monitoring
+`
+
+	require.NoError(t, plan.AddManifests(cluster.OperationPhaseDistribution, []byte(manifests)))
+
+	assert.Equal(t, []cluster.DeletionPlanResource{
+		{
+			Phase:  cluster.OperationPhaseDistribution,
+			Source: cluster.DeletionPlanSourceKubernetes,
+			Type:   "Namespace",
+			Name:   "monitoring",
+		},
+		{
+			Phase:     cluster.OperationPhaseDistribution,
+			Source:    cluster.DeletionPlanSourceKubernetes,
+			Type:      "Deployment",
+			Namespace: "monitoring",
+			Name:      "grafana",
+		},
+	}, plan.Resources)
+
+	require.Error(t, plan.AddManifests(cluster.OperationPhaseDistribution, []byte("kind: [")))
+}
+
+func TestDeletionPlan_Output(t *testing.T) {
+	t.Parallel()
+
+	plan := cluster.NewDeletionPlan("test", "OnPremises")
+
+	plan.AddNodes(cluster.OperationPhaseKubernetes, []string{"master1", "worker1"})
+	require.NoError(t, plan.AddManifests(
+		cluster.OperationPhaseDistribution,
+		[]byte("kind: Namespace\nmetadata:\n  name: logging\n"),
+	))
+
+	table := plan.Table()
+
+	assert.Contains(t, table, "PHASE")
+	assert.Regexp(t, `kubernetes\s+ansible\s+node\s+-\s+master1`, table)
+	assert.Contains(t, table, "3 resources to be deleted (kubernetes: 2, distribution: 1)")
+
+	out, err := plan.JSON()
+	require.NoError(t, err)
+
+	var got cluster.DeletionPlan
+
+	require.NoError(t, json.Unmarshal(out, &got))
+	assert.Equal(t, *plan, got)
+}
+
+func TestDeletionPlan_Nil(t *testing.T) {
+	t.Parallel()
+
+	var plan *cluster.DeletionPlan
+
+	plan.AddNodes(cluster.OperationPhaseKubernetes, []string{"master1"})
+	plan.AddTerraformPlan(cluster.OperationPhaseKubernetes, &tfjson.Plan{})
+
+	require.NoError(t, plan.AddManifests(cluster.OperationPhaseDistribution, []byte("kind: Namespace")))
+	require.NoError(t, plan.AddKustomization(cluster.OperationPhaseDistribution, "kustomize", t.TempDir()))
+	assert.Nil(t, plan)
+}
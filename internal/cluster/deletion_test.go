@@ -0,0 +1,326 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package cluster_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sighupio/furyctl/internal/atrest"
+	"github.com/sighupio/furyctl/internal/cluster"
+)
+
+const storedConfig = "metadata:\n  name: test\n  deletionProtection: true\n"
+
+var errClusterUnreachable = errors.New("cluster unreachable")
+
+type fakeStore struct {
+	config []byte
+	err    error
+}
+
+func (*fakeStore) StoreKFD() error {
+	return nil
+}
+
+func (*fakeStore) StoreConfig(_ map[string]any) error {
+	return nil
+}
+
+func (s *fakeStore) GetConfig() ([]byte, error) {
+	return s.config, s.err
+}
+
+func (s *fakeStore) GetRenderedConfig() ([]byte, error) {
+	return s.config, s.err
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	t.Parallel()
+
+	protected := map[string]any{"metadata": map[string]any{"name": "test", "deletionProtection": true}}
+	unprotected := map[string]any{"metadata": map[string]any{"name": "test", "deletionProtection": false}}
+	unset := map[string]any{"metadata": map[string]any{"name": "test"}}
+	invalid := map[string]any{"metadata": map[string]any{"name": "test", "deletionProtection": "true"}}
+
+	testCases := []struct {
+		desc    string
+		local   map[string]any
+		stored  map[string]any
+		wantErr error
+	}{
+		{
+			desc:  "protection not set",
+			local: unset,
+		},
+		{
+			desc:   "protection disabled everywhere",
+			local:  unprotected,
+			stored: unprotected,
+		},
+		{
+			desc:    "protection enabled in the configuration file",
+			local:   protected,
+			stored:  unprotected,
+			wantErr: cluster.ErrDeletionProtected,
+		},
+		{
+			desc:    "protection disabled locally but not applied yet",
+			local:   unprotected,
+			stored:  protected,
+			wantErr: cluster.ErrDeletionProtected,
+		},
+		{
+			desc:    "protection enabled and cluster unreachable",
+			local:   protected,
+			wantErr: cluster.ErrDeletionProtected,
+		},
+		{
+			desc:    "protection set to a string in the configuration file",
+			local:   invalid,
+			wantErr: cluster.ErrInvalidDeletionProtection,
+		},
+		{
+			desc:    "protection set to a string in the configuration applied to the cluster",
+			local:   unprotected,
+			stored:  invalid,
+			wantErr: cluster.ErrInvalidDeletionProtection,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := cluster.CheckDeletionProtection(tc.local, tc.stored)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReadStoredConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		store   *fakeStore
+		wantErr bool
+	}{
+		{
+			desc:  "configuration of the cluster",
+			store: &fakeStore{config: []byte(storedConfig)},
+		},
+		{
+			desc:    "configuration of another cluster",
+			store:   &fakeStore{config: []byte("metadata:\n  name: other\n")},
+			wantErr: true,
+		},
+		{
+			desc:    "cluster unreachable",
+			store:   &fakeStore{err: errClusterUnreachable},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			raw, stored, err := cluster.ReadStoredConfig(tc.store, "test")
+			if tc.wantErr {
+				require.ErrorIs(t, err, cluster.ErrStoredConfigUnavailable)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, storedConfig, string(raw))
+
+			enabled, err := cluster.DeletionProtection(stored)
+			require.NoError(t, err)
+			assert.True(t, enabled)
+		})
+	}
+}
+
+func TestDeletionBackup_Create(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc       string
+		store      *fakeStore
+		wantStored bool
+	}{
+		{
+			desc:       "cluster reachable",
+			store:      &fakeStore{config: []byte(storedConfig)},
+			wantStored: true,
+		},
+		{
+			desc:  "kubeconfig of another cluster",
+			store: &fakeStore{config: []byte("metadata:\n  name: other\n")},
+		},
+		{
+			desc:  "cluster unreachable",
+			store: &fakeStore{err: errClusterUnreachable},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			workDir := filepath.Join(tmpDir, ".furyctl", "test")
+
+			writeFile(t, filepath.Join(tmpDir, "furyctl.yaml"), "local")
+			writeFile(t, filepath.Join(tmpDir, "distro", "kfd.yaml"), "kfd")
+			writeFile(t, filepath.Join(workDir, cluster.OperationPhaseKubernetes, "admin.conf"), "admin")
+
+			now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+			archive, err := cluster.DeletionBackup{
+				Kind:        "OnPremises",
+				ClusterName: "test",
+				ConfigPath:  filepath.Join(tmpDir, "furyctl.yaml"),
+				DistroPath:  filepath.Join(tmpDir, "distro"),
+				WorkDir:     workDir,
+				StateStore:  tc.store,
+			}.Create(now)
+			require.NoError(t, err)
+
+			assert.Equal(t, filepath.Join(workDir, "backups", "20240102030405.tar.gz"), archive)
+
+			got := readArchive(t, archive)
+
+			assert.Equal(t, "local", got["furyctl.yaml"])
+			assert.Equal(t, "kfd", got["kfd.yaml"])
+			assert.Equal(t, "admin", got["kubeconfig/onpremises-kubeconfig"])
+
+			stored, ok := got["state/furyctl.yaml"]
+			require.Equal(t, tc.wantStored, ok)
+
+			if tc.wantStored {
+				assert.Equal(t, storedConfig, stored)
+			}
+		})
+	}
+}
+
+func TestDeletionBackup_CreateMissingConfig(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	_, err := cluster.DeletionBackup{
+		Kind:       "OnPremises",
+		ConfigPath: filepath.Join(tmpDir, "furyctl.yaml"),
+		WorkDir:    tmpDir,
+	}.Create(time.Now())
+	require.Error(t, err)
+
+	_, err = os.Stat(filepath.Join(tmpDir, "backups"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestDeletionBackup_CreateEncrypted(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	idPath := filepath.Join(tmpDir, "key.txt")
+	writeFile(t, idPath, id.String()+"\n")
+	writeFile(t, filepath.Join(tmpDir, "furyctl.yaml"), "local")
+
+	session := atrest.NewSession()
+	require.NoError(t, session.Configure(atrest.Config{IdentityPath: idPath}))
+
+	archive, err := cluster.DeletionBackup{
+		Kind:       "OnPremises",
+		ConfigPath: filepath.Join(tmpDir, "furyctl.yaml"),
+		WorkDir:    tmpDir,
+		AtRest:     session,
+	}.Create(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(tmpDir, "backups", "20240102030405"+atrest.ArchiveExt), archive)
+
+	f, err := os.Open(archive)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	r, err := age.Decrypt(f, id)
+	require.NoError(t, err)
+
+	assert.Equal(t, "local", readTar(t, r)["furyctl.yaml"])
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func readArchive(t *testing.T, path string) map[string]string {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	return readTar(t, zr)
+}
+
+func readTar(t *testing.T, r io.Reader) map[string]string {
+	t.Helper()
+
+	files := make(map[string]string)
+	tr := tar.NewReader(r)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files
+		}
+
+		require.NoError(t, err)
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+
+		files[hdr.Name] = string(content)
+	}
+}
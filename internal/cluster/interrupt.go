@@ -0,0 +1,49 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+)
+
+var ErrInterrupted = errors.New("operation interrupted")
+
+// Interrupted returns an error if ctx has been canceled, so that no new step of an operation is started.
+func Interrupted(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+
+	if cause := context.Cause(ctx); !errors.Is(cause, ErrInterrupted) {
+		return fmt.Errorf("%w: %w", ErrInterrupted, cause)
+	}
+
+	return context.Cause(ctx) //nolint:wrapcheck // The cause is already an ErrInterrupted.
+}
+
+// StopOnCancel asks the tools run by a phase to stop, through its stop function, when ctx is canceled. Tools already
+// interrupted by the terminal are left alone, as a second interrupt would make them abort.
+// The returned function must be called when the phase is over.
+func StopOnCancel(ctx context.Context, phase string, stop func() error) func() bool {
+	return context.AfterFunc(ctx, func() {
+		if execx.SignaledByTerminal(ctx) {
+			logrus.Infof("Waiting for the %s phase to stop...", phase)
+
+			return
+		}
+
+		logrus.Infof("Stopping %s phase...", phase)
+
+		if err := stop(); err != nil {
+			logrus.Errorf("error while stopping %s phase: %v", phase, err)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sighupio/fury-distribution/pkg/apis/config"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+const (
+	KubeconfigCreatorPropertyFuryctlConf = "furyctlconf"
+	KubeconfigCreatorPropertyConfigPath  = "configpath"
+	KubeconfigCreatorPropertyKfdManifest = "kfdmanifest"
+	KubeconfigCreatorPropertyDistroPath  = "distropath"
+	KubeconfigCreatorPropertyWorkDir     = "workdir"
+
+	KubeconfigCreatorPropertyUser      = "user"
+	KubeconfigCreatorPropertyGroups    = "groups"
+	KubeconfigCreatorPropertyTTL       = "ttl"
+	KubeconfigCreatorPropertyAuditFile = "auditfile"
+
+	KubeconfigCreatorPropertyAllowSystemMasters = "allowsystemmasters"
+)
+
+var kubeconfigCreatorFactories = make(map[string]map[string]KubeconfigCreatorFactory) //nolint:gochecknoglobals, lll // This patterns requires kubeconfigCreatorFactories as global to work with init function.
+
+type KubeconfigCreatorFactory func(configPath string, props []KubeconfigCreatorProperty) (KubeconfigCreator, error) //nolint:lll // This pattern requires KubeconfigCreatorFactory as global to work with init function.
+
+type KubeconfigCreatorProperty struct {
+	Name  string
+	Value any
+}
+
+// KubeconfigCreatorOptions describes the user the kubeconfig is issued to.
+type KubeconfigCreatorOptions struct {
+	// User is the Kubernetes username, set as the common name of the client certificate.
+	User string
+	// Groups are the Kubernetes groups of the user, set as the organizations of the client certificate.
+	Groups []string
+	// TTL is the validity of the client certificate.
+	TTL time.Duration
+	// AuditFile is the file every issued kubeconfig is logged to.
+	AuditFile string
+	// AllowSystemMasters allows issuing a kubeconfig for the system:masters group, which bypasses RBAC.
+	AllowSystemMasters bool
+}
+
+// KubeconfigCreator issues kubeconfigs authenticating users with client certificates signed by the cluster CA.
+type KubeconfigCreator interface {
+	SetProperties(props []KubeconfigCreatorProperty)
+	SetProperty(name string, value any)
+	Create() error
+}
+
+func NewKubeconfigCreator(
+	minimalConf config.Furyctl,
+	kfdManifest config.KFD,
+	distroPath string,
+	configPath string,
+	workDir string,
+	opts KubeconfigCreatorOptions,
+) (KubeconfigCreator, error) {
+	lcAPIVersion := strings.ToLower(minimalConf.APIVersion)
+	lcResourceType := strings.ToLower(minimalConf.Kind)
+
+	if factoryFn, ok := kubeconfigCreatorFactories[lcAPIVersion][lcResourceType]; ok {
+		return factoryFn(configPath, []KubeconfigCreatorProperty{
+			{
+				Name:  KubeconfigCreatorPropertyKfdManifest,
+				Value: kfdManifest,
+			},
+			{
+				Name:  KubeconfigCreatorPropertyDistroPath,
+				Value: distroPath,
+			},
+			{
+				Name:  KubeconfigCreatorPropertyWorkDir,
+				Value: workDir,
+			},
+			{
+				Name:  KubeconfigCreatorPropertyUser,
+				Value: opts.User,
+			},
+			{
+				Name:  KubeconfigCreatorPropertyGroups,
+				Value: opts.Groups,
+			},
+			{
+				Name:  KubeconfigCreatorPropertyTTL,
+				Value: opts.TTL,
+			},
+			{
+				Name:  KubeconfigCreatorPropertyAuditFile,
+				Value: opts.AuditFile,
+			},
+			{
+				Name:  KubeconfigCreatorPropertyAllowSystemMasters,
+				Value: opts.AllowSystemMasters,
+			},
+		})
+	}
+
+	return nil, fmt.Errorf("%w -  type '%s' api version '%s'", errResourceNotSupported, lcResourceType, lcAPIVersion)
+}
+
+func RegisterKubeconfigCreatorFactory(apiVersion, kind string, factory KubeconfigCreatorFactory) {
+	lcAPIVersion := strings.ToLower(apiVersion)
+	lcKind := strings.ToLower(kind)
+
+	if _, ok := kubeconfigCreatorFactories[lcAPIVersion]; !ok {
+		kubeconfigCreatorFactories[lcAPIVersion] = make(map[string]KubeconfigCreatorFactory)
+	}
+
+	kubeconfigCreatorFactories[lcAPIVersion][lcKind] = factory
+}
+
+func NewKubeconfigCreatorFactory[T KubeconfigCreator, S any](cc T) KubeconfigCreatorFactory {
+	return func(configPath string, props []KubeconfigCreatorProperty) (KubeconfigCreator, error) {
+		furyctlConf, err := yamlx.FromFileV3[S](configPath)
+		if err != nil {
+			return nil, err
+		}
+
+		cc.SetProperty(KubeconfigCreatorPropertyConfigPath, configPath)
+		cc.SetProperty(KubeconfigCreatorPropertyFuryctlConf, furyctlConf)
+		cc.SetProperties(props)
+
+		return cc, nil
+	}
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
+	"github.com/sighupio/furyctl/internal/atrest"
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	iox "github.com/sighupio/furyctl/internal/x/io"
 	slicesx "github.com/sighupio/furyctl/internal/x/slices"
 	"github.com/sighupio/furyctl/pkg/merge"
@@ -188,19 +190,19 @@ type OperationPhaseOption struct {
 func NewOperationPhase(folder string, kfdTools config.KFDTools, binPath string) *OperationPhase {
 	basePath := folder
 
-	kustomizePath := path.Join(binPath, "kustomize", kfdTools.Common.Kustomize.Version, "kustomize")
-	kubectlPath := path.Join(binPath, "kubectl", kfdTools.Common.Kubectl.Version, "kubectl")
-	yqPath := path.Join(binPath, "yq", kfdTools.Common.Yq.Version, "yq")
-	helmPath := path.Join(binPath, "helm", kfdTools.Common.Helm.Version, "helm")
-	helmfilePath := path.Join(binPath, "helmfile", kfdTools.Common.Helmfile.Version, "helmfile")
-	kappPath := path.Join(binPath, "kapp", kfdTools.Common.Kapp.Version, "kapp")
+	kustomizePath := path.Join(platform.ToolPath(binPath, "kustomize", kfdTools.Common.Kustomize.Version), "kustomize")
+	kubectlPath := path.Join(platform.ToolPath(binPath, "kubectl", kfdTools.Common.Kubectl.Version), "kubectl")
+	yqPath := path.Join(platform.ToolPath(binPath, "yq", kfdTools.Common.Yq.Version), "yq")
+	helmPath := path.Join(platform.ToolPath(binPath, "helm", kfdTools.Common.Helm.Version), "helm")
+	helmfilePath := path.Join(platform.ToolPath(binPath, "helmfile", kfdTools.Common.Helmfile.Version), "helmfile")
+	kappPath := path.Join(platform.ToolPath(binPath, "kapp", kfdTools.Common.Kapp.Version), "kapp")
 
 	var terraformPath string
 
 	if kfdTools.Common.OpenTofu.Version != "" {
-		terraformPath = path.Join(binPath, "opentofu", kfdTools.Common.OpenTofu.Version, "tofu")
+		terraformPath = path.Join(platform.ToolPath(binPath, "opentofu", kfdTools.Common.OpenTofu.Version), "tofu")
 	} else {
-		terraformPath = path.Join(binPath, "terraform", kfdTools.Common.Terraform.Version, "terraform")
+		terraformPath = path.Join(platform.ToolPath(binPath, "terraform", kfdTools.Common.Terraform.Version), "terraform")
 	}
 
 	planPath := path.Join(basePath, "terraform", "plan")
@@ -251,6 +253,10 @@ func (op *OperationPhase) CreateTerraformFolderStructure() error {
 		}
 	}
 
+	if err := atrest.Default.Protect(op.TerraformSecretsPath); err != nil {
+		return fmt.Errorf("error decrypting folder %s: %w", op.TerraformSecretsPath, err)
+	}
+
 	if _, err := os.Stat(op.TerraformSecretsPath); os.IsNotExist(err) {
 		if err := os.Mkdir(op.TerraformSecretsPath, iox.FullPermAccess); err != nil {
 			return fmt.Errorf("error creating folder %s: %w", op.TerraformSecretsPath, err)
@@ -0,0 +1,169 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clusterpki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+	pki "k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+)
+
+var (
+	ErrCAKeyMissing     = errors.New("both the CA certificate and its private key must be provided")
+	ErrCANotCA          = errors.New("certificate is not a CA: basic constraints CA:TRUE is missing")
+	ErrCANoCertSign     = errors.New("CA certificate key usage does not allow signing certificates")
+	ErrCAPathLen        = errors.New("CA certificate path length constraint does not allow intermediate CAs")
+	ErrCANotValid       = errors.New("CA certificate is not valid at the current time")
+	ErrCAKeyMismatch    = errors.New("CA private key does not match the certificate")
+	ErrCAKeyUnsupported = errors.New("CA private key must be an RSA or ECDSA key")
+	ErrCAChainInvalid   = errors.New("CA certificate chain is not valid")
+	ErrCAChainNotIssued = errors.New("certificate of the chain is not issued by the next one")
+)
+
+// CA is an existing certificate authority, such as an intermediate issued by a corporate root, imported in the PKI
+// instead of a self-signed one.
+type CA struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+	// Chain holds the certificates following Cert in the imported file, up to the root when present.
+	Chain []*x509.Certificate
+}
+
+// LoadCA reads a CA from the PEM encoded certificate at certPath, optionally followed by its chain, and the private
+// key at keyPath.
+func LoadCA(certPath, keyPath string, now time.Time) (*CA, error) {
+	if certPath == "" || keyPath == "" {
+		return nil, ErrCAKeyMissing
+	}
+
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading CA certificate: %w", err)
+	}
+
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading CA private key: %w", err)
+	}
+
+	ca, err := ParseCA(certPEM, keyPEM, now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA %s: %w", certPath, err)
+	}
+
+	return ca, nil
+}
+
+// ParseCA parses and validates a CA: the certificate must be allowed to sign certificates and be valid at now, the key
+// must match it and every certificate of the chain must be issued by the following one.
+func ParseCA(certPEM, keyPEM []byte, now time.Time) (*CA, error) {
+	certs, err := certutil.ParseCertsPEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing CA certificate: %w", err)
+	}
+
+	key, err := keyutil.ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing CA private key: %w", err)
+	}
+
+	var signer crypto.Signer
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signer = k
+
+	case *ecdsa.PrivateKey:
+		signer = k
+
+	default:
+		return nil, ErrCAKeyUnsupported
+	}
+
+	ca := &CA{Cert: certs[0], Key: signer, Chain: certs[1:]}
+
+	if err := checkCA(ca.Cert, now); err != nil {
+		return nil, err
+	}
+
+	pub, ok := ca.Cert.PublicKey.(interface{ Equal(x crypto.PublicKey) bool })
+	if !ok || !pub.Equal(signer.Public()) {
+		return nil, ErrCAKeyMismatch
+	}
+
+	if err := ca.verifyChain(now); err != nil {
+		return nil, err
+	}
+
+	return ca, nil
+}
+
+// Files returns the PEM encoded certificate and key of the CA. Only the CA certificate is returned, not the chain:
+// the nodes use the file to authenticate clients, and trusting the root would accept any certificate it issued.
+func (ca *CA) Files() ([]byte, []byte) {
+	return pki.EncodeCertPEM(ca.Cert), EncodePrivateKey(ca.Key)
+}
+
+func (ca *CA) verifyChain(now time.Time) error {
+	chain := append([]*x509.Certificate{ca.Cert}, ca.Chain...)
+
+	for i, cert := range chain[1:] {
+		if err := checkCA(cert, now); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrCAChainInvalid, cert.Subject, err)
+		}
+
+		// The path length constraint counts the intermediate CAs that can follow the certificate in the chain.
+		if (cert.MaxPathLen > 0 || cert.MaxPathLenZero) && cert.MaxPathLen < i+1 {
+			return fmt.Errorf("%w: %s: %w", ErrCAChainInvalid, cert.Subject, ErrCAPathLen)
+		}
+
+		if err := chain[i].CheckSignatureFrom(cert); err != nil {
+			return fmt.Errorf("%w: %s: %w: %w", ErrCAChainInvalid, chain[i].Subject, ErrCAChainNotIssued, err)
+		}
+	}
+
+	return nil
+}
+
+func checkCA(cert *x509.Certificate, now time.Time) error {
+	if !cert.BasicConstraintsValid || !cert.IsCA {
+		return ErrCANotCA
+	}
+
+	if cert.KeyUsage != 0 && cert.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return ErrCANoCertSign
+	}
+
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return fmt.Errorf("%w: valid from %s to %s", ErrCANotValid, cert.NotBefore, cert.NotAfter)
+	}
+
+	return nil
+}
+
+// newOrImportedCA returns the PEM encoded certificate and key of ca, or of a new CA when ca is nil.
+func newOrImportedCA(ca *CA, certConfig *pki.CertConfig) ([]byte, []byte, error) {
+	if ca != nil {
+		crt, key := ca.Files()
+
+		return crt, key, nil
+	}
+
+	crt, key, err := pki.NewCertificateAuthority(certConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while creating CA: %w", err)
+	}
+
+	return pki.EncodeCertPEM(crt), EncodePrivateKey(key), nil
+}
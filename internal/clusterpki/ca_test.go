@@ -0,0 +1,165 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package clusterpki_test
+
+import (
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certutil "k8s.io/client-go/util/cert"
+	pki "k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+
+	"github.com/sighupio/furyctl/internal/clusterpki"
+)
+
+func TestParseCA(t *testing.T) {
+	t.Parallel()
+
+	rootCfg := &pki.CertConfig{Config: certutil.Config{CommonName: "corporate-root"}}
+	root, rootKey, err := pki.NewCertificateAuthority(rootCfg)
+	require.NoError(t, err)
+
+	intermediate, intermediateKey, err := pki.NewIntermediateCertificateAuthority(
+		root,
+		rootKey,
+		&pki.CertConfig{Config: certutil.Config{CommonName: "kubernetes-intermediate"}},
+	)
+	require.NoError(t, err)
+
+	other, otherKey, err := pki.NewCertificateAuthority(&pki.CertConfig{Config: certutil.Config{CommonName: "other"}})
+	require.NoError(t, err)
+
+	leaf, leafKey, err := pki.NewCertAndKey(root, rootKey, &pki.CertConfig{Config: certutil.Config{
+		CommonName: "leaf",
+		Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}})
+	require.NoError(t, err)
+
+	pem := func(certs ...[]byte) []byte {
+		out := []byte{}
+		for _, c := range certs {
+			out = append(out, c...)
+		}
+
+		return out
+	}
+
+	now := time.Now()
+
+	testCases := []struct {
+		desc    string
+		cert    []byte
+		key     []byte
+		now     time.Time
+		wantErr error
+	}{
+		{
+			desc: "self-signed CA",
+			cert: pki.EncodeCertPEM(root),
+			key:  clusterpki.EncodePrivateKey(rootKey),
+			now:  now,
+		},
+		{
+			desc: "intermediate CA without chain",
+			cert: pki.EncodeCertPEM(intermediate),
+			key:  clusterpki.EncodePrivateKey(intermediateKey),
+			now:  now,
+		},
+		{
+			desc: "intermediate CA with chain",
+			cert: pem(pki.EncodeCertPEM(intermediate), pki.EncodeCertPEM(root)),
+			key:  clusterpki.EncodePrivateKey(intermediateKey),
+			now:  now,
+		},
+		{
+			desc:    "chain not issuing the CA",
+			cert:    pem(pki.EncodeCertPEM(intermediate), pki.EncodeCertPEM(other)),
+			key:     clusterpki.EncodePrivateKey(intermediateKey),
+			now:     now,
+			wantErr: clusterpki.ErrCAChainNotIssued,
+		},
+		{
+			desc:    "key not matching",
+			cert:    pki.EncodeCertPEM(root),
+			key:     clusterpki.EncodePrivateKey(otherKey),
+			now:     now,
+			wantErr: clusterpki.ErrCAKeyMismatch,
+		},
+		{
+			desc:    "not a CA",
+			cert:    pki.EncodeCertPEM(leaf),
+			key:     clusterpki.EncodePrivateKey(leafKey),
+			now:     now,
+			wantErr: clusterpki.ErrCANotCA,
+		},
+		{
+			desc:    "expired CA",
+			cert:    pki.EncodeCertPEM(other),
+			key:     clusterpki.EncodePrivateKey(otherKey),
+			now:     other.NotAfter.Add(time.Hour),
+			wantErr: clusterpki.ErrCANotValid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ca, err := clusterpki.ParseCA(tc.cert, tc.key, tc.now)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			crt, _ := ca.Files()
+
+			// Only the CA certificate is kept, not the chain.
+			assert.Equal(t, tc.cert[:len(crt)], crt)
+		})
+	}
+}
+
+func TestControlPlanePKI_CreateWithImportedCA(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	caCert, caKey, err := pki.NewCertificateAuthority(
+		&pki.CertConfig{Config: certutil.Config{CommonName: "kubernetes-intermediate"}},
+	)
+	require.NoError(t, err)
+
+	cp := clusterpki.ControlPlanePKI{
+		ClusterPKI: clusterpki.ClusterPKI{Config: clusterpki.Config{Path: dir, CertConfig: clusterpki.DefaultCertConfig()}},
+		CA:         &clusterpki.CA{Cert: caCert, Key: caKey},
+	}
+
+	require.NoError(t, cp.Create())
+
+	crt, err := os.ReadFile(filepath.Join(dir, clusterpki.ControlPlanePath, clusterpki.ControlPlaneCaCrt))
+	require.NoError(t, err)
+	assert.Equal(t, pki.EncodeCertPEM(caCert), crt)
+
+	// The other artifacts are generated in the same layout.
+	for _, name := range []string{
+		clusterpki.ControlPlaneCaKey,
+		clusterpki.ControlPlaneSaKey,
+		clusterpki.ControlPlaneSaPub,
+		clusterpki.ControlPlaneFProxyCrt,
+		clusterpki.ControlPlaneFProxyKey,
+	} {
+		assert.FileExists(t, filepath.Join(dir, clusterpki.ControlPlanePath, name))
+	}
+}
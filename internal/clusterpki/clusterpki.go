@@ -14,6 +14,8 @@ import (
 	pki "k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
 )
 
+const permOwnerGroup os.FileMode = 0o770
+
 type ClusterPKI struct {
 	Config
 }
@@ -47,10 +49,6 @@ type ControlPlaneConfig struct {
 }
 
 func (c *Config) save(files map[string][]byte, dir string) error {
-	const (
-		permOwnerGroup os.FileMode = 0o770
-	)
-
 	basePath := filepath.Join(c.Path, dir)
 	logrus.Debugf("checking if target path %s exists before proceeding", basePath)
 
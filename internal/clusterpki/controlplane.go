@@ -5,6 +5,8 @@
 package clusterpki
 
 import (
+	"fmt"
+
 	"github.com/sirupsen/logrus"
 	pki "k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
 )
@@ -26,13 +28,16 @@ const (
 // ControlPlanePKI implements the ClusterComponent interface.
 type ControlPlanePKI struct {
 	ClusterPKI
+	// CA is imported instead of generating a new one, when set. The service accounts key pair and the front-proxy CA
+	// are generated in any case.
+	CA *CA
 }
 
 func (cp ControlPlanePKI) Create() error {
 	// Create certificates for Kubernetes control plane.
-	caCert, caKey, err := pki.NewCertificateAuthority(&cp.CertConfig)
+	caCert, caKey, err := newOrImportedCA(cp.CA, &cp.CertConfig)
 	if err != nil {
-		logrus.Fatal(err)
+		return fmt.Errorf("error while creating CA for the control plane: %w", err)
 	}
 
 	saCert, saKey, err := pki.NewCertificateAuthority(&cp.CertConfig)
@@ -46,8 +51,8 @@ func (cp ControlPlanePKI) Create() error {
 	}
 
 	certs := map[string][]byte{
-		ControlPlaneCaCrt:     pki.EncodeCertPEM(caCert),
-		ControlPlaneCaKey:     EncodePrivateKey(caKey),
+		ControlPlaneCaCrt:     caCert,
+		ControlPlaneCaKey:     caKey,
 		ControlPlaneSaPub:     pki.EncodeCertPEM(saCert),
 		ControlPlaneSaKey:     EncodePrivateKey(saKey),
 		ControlPlaneFProxyCrt: pki.EncodeCertPEM(fpCert),
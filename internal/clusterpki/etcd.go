@@ -4,11 +4,7 @@
 
 package clusterpki
 
-import (
-	"fmt"
-
-	pki "k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
-)
+import "fmt"
 
 const (
 	EtcdCaCrt = "ca.crt"
@@ -19,17 +15,19 @@ const (
 // Etcd implements the ClusterComponent Interface.
 type Etcd struct {
 	ClusterPKI
+	// CA is imported instead of generating a new one, when set.
+	CA *CA
 }
 
 func (e Etcd) Create() error {
-	ca, privateKey, err := pki.NewCertificateAuthority(&e.CertConfig)
+	ca, privateKey, err := newOrImportedCA(e.CA, &e.CertConfig)
 	if err != nil {
 		return fmt.Errorf("error while creating CA for etcd: %w", err)
 	}
 
 	certs := map[string][]byte{
-		EtcdCaCrt: pki.EncodeCertPEM(ca),
-		EtcdCaKey: EncodePrivateKey(privateKey),
+		EtcdCaCrt: ca,
+		EtcdCaKey: privateKey,
 	}
 
 	return e.save(certs, etcdPath)
@@ -0,0 +1,83 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clusterpki
+
+import (
+	"fmt"
+	"time"
+)
+
+// ExternalCertificate describes certificates of the cluster that furyctl does not renew, along with who renews them.
+type ExternalCertificate struct {
+	Name      string
+	ManagedBy string
+	// NotAfter is set only when the certificate can be read from the configuration file.
+	NotAfter time.Time
+}
+
+func (c ExternalCertificate) String() string {
+	if c.NotAfter.IsZero() {
+		return fmt.Sprintf("%s: managed by %s", c.Name, c.ManagedBy)
+	}
+
+	return fmt.Sprintf("%s: managed by %s, expires on %s", c.Name, c.ManagedBy, c.NotAfter.Format(time.DateOnly))
+}
+
+// ExternallyManaged lists the certificates of the distribution modules that furyctl does not renew, given the
+// furyctl.yaml file unmarshalled into furyctlConf.
+func ExternallyManaged(furyctlConf map[string]any, now time.Time) []ExternalCertificate {
+	certs := []ExternalCertificate{}
+
+	ingressManagedBy := ""
+
+	switch lookup(furyctlConf, "spec", "distribution", "modules", "ingress", "tls", "provider") {
+	case "certManager":
+		ingressManagedBy = "cert-manager, with the issuer set in .spec.distribution.modules.ingress.certManager"
+
+		certs = append(certs, ExternalCertificate{Name: "Ingress certificates", ManagedBy: ingressManagedBy})
+
+	case "secret":
+		ingressManagedBy = "the user, in .spec.distribution.modules.ingress.tls.secret, re-run apply after updating it"
+
+		cert := ExternalCertificate{Name: "Ingress certificate", ManagedBy: ingressManagedBy}
+
+		// The certificate can also be a reference to a file or an environment variable, that is not resolved here.
+		pemCert, _ := lookup(furyctlConf, "spec", "distribution", "modules", "ingress", "tls", "secret", "cert").(string)
+		if parsed, err := ParseCertificates([]byte(pemCert), "", "", now); err == nil && len(parsed) > 0 {
+			cert.NotAfter = parsed[0].NotAfter
+		}
+
+		certs = append(certs, cert)
+	}
+
+	authProvider := lookup(furyctlConf, "spec", "distribution", "modules", "auth", "provider", "type")
+	if authProvider == "sso" && ingressManagedBy != "" {
+		certs = append(certs, ExternalCertificate{
+			Name:      "Dex and Pomerium certificates, served through the Ingress",
+			ManagedBy: ingressManagedBy,
+		})
+	}
+
+	return append(certs, ExternalCertificate{
+		Name:      "Webhooks serving certificates and cert-manager internal CAs",
+		ManagedBy: "cert-manager and the operators of the modules, in the cluster",
+	})
+}
+
+// lookup returns the value found following keys in the nested maps of m, nil if any of them is missing.
+func lookup(m map[string]any, keys ...string) any {
+	var v any = m
+
+	for _, key := range keys {
+		mm, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		v = mm[key]
+	}
+
+	return v
+}
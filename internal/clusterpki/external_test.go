@@ -0,0 +1,147 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package clusterpki_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certutil "k8s.io/client-go/util/cert"
+	pki "k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+
+	"github.com/sighupio/furyctl/internal/clusterpki"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+func TestExternallyManaged(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc      string
+		conf      string
+		wantNames []string
+	}{
+		{
+			desc: "cert-manager with sso",
+			conf: `
+spec:
+  distribution:
+    modules:
+      ingress:
+        tls:
+          provider: certManager
+      auth:
+        provider:
+          type: sso
+`,
+			wantNames: []string{
+				"Ingress certificates",
+				"Dex and Pomerium certificates, served through the Ingress",
+				"Webhooks serving certificates and cert-manager internal CAs",
+			},
+		},
+		{
+			desc: "secret referenced from a file",
+			conf: `
+spec:
+  distribution:
+    modules:
+      ingress:
+        tls:
+          provider: secret
+          secret:
+            cert: "{file://./tls.crt}"
+`,
+			wantNames: []string{
+				"Ingress certificate",
+				"Webhooks serving certificates and cert-manager internal CAs",
+			},
+		},
+		{
+			desc: "no tls",
+			conf: `
+spec:
+  distribution:
+    modules:
+      ingress:
+        tls:
+          provider: none
+      auth:
+        provider:
+          type: sso
+`,
+			wantNames: []string{
+				"Webhooks serving certificates and cert-manager internal CAs",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var conf map[string]any
+
+			require.NoError(t, yamlx.UnmarshalV3([]byte(tc.conf), &conf))
+
+			names := []string{}
+
+			for _, c := range clusterpki.ExternallyManaged(conf, time.Now()) {
+				assert.Zero(t, c.NotAfter)
+
+				names = append(names, c.Name)
+			}
+
+			assert.Equal(t, tc.wantNames, names)
+		})
+	}
+}
+
+func TestExternallyManaged_InlineSecret(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	etcd := clusterpki.Etcd{ClusterPKI: clusterpki.ClusterPKI{Config: clusterpki.Config{
+		Path:       dir,
+		CertConfig: pki.CertConfig{Config: certutil.Config{CommonName: "ingress"}},
+	}}}
+
+	require.NoError(t, etcd.Create())
+
+	cert, err := os.ReadFile(filepath.Join(dir, "etcd", clusterpki.EtcdCaCrt))
+	require.NoError(t, err)
+
+	conf := map[string]any{"spec": map[string]any{"distribution": map[string]any{"modules": map[string]any{
+		"ingress": map[string]any{"tls": map[string]any{
+			"provider": "secret",
+			"secret":   map[string]any{"cert": string(cert)},
+		}},
+	}}}}
+
+	certs := clusterpki.ExternallyManaged(conf, time.Now())
+	require.NotEmpty(t, certs)
+
+	assert.Equal(t, "Ingress certificate", certs[0].Name)
+	assert.True(t, certs[0].NotAfter.After(time.Now()))
+}
+
+func TestExternalCertificate_String(t *testing.T) {
+	t.Parallel()
+
+	c := clusterpki.ExternalCertificate{Name: "Ingress certificate", ManagedBy: "the user"}
+
+	assert.Equal(t, "Ingress certificate: managed by the user", c.String())
+
+	c.NotAfter = time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "Ingress certificate: managed by the user, expires on 2027-03-01", c.String())
+}
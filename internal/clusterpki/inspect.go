@@ -0,0 +1,181 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clusterpki
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const hoursPerDay = 24
+
+var ErrInvalidThreshold = errors.New("invalid threshold, use a number of days (e.g. 30d) or a duration (e.g. 720h)")
+
+const (
+	CertificateStatusOK       = "OK"
+	CertificateStatusExpiring = "EXPIRING"
+	CertificateStatusExpired  = "EXPIRED"
+)
+
+// Certificate describes a certificate of the cluster's PKI, found locally or on one of the nodes.
+type Certificate struct {
+	Node          string    `json:"node,omitempty"`
+	Path          string    `json:"path"`
+	Subject       string    `json:"subject"`
+	Issuer        string    `json:"issuer"`
+	SANs          []string  `json:"sans,omitempty"`
+	NotAfter      time.Time `json:"notAfter"`
+	DaysRemaining int       `json:"daysRemaining"`
+}
+
+// Status tells whether the certificate is expired or expires within warnDays days.
+func (c Certificate) Status(warnDays int) string {
+	switch {
+	case c.DaysRemaining < 0:
+		return CertificateStatusExpired
+
+	case c.DaysRemaining < warnDays:
+		return CertificateStatusExpiring
+
+	default:
+		return CertificateStatusOK
+	}
+}
+
+// ParseCertificates returns the certificates contained in the PEM data read from path on node, blocks that are not
+// certificates (e.g. private keys) are skipped.
+func ParseCertificates(data []byte, node, path string, now time.Time) ([]Certificate, error) {
+	certs := []Certificate{}
+
+	for {
+		var block *pem.Block
+
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		x509Cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing certificate %s: %w", path, err)
+		}
+
+		sans := slices.Clone(x509Cert.DNSNames)
+		for _, ip := range x509Cert.IPAddresses {
+			sans = append(sans, ip.String())
+		}
+
+		certs = append(certs, Certificate{
+			Node:          node,
+			Path:          path,
+			Subject:       x509Cert.Subject.String(),
+			Issuer:        x509Cert.Issuer.String(),
+			SANs:          sans,
+			NotAfter:      x509Cert.NotAfter,
+			DaysRemaining: int(x509Cert.NotAfter.Sub(now).Hours() / hoursPerDay),
+		})
+	}
+
+	return certs, nil
+}
+
+// Inspect returns the certificates found in the PKI folder at dir, as created by the `furyctl create pki` command.
+func Inspect(dir string, now time.Time) ([]Certificate, error) {
+	certs := []Certificate{}
+
+	if err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !slices.Contains([]string{".crt", ".pub", ".pem"}, filepath.Ext(path)) {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error while reading %s: %w", path, err)
+		}
+
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return fmt.Errorf("error while getting relative path of %s: %w", path, err)
+		}
+
+		fileCerts, err := ParseCertificates(data, "", relPath, now)
+		if err != nil {
+			return err
+		}
+
+		certs = append(certs, fileCerts...)
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("error while inspecting PKI folder %s: %w", dir, err)
+	}
+
+	return certs, nil
+}
+
+// ExpiresWithin tells whether the certificate expires within threshold from now.
+func (c Certificate) ExpiresWithin(threshold time.Duration, now time.Time) bool {
+	return c.NotAfter.Sub(now) < threshold
+}
+
+// IsCA tells whether the certificate is a certificate authority, by its file name, as CAs are not renewed along with
+// the certificates they signed.
+func (c Certificate) IsCA() bool {
+	return strings.HasSuffix(filepath.Base(c.Path), "ca.crt")
+}
+
+// ParseThreshold parses an expiration threshold, expressed either as a number of days (e.g. 30d) or as a duration.
+func ParseThreshold(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidThreshold, s)
+		}
+
+		return time.Duration(n) * hoursPerDay * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidThreshold, s)
+	}
+
+	return d, nil
+}
+
+// NodesExpiringWithin returns the nodes, sorted, having at least one certificate that is not a CA expiring within
+// threshold from now.
+func NodesExpiringWithin(certs []Certificate, threshold time.Duration, now time.Time) []string {
+	nodes := []string{}
+
+	for _, c := range certs {
+		if c.Node == "" || c.IsCA() || !c.ExpiresWithin(threshold, now) || slices.Contains(nodes, c.Node) {
+			continue
+		}
+
+		nodes = append(nodes, c.Node)
+	}
+
+	slices.Sort(nodes)
+
+	return nodes
+}
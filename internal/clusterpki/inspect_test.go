@@ -0,0 +1,114 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package clusterpki_test
+
+import (
+	"crypto/x509"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certutil "k8s.io/client-go/util/cert"
+	pki "k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+
+	"github.com/sighupio/furyctl/internal/clusterpki"
+)
+
+func TestInspect(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	etcd := clusterpki.Etcd{ClusterPKI: clusterpki.ClusterPKI{Config: clusterpki.Config{
+		Path: dir,
+		CertConfig: pki.CertConfig{Config: certutil.Config{
+			CommonName: "etcd-ca",
+			AltNames:   certutil.AltNames{DNSNames: []string{"etcd.local"}, IPs: []net.IP{net.ParseIP("10.0.0.1")}},
+			Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}},
+	}}}
+
+	require.NoError(t, etcd.Create())
+
+	now := time.Now()
+
+	certs, err := clusterpki.Inspect(dir, now)
+	require.NoError(t, err)
+
+	// The private key is not a certificate and is skipped.
+	require.Len(t, certs, 1)
+
+	assert.Equal(t, filepath.Join("etcd", clusterpki.EtcdCaCrt), certs[0].Path)
+	assert.Equal(t, "CN=etcd-ca", certs[0].Subject)
+	assert.Equal(t, "CN=etcd-ca", certs[0].Issuer)
+	assert.Positive(t, certs[0].DaysRemaining)
+	assert.Equal(t, clusterpki.CertificateStatusOK, certs[0].Status(30))
+	assert.Equal(t, clusterpki.CertificateStatusExpiring, certs[0].Status(certs[0].DaysRemaining+1))
+}
+
+func TestCertificate_Status(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, clusterpki.CertificateStatusExpired, clusterpki.Certificate{DaysRemaining: -1}.Status(30))
+	assert.Equal(t, clusterpki.CertificateStatusExpiring, clusterpki.Certificate{DaysRemaining: 10}.Status(30))
+	assert.Equal(t, clusterpki.CertificateStatusOK, clusterpki.Certificate{DaysRemaining: 30}.Status(30))
+}
+
+func TestParseThreshold(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{desc: "days", in: "30d", want: 30 * 24 * time.Hour},
+		{desc: "duration", in: "36h", want: 36 * time.Hour},
+		{desc: "negative days", in: "-1d", wantErr: true},
+		{desc: "garbage", in: "soon", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := clusterpki.ParseThreshold(tc.in)
+			if tc.wantErr {
+				require.ErrorIs(t, err, clusterpki.ErrInvalidThreshold)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNodesExpiringWithin(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	soon := now.Add(10 * 24 * time.Hour)
+	later := now.Add(300 * 24 * time.Hour)
+
+	certs := []clusterpki.Certificate{
+		{Node: "master2", Path: "/etc/kubernetes/pki/apiserver.crt", NotAfter: soon},
+		{Node: "master1", Path: "/etc/kubernetes/pki/apiserver.crt", NotAfter: later},
+		{Node: "master1", Path: "/etc/kubernetes/pki/ca.crt", NotAfter: soon},
+		{Node: "master3", Path: "/etc/etcd/pki/etcd/server.crt", NotAfter: soon},
+		{Node: "master3", Path: "/etc/etcd/pki/etcd/peer.crt", NotAfter: soon},
+		{Path: "master/apiserver.crt", NotAfter: soon},
+	}
+
+	assert.Equal(t, []string{"master2", "master3"}, clusterpki.NodesExpiringWithin(certs, 30*24*time.Hour, now))
+	assert.Empty(t, clusterpki.NodesExpiringWithin(certs, 24*time.Hour, now))
+}
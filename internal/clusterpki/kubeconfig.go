@@ -0,0 +1,165 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clusterpki
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
+	pki "k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+)
+
+const (
+	// clockSkew backdates the client certificates, so that they are accepted by API servers whose clock is slightly
+	// behind.
+	clockSkew = 5 * time.Minute
+
+	// maxKubeconfigTTLDays is the longest validity of a user kubeconfig, as client certificates cannot be revoked.
+	maxKubeconfigTTLDays = 30
+	MaxKubeconfigTTL     = maxKubeconfigTTLDays * hoursPerDay * time.Hour
+
+	// SystemMastersGroup is the group bypassing RBAC.
+	SystemMastersGroup = "system:masters"
+)
+
+var (
+	ErrUserMissing       = errors.New("the user of the kubeconfig must be set")
+	ErrTTLNotValid       = errors.New("the TTL of the kubeconfig must be positive")
+	ErrTTLTooLong        = errors.New("the TTL of the kubeconfig exceeds the maximum")
+	ErrTTLExceedsCA      = errors.New("the kubeconfig would expire after the CA")
+	ErrServerMissing     = errors.New("the address of the API server must be set")
+	ErrSystemMastersUser = errors.New("the system:masters group bypasses RBAC and cannot be revoked before the " +
+		"kubeconfig expires, it must be explicitly allowed")
+)
+
+// UserKubeconfig describes a kubeconfig authenticating a user with a client certificate signed by the cluster CA.
+type UserKubeconfig struct {
+	ClusterName string
+	Server      string
+	User        string
+	Groups      []string
+	TTL         time.Duration
+	// AllowSystemMasters allows issuing a kubeconfig for the system:masters group.
+	AllowSystemMasters bool
+}
+
+// Issue signs a client certificate for the user with ca and returns the kubeconfig embedding it, along with the
+// certificate.
+func (u UserKubeconfig) Issue(ca *CA, now time.Time) ([]byte, *x509.Certificate, error) {
+	switch {
+	case u.User == "":
+		return nil, nil, ErrUserMissing
+
+	case u.TTL <= 0:
+		return nil, nil, ErrTTLNotValid
+
+	case u.TTL > MaxKubeconfigTTL:
+		return nil, nil, fmt.Errorf("%w of %dd", ErrTTLTooLong, maxKubeconfigTTLDays)
+
+	case slices.Contains(u.Groups, SystemMastersGroup) && !u.AllowSystemMasters:
+		return nil, nil, ErrSystemMastersUser
+
+	case u.Server == "":
+		return nil, nil, ErrServerMissing
+
+	case now.Add(u.TTL).After(ca.Cert.NotAfter):
+		return nil, nil, fmt.Errorf("%w, on %s", ErrTTLExceedsCA, ca.Cert.NotAfter.Format(time.DateOnly))
+	}
+
+	cert, key, err := pki.NewCertAndKey(ca.Cert, ca.Key, &pki.CertConfig{
+		Config: certutil.Config{
+			CommonName:   u.User,
+			Organization: u.Groups,
+			Usages:       []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			NotBefore:    now.Add(-clockSkew),
+		},
+		NotAfter: now.Add(u.TTL),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while signing client certificate: %w", err)
+	}
+
+	contextName := u.User + "@" + u.ClusterName
+
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[u.ClusterName] = &clientcmdapi.Cluster{
+		Server:                   u.Server,
+		CertificateAuthorityData: pki.EncodeCertPEM(ca.Cert),
+	}
+	kubeconfig.AuthInfos[u.User] = &clientcmdapi.AuthInfo{
+		ClientCertificateData: pki.EncodeCertPEM(cert),
+		ClientKeyData:         EncodePrivateKey(key),
+	}
+	kubeconfig.Contexts[contextName] = &clientcmdapi.Context{
+		Cluster:  u.ClusterName,
+		AuthInfo: u.User,
+	}
+	kubeconfig.CurrentContext = contextName
+
+	out, err := clientcmd.Write(*kubeconfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while encoding kubeconfig: %w", err)
+	}
+
+	return out, cert, nil
+}
+
+// KubeconfigIssuance is the record of an issued kubeconfig kept in the audit file.
+type KubeconfigIssuance struct {
+	Date     time.Time `json:"date"`
+	Cluster  string    `json:"cluster"`
+	User     string    `json:"user"`
+	Groups   []string  `json:"groups"`
+	Serial   string    `json:"serial"`
+	NotAfter time.Time `json:"notAfter"`
+	IssuedBy string    `json:"issuedBy"`
+}
+
+// NewKubeconfigIssuance returns the record of the kubeconfig issued with cert.
+func NewKubeconfigIssuance(cluster, issuedBy string, cert *x509.Certificate, now time.Time) KubeconfigIssuance {
+	return KubeconfigIssuance{
+		Date:     now.UTC(),
+		Cluster:  cluster,
+		User:     cert.Subject.CommonName,
+		Groups:   cert.Subject.Organization,
+		Serial:   cert.SerialNumber.Text(16),
+		NotAfter: cert.NotAfter.UTC(),
+		IssuedBy: issuedBy,
+	}
+}
+
+// AppendKubeconfigIssuance appends the issuance to the audit file at path, one JSON object per line.
+func AppendKubeconfigIssuance(path string, issuance KubeconfigIssuance) error {
+	line, err := json.Marshal(issuance)
+	if err != nil {
+		return fmt.Errorf("error while encoding kubeconfig issuance: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), permOwnerGroup); err != nil {
+		return fmt.Errorf("error while creating audit file folder: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("error while opening audit file: %w", err)
+	}
+
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error while writing audit file: %w", err)
+	}
+
+	return nil
+}
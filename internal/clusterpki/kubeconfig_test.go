@@ -0,0 +1,117 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package clusterpki_test
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+	certutil "k8s.io/client-go/util/cert"
+	pki "k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+
+	"github.com/sighupio/furyctl/internal/clusterpki"
+)
+
+func TestUserKubeconfig_Issue(t *testing.T) {
+	t.Parallel()
+
+	caCert, caKey, err := pki.NewCertificateAuthority(&pki.CertConfig{Config: certutil.Config{CommonName: "kubernetes"}})
+	require.NoError(t, err)
+
+	ca := &clusterpki.CA{Cert: caCert, Key: caKey}
+	now := time.Now()
+
+	uk := clusterpki.UserKubeconfig{
+		ClusterName: "furyctl",
+		Server:      "https://control-plane.example.com:6443",
+		User:        "alice",
+		Groups:      []string{"sre"},
+		TTL:         8 * time.Hour,
+	}
+
+	out, cert, err := uk.Issue(ca, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, "alice", cert.Subject.CommonName)
+	assert.Equal(t, []string{"sre"}, cert.Subject.Organization)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+	assert.WithinDuration(t, now.Add(8*time.Hour), cert.NotAfter, time.Second)
+	require.NoError(t, cert.CheckSignatureFrom(caCert))
+
+	kubeconfig, err := clientcmd.Load(out)
+	require.NoError(t, err)
+
+	assert.Equal(t, "alice@furyctl", kubeconfig.CurrentContext)
+	assert.Equal(t, "https://control-plane.example.com:6443", kubeconfig.Clusters["furyctl"].Server)
+	assert.Equal(t, pki.EncodeCertPEM(caCert), kubeconfig.Clusters["furyctl"].CertificateAuthorityData)
+	assert.Equal(t, pki.EncodeCertPEM(cert), kubeconfig.AuthInfos["alice"].ClientCertificateData)
+
+	uk.TTL = caCert.NotAfter.Sub(now) - time.Hour
+
+	_, _, err = uk.Issue(ca, now)
+	require.ErrorIs(t, err, clusterpki.ErrTTLTooLong)
+
+	uk.TTL = clusterpki.MaxKubeconfigTTL
+
+	shortCACert, shortCAKey, err := pki.NewCertAndKey(caCert, caKey, &pki.CertConfig{
+		Config:   certutil.Config{CommonName: "short-lived-ca", Usages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}},
+		NotAfter: now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	_, _, err = uk.Issue(&clusterpki.CA{Cert: shortCACert, Key: shortCAKey}, now)
+	require.ErrorIs(t, err, clusterpki.ErrTTLExceedsCA)
+
+	uk.TTL = 8 * time.Hour
+	uk.Groups = []string{"sre", clusterpki.SystemMastersGroup}
+
+	_, _, err = uk.Issue(ca, now)
+	require.ErrorIs(t, err, clusterpki.ErrSystemMastersUser)
+
+	uk.AllowSystemMasters = true
+
+	_, cert, err = uk.Issue(ca, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sre", clusterpki.SystemMastersGroup}, cert.Subject.Organization)
+
+	uk.TTL = 0
+
+	_, _, err = uk.Issue(ca, now)
+	require.ErrorIs(t, err, clusterpki.ErrTTLNotValid)
+}
+
+func TestAppendKubeconfigIssuance(t *testing.T) {
+	t.Parallel()
+
+	auditFile := filepath.Join(t.TempDir(), "audit", "kubeconfig-audit.log")
+
+	for _, u := range []string{"alice", "bob"} {
+		require.NoError(t, clusterpki.AppendKubeconfigIssuance(auditFile, clusterpki.KubeconfigIssuance{
+			Cluster: "furyctl",
+			User:    u,
+			Groups:  []string{"sre"},
+		}))
+	}
+
+	data, err := os.ReadFile(auditFile)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	got := clusterpki.KubeconfigIssuance{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &got))
+	assert.Equal(t, "bob", got.User)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clusterpki
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	certutil "k8s.io/client-go/util/cert"
+	pki "k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+)
+
+const (
+	CaCrt    = "ca.crt"
+	CaKey    = "ca.key"
+	CaNewCrt = "ca-new.crt"
+	CaNewKey = "ca-new.key"
+	CaOldCrt = "ca-old.crt"
+	CaOldKey = "ca-old.key"
+
+	// frontProxyCaName is the name of the files of the front-proxy CA, kept next to the Kubernetes one.
+	frontProxyCaName = "front-proxy-ca"
+)
+
+var ErrRotationNotStarted = errors.New("no new CA found, the rotation has not been started")
+
+// DefaultCertConfig returns the configuration of the CAs created by furyctl.
+func DefaultCertConfig() pki.CertConfig {
+	return pki.CertConfig{
+		Config: certutil.Config{
+			CommonName:   "SIGHUP s.r.l. Server",
+			Organization: []string{"SIGHUP s.r.l."},
+			AltNames:     certutil.AltNames{DNSNames: []string{}, IPs: []net.IP{}},
+			Usages:       []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		},
+		EncryptionAlgorithm: "",
+	}
+}
+
+// CARotation rotates the CA kept in one of the folders of the PKI, keeping the files of both the current and the
+// other CA until the rotation is completed: ca-new.* before the new CA is promoted, ca-old.* after.
+type CARotation struct {
+	Dir string
+	// Name is the name of the CA files, without extension, ca when empty.
+	Name       string
+	CertConfig pki.CertConfig
+}
+
+// CARotations returns the rotations of the control plane, etcd and front-proxy CAs of the PKI folder at pkiPath.
+func CARotations(pkiPath string, certConfig pki.CertConfig) []CARotation {
+	return []CARotation{
+		{Dir: filepath.Join(pkiPath, ControlPlanePath), CertConfig: certConfig},
+		{Dir: filepath.Join(pkiPath, etcdPath), CertConfig: certConfig},
+		{Dir: filepath.Join(pkiPath, ControlPlanePath), Name: frontProxyCaName, CertConfig: certConfig},
+	}
+}
+
+// Generate creates the new CA, unless a previous run has already created it.
+func (r CARotation) Generate() error {
+	if r.exists(CaNewCrt) || r.exists(CaOldCrt) {
+		return nil
+	}
+
+	ca, key, err := pki.NewCertificateAuthority(&r.CertConfig)
+	if err != nil {
+		return fmt.Errorf("error while creating CA in %s: %w", r.Dir, err)
+	}
+
+	if err := os.WriteFile(r.path(CaNewKey), EncodePrivateKey(key), permOwnerGroup); err != nil {
+		return fmt.Errorf("error while saving new CA key in %s: %w", r.Dir, err)
+	}
+
+	if err := os.WriteFile(r.path(CaNewCrt), pki.EncodeCertPEM(ca), permOwnerGroup); err != nil {
+		return fmt.Errorf("error while saving new CA certificate in %s: %w", r.Dir, err)
+	}
+
+	return nil
+}
+
+// Bundle returns the certificate of the current CA, the one signing, followed by the one of the other CA.
+func (r CARotation) Bundle() ([]byte, error) {
+	other := CaNewCrt
+	if !r.exists(other) {
+		other = CaOldCrt
+	}
+
+	current, err := r.Current()
+	if err != nil {
+		return nil, err
+	}
+
+	otherCrt, err := os.ReadFile(r.path(other))
+	if err != nil {
+		return nil, fmt.Errorf("error while reading CA certificate: %w", err)
+	}
+
+	return append(current, otherCrt...), nil
+}
+
+// Current returns the certificate of the current CA.
+func (r CARotation) Current() ([]byte, error) {
+	crt, err := os.ReadFile(r.path(CaCrt))
+	if err != nil {
+		return nil, fmt.Errorf("error while reading CA certificate: %w", err)
+	}
+
+	return crt, nil
+}
+
+// Promote makes the new CA the current one, keeping the previous one aside.
+func (r CARotation) Promote() error {
+	if !r.exists(CaNewCrt) {
+		if r.exists(CaOldCrt) {
+			return nil
+		}
+
+		return fmt.Errorf("%w in %s", ErrRotationNotStarted, r.Dir)
+	}
+
+	for _, mv := range [][2]string{{CaCrt, CaOldCrt}, {CaKey, CaOldKey}, {CaNewCrt, CaCrt}, {CaNewKey, CaKey}} {
+		if err := os.Rename(r.path(mv[0]), r.path(mv[1])); err != nil {
+			return fmt.Errorf("error while promoting new CA in %s: %w", r.Dir, err)
+		}
+	}
+
+	return nil
+}
+
+// Complete removes the previous CA.
+func (r CARotation) Complete() error {
+	for _, name := range []string{CaOldCrt, CaOldKey} {
+		if err := os.Remove(r.path(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error while removing previous CA in %s: %w", r.Dir, err)
+		}
+	}
+
+	return nil
+}
+
+// KeyPath returns the path of the key of the current CA.
+func (r CARotation) KeyPath() string {
+	return r.path(CaKey)
+}
+
+func (r CARotation) exists(name string) bool {
+	_, err := os.Stat(r.path(name))
+
+	return err == nil
+}
+
+// path returns the path of the CA file name, one of the Ca* constants, renamed after the CA when it has a name.
+func (r CARotation) path(name string) string {
+	if r.Name != "" {
+		name = r.Name + strings.TrimPrefix(name, "ca")
+	}
+
+	return filepath.Join(r.Dir, name)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package clusterpki_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sighupio/furyctl/internal/clusterpki"
+)
+
+func TestCARotation(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	etcd := clusterpki.Etcd{ClusterPKI: clusterpki.ClusterPKI{Config: clusterpki.Config{
+		Path:       dir,
+		CertConfig: clusterpki.DefaultCertConfig(),
+	}}}
+
+	require.NoError(t, etcd.Create())
+
+	rotations := clusterpki.CARotations(dir, clusterpki.DefaultCertConfig())
+	require.Len(t, rotations, 3)
+
+	r := rotations[1]
+	require.Equal(t, filepath.Join(dir, "etcd"), r.Dir)
+
+	read := func(name string) []byte {
+		t.Helper()
+
+		data, err := os.ReadFile(filepath.Join(r.Dir, name))
+		require.NoError(t, err)
+
+		return data
+	}
+
+	oldCrt := read(clusterpki.CaCrt)
+
+	require.ErrorIs(t, r.Promote(), clusterpki.ErrRotationNotStarted)
+
+	require.NoError(t, r.Generate())
+
+	newCrt := read(clusterpki.CaNewCrt)
+	assert.NotEqual(t, oldCrt, newCrt)
+
+	// Generating again keeps the CA created by the previous run.
+	require.NoError(t, r.Generate())
+	assert.Equal(t, newCrt, read(clusterpki.CaNewCrt))
+
+	bundle, err := r.Bundle()
+	require.NoError(t, err)
+	assert.Equal(t, append(append([]byte{}, oldCrt...), newCrt...), bundle)
+
+	require.NoError(t, r.Promote())
+	assert.Equal(t, newCrt, read(clusterpki.CaCrt))
+	assert.Equal(t, oldCrt, read(clusterpki.CaOldCrt))
+	assert.NoFileExists(t, filepath.Join(r.Dir, clusterpki.CaNewCrt))
+
+	// Promoting again, as a resumed rotation does, is a no-op.
+	require.NoError(t, r.Promote())
+	assert.Equal(t, newCrt, read(clusterpki.CaCrt))
+
+	bundle, err = r.Bundle()
+	require.NoError(t, err)
+	assert.Equal(t, append(append([]byte{}, newCrt...), oldCrt...), bundle)
+
+	require.NoError(t, r.Generate())
+	assert.NoFileExists(t, filepath.Join(r.Dir, clusterpki.CaNewCrt))
+
+	require.NoError(t, r.Complete())
+	assert.NoFileExists(t, filepath.Join(r.Dir, clusterpki.CaOldCrt))
+	assert.NoFileExists(t, filepath.Join(r.Dir, clusterpki.CaOldKey))
+
+	current, err := r.Current()
+	require.NoError(t, err)
+	assert.Equal(t, newCrt, current)
+
+	require.NoError(t, r.Complete())
+}
+
+func TestCARotation_FrontProxy(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	controlPlane := clusterpki.ControlPlanePKI{ClusterPKI: clusterpki.ClusterPKI{Config: clusterpki.Config{
+		Path:       dir,
+		CertConfig: clusterpki.DefaultCertConfig(),
+	}}}
+
+	require.NoError(t, controlPlane.Create())
+
+	rotations := clusterpki.CARotations(dir, clusterpki.DefaultCertConfig())
+	require.Len(t, rotations, 3)
+
+	r := rotations[2]
+	require.Equal(t, filepath.Join(dir, clusterpki.ControlPlanePath), r.Dir)
+
+	oldCrt, err := os.ReadFile(filepath.Join(r.Dir, clusterpki.ControlPlaneFProxyCrt))
+	require.NoError(t, err)
+
+	caCrt, err := os.ReadFile(filepath.Join(r.Dir, clusterpki.ControlPlaneCaCrt))
+	require.NoError(t, err)
+
+	require.NoError(t, r.Generate())
+	assert.FileExists(t, filepath.Join(r.Dir, "front-proxy-ca-new.crt"))
+	assert.FileExists(t, filepath.Join(r.Dir, "front-proxy-ca-new.key"))
+
+	require.NoError(t, r.Promote())
+	assert.Equal(t, filepath.Join(r.Dir, clusterpki.ControlPlaneFProxyKey), r.KeyPath())
+
+	current, err := r.Current()
+	require.NoError(t, err)
+	assert.NotEqual(t, oldCrt, current)
+
+	require.NoError(t, r.Complete())
+	assert.NoFileExists(t, filepath.Join(r.Dir, "front-proxy-ca-old.crt"))
+	assert.NoFileExists(t, filepath.Join(r.Dir, "front-proxy-ca-old.key"))
+
+	// The Kubernetes CA, kept in the same folder, is left untouched.
+	kubernetesCrt, err := rotations[0].Current()
+	require.NoError(t, err)
+	assert.Equal(t, caCrt, kubernetesCrt)
+}
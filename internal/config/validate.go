@@ -10,16 +10,21 @@ import (
 	"html/template"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/santhosh-tekuri/jsonschema/v5"
 	"github.com/sirupsen/logrus"
 
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
 	"github.com/sighupio/furyctl/internal/analytics"
 	"github.com/sighupio/furyctl/internal/apis"
+	"github.com/sighupio/furyctl/internal/cluster"
 	"github.com/sighupio/furyctl/internal/distribution"
 	"github.com/sighupio/furyctl/internal/flags"
 	"github.com/sighupio/furyctl/internal/parser"
+	"github.com/sighupio/furyctl/internal/redact"
 	"github.com/sighupio/furyctl/internal/schema/santhosh"
 	iox "github.com/sighupio/furyctl/internal/x/io"
 	dist "github.com/sighupio/furyctl/pkg/distribution"
@@ -130,6 +135,13 @@ func Validate(path, repoPath string) error {
 		}
 	}
 
+	// The deletion protection is enforced by furyctl and unknown to the schemas, so it is validated here.
+	if _, err := cluster.DeletionProtection(rawConf); err != nil {
+		return err
+	}
+
+	rawConf = stripFuryctlMetadata(rawConf)
+
 	// Check if the schema supports flags field.
 	schemaSupportsFlags := checkSchemaSupportsFlags(schemaPath)
 
@@ -141,8 +153,10 @@ func Validate(path, repoPath string) error {
 			return fmt.Errorf("error expanding dynamic values: %w", err)
 		}
 
+		trackSensitiveValues(schemaPath, expandedConf)
+
 		// Validate configuration with flags included.
-		if err = schema.Validate(expandedConf); err != nil {
+		if err = validateSchema(schema, expandedConf); err != nil {
 			return fmt.Errorf("error while validating against schema: %w", err)
 		}
 	} else {
@@ -155,8 +169,10 @@ func Validate(path, repoPath string) error {
 			return fmt.Errorf("error expanding dynamic values: %w", err)
 		}
 
+		trackSensitiveValues(schemaPath, expandedConf)
+
 		// Validate expanded configuration against fury-distribution schema.
-		if err = schema.Validate(expandedConf); err != nil {
+		if err = validateSchema(schema, expandedConf); err != nil {
 			return fmt.Errorf("error while validating against schema: %w", err)
 		}
 	}
@@ -171,6 +187,41 @@ func Validate(path, repoPath string) error {
 	return validateToolsConfiguration(repoPath, rawConf)
 }
 
+// ValidateTfOutputs validates the furyctl.yaml file again when it holds terraform output references, so that the
+// outputs resolved since the last validation are checked against the schema too.
+func ValidateTfOutputs(path, repoPath string) error {
+	rawConf, err := yamlx.FromFileV3[map[string]any](path)
+	if err != nil {
+		return err
+	}
+
+	refs, err := parser.FindTfOutputReferences(rawConf)
+	if err != nil {
+		return fmt.Errorf("error looking for terraform output references: %w", err)
+	}
+
+	if len(refs) == 0 {
+		return nil
+	}
+
+	return Validate(path, repoPath)
+}
+
+// trackSensitiveValues registers the values of the fields holding secrets, so that they are redacted from
+// logs and diffs. Fields are taken from the schema, when it marks them, on top of the well-known ones.
+func trackSensitiveValues(schemaPath string, expandedConf any) {
+	content, err := os.ReadFile(schemaPath)
+	if err != nil {
+		logrus.Debugf("Could not read schema file to find sensitive fields: %v", err)
+	} else if fields, err := redact.SensitiveFieldsFromSchema(content); err != nil {
+		logrus.Debugf("Could not find sensitive fields in schema: %v", err)
+	} else {
+		redact.Secrets.AddSensitiveFields(fields...)
+	}
+
+	redact.Secrets.AddFromConfig(expandedConf)
+}
+
 // checkSchemaSupportsFlags determines if the schema includes support for the flags field.
 // This allows furyctl to work with both old schemas (without flags) and new schemas (with flags).
 func checkSchemaSupportsFlags(schemaPath string) bool {
@@ -216,6 +267,39 @@ func createCleanConfigForSchemaValidation(rawConf map[string]any) map[string]any
 	return cleanConf
 }
 
+// furyctlMetadataFields are the fields of the metadata read by furyctl itself, that the schemas do not know about.
+var furyctlMetadataFields = []string{"deletionProtection", "awsAccountGuard"}
+
+// stripFuryctlMetadata returns a copy of rawConf without the furyctl-specific metadata fields.
+func stripFuryctlMetadata(rawConf map[string]any) map[string]any {
+	metadata, ok := rawConf["metadata"].(map[string]any)
+	if !ok {
+		return rawConf
+	}
+
+	cleanMetadata := make(map[string]any, len(metadata))
+
+	for key, value := range metadata {
+		if !slices.Contains(furyctlMetadataFields, key) {
+			cleanMetadata[key] = value
+		}
+	}
+
+	if len(cleanMetadata) == len(metadata) {
+		return rawConf
+	}
+
+	cleanConf := make(map[string]any, len(rawConf))
+
+	for key, value := range rawConf {
+		cleanConf[key] = value
+	}
+
+	cleanConf["metadata"] = cleanMetadata
+
+	return cleanConf
+}
+
 // expandDynamicValues recursively expands dynamic values in the configuration
 // before schema validation.
 func expandDynamicValues(conf map[string]any, baseDir string) (map[string]any, error) {
@@ -265,6 +349,21 @@ func expandDynamicValuesRecursive(value any, configParser *parser.ConfigParser)
 		return result, nil
 
 	case string:
+		// Terraform outputs are only known once their phase has been applied, the references that cannot be
+		// resolved yet are kept as they are and the fields holding them are not validated against the schema.
+		if containsTfOutputPattern(v) {
+			expandedVal, err := configParser.ParseDynamicValue(v)
+			if errors.Is(err, parser.ErrTfOutputNotConfigured) || errors.Is(err, parser.ErrTfOutputNotAvailable) {
+				return v, nil
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("error parsing dynamic value: %w", err)
+			}
+
+			return expandedVal, nil
+		}
+
 		// Check if this string contains dynamic value patterns.
 		if containsDynamicPattern(v) {
 			expandedVal, err := configParser.ParseDynamicValue(v)
@@ -301,6 +400,90 @@ func containsDynamicPattern(s string) bool {
 		(len(s) > pathPrefixLen && s[1:pathPrefixLen+1] == "path://"))
 }
 
+// containsTfOutputPattern checks if a string contains a {tfoutput://} dynamic value.
+func containsTfOutputPattern(s string) bool {
+	return strings.Contains(s, "{"+parser.TfOutput+"://")
+}
+
+// validateSchema validates conf against the schema, ignoring the errors of the fields holding terraform
+// output references.
+func validateSchema(schema *jsonschema.Schema, conf map[string]any) error {
+	err := schema.Validate(conf)
+	if err == nil {
+		return nil
+	}
+
+	locations := map[string]struct{}{}
+
+	tfOutputLocations(conf, "", locations)
+
+	var verr *jsonschema.ValidationError
+
+	if len(locations) == 0 || !errors.As(err, &verr) {
+		return err
+	}
+
+	if verr = skipLocations(verr, locations); verr == nil {
+		return nil
+	}
+
+	return verr
+}
+
+// tfOutputLocations collects the JSON pointers of the values containing terraform output references.
+func tfOutputLocations(value any, location string, locations map[string]struct{}) {
+	switch v := value.(type) {
+	case map[string]any:
+		escaper := strings.NewReplacer("~", "~0", "/", "~1")
+
+		for key, val := range v {
+			tfOutputLocations(val, location+"/"+escaper.Replace(key), locations)
+		}
+
+	case []any:
+		for i, val := range v {
+			tfOutputLocations(val, location+"/"+strconv.Itoa(i), locations)
+		}
+
+	case string:
+		if containsTfOutputPattern(v) {
+			locations[location] = struct{}{}
+		}
+	}
+}
+
+// skipLocations removes from the validation error the failures of the values at the given locations,
+// it returns nil when no failure is left.
+func skipLocations(
+	verr *jsonschema.ValidationError,
+	locations map[string]struct{},
+) *jsonschema.ValidationError {
+	if len(verr.Causes) == 0 {
+		if _, ok := locations[verr.InstanceLocation]; ok {
+			return nil
+		}
+
+		return verr
+	}
+
+	causes := make([]*jsonschema.ValidationError, 0, len(verr.Causes))
+
+	for _, cause := range verr.Causes {
+		if cause = skipLocations(cause, locations); cause != nil {
+			causes = append(causes, cause)
+		}
+	}
+
+	if len(causes) == 0 {
+		return nil
+	}
+
+	pruned := *verr
+	pruned.Causes = causes
+
+	return &pruned
+}
+
 // validateFlagsSection validates the flags section using furyctl-specific validation rules.
 func validateFlagsSection(flagsSection any) error {
 	// Convert to FlagsConfig type for validation.
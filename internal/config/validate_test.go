@@ -0,0 +1,181 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sighupio/furyctl/internal/config"
+	"github.com/sighupio/furyctl/internal/parser"
+)
+
+const validateTestSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "apiVersion": {"type": "string"},
+    "kind": {"type": "string"},
+    "metadata": {"type": "object"},
+    "spec": {
+      "type": "object",
+      "properties": {
+        "distributionVersion": {"type": "string"},
+        "vpcId": {"type": "string", "pattern": "^vpc-[0-9a-f]+$"},
+        "subnetIds": {"type": "array", "items": {"type": "string", "pattern": "^subnet-[0-9a-f]+$"}}
+      },
+      "required": ["vpcId"]
+    }
+  }
+}`
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		spec    string
+		wantErr string
+	}{
+		{
+			desc: "valid values",
+			spec: `
+  vpcId: vpc-0123abcd
+  subnetIds: [subnet-0123abcd]`,
+		},
+		{
+			desc: "value not matching the pattern",
+			spec: `
+  vpcId: my-vpc`,
+			wantErr: "/spec/vpcId",
+		},
+		{
+			desc: "terraform output references in pattern constrained fields",
+			spec: `
+  vpcId: "{tfoutput://infrastructure/vpc_id}"
+  subnetIds: ["{tfoutput://infrastructure/private_subnets}", subnet-0123abcd]`,
+		},
+		{
+			desc: "terraform output reference next to an invalid value",
+			spec: `
+  vpcId: "{tfoutput://infrastructure/vpc_id}"
+  subnetIds: ["{tfoutput://infrastructure/private_subnets}", my-subnet]`,
+			wantErr: "/spec/subnetIds/1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			configPath, repoPath := writeValidateTestFiles(t, tc.spec)
+
+			err := config.Validate(configPath, repoPath)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+//nolint:paralleltest // The terraform outputs are resolved by the shared parser.TfOutputs resolver.
+func TestValidate_ResolvedTfOutputs(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		outputs string
+		wantErr string
+	}{
+		{
+			desc: "valid outputs",
+			outputs: `{
+				"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-0123abcd"},
+				"private_subnets": {"sensitive": false, "type": ["list", "string"], "value": ["subnet-0123abcd"]}
+			}`,
+		},
+		{
+			desc: "output not matching the pattern",
+			outputs: `{
+				"vpc_id": {"sensitive": false, "type": "string", "value": "my-vpc"},
+				"private_subnets": {"sensitive": false, "type": ["list", "string"], "value": ["subnet-0123abcd"]}
+			}`,
+			wantErr: "/spec/vpcId",
+		},
+		{
+			desc: "list output with an item not matching the pattern",
+			outputs: `{
+				"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-0123abcd"},
+				"private_subnets": {"sensitive": false, "type": ["list", "string"], "value": ["subnet-0123abcd", "my-subnet"]}
+			}`,
+			wantErr: "/spec/subnetIds/1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			configPath, repoPath := writeValidateTestFiles(t, `
+  vpcId: "{tfoutput://infrastructure/vpc_id}"
+  subnetIds: "{tfoutput://infrastructure/private_subnets}"`)
+
+			outputsPath := t.TempDir()
+
+			require.NoError(t, os.WriteFile(filepath.Join(outputsPath, "output.json"), []byte(tc.outputs), 0o600))
+
+			parser.TfOutputs.Register(
+				parser.TfOutputPhase{Name: "infrastructure", OutputsPath: outputsPath},
+				parser.TfOutputPhase{Name: "kubernetes"},
+			)
+			defer parser.TfOutputs.Reset()
+
+			// The outputs are not resolved while the producing phase runs.
+			require.NoError(t, parser.TfOutputs.SetCurrentPhase("infrastructure"))
+			require.NoError(t, config.Validate(configPath, repoPath))
+
+			require.NoError(t, parser.TfOutputs.SetCurrentPhase("kubernetes"))
+
+			err := config.Validate(configPath, repoPath)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func writeValidateTestFiles(t *testing.T, spec string) (string, string) {
+	t.Helper()
+
+	repoPath := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(repoPath, "schemas", "public"), 0o755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(repoPath, "schemas", "public", "kfddistribution-kfd-v1alpha2.json"),
+		[]byte(validateTestSchema),
+		0o600,
+	))
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "kfd.yaml"), []byte("version: v1.35.0\n"), 0o600))
+
+	configPath := filepath.Join(t.TempDir(), "furyctl.yaml")
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`apiVersion: kfd.sighup.io/v1alpha2
+kind: KFDDistribution
+metadata:
+  name: test
+spec:
+  distributionVersion: v1.35.0`+spec+"\n"), 0o600))
+
+	return configPath, repoPath
+}
@@ -7,20 +7,35 @@ package envvars
 import (
 	"errors"
 	"fmt"
-	"os"
-	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sighupio/furyctl/internal/tool/awscli"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
 )
 
 var (
 	ErrMissingEnvVars        = errors.New("missing environment variables")
 	ErrMissingRequiredEnvVar = errors.New("missing required environment variable")
+	ErrAWSCredentials        = errors.New(
+		"cannot resolve AWS credentials, configure any source supported by the AWS credential chain, " +
+			"such as AWS_PROFILE, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, an SSO session, " +
+			"a web identity token or an instance role",
+	)
 )
 
-func NewValidator() *Validator {
-	return &Validator{}
+func NewValidator(executor execx.Executor, furyctlPath string) *Validator {
+	return &Validator{
+		executor:    executor,
+		furyctlPath: furyctlPath,
+	}
 }
 
-type Validator struct{}
+type Validator struct {
+	executor    execx.Executor
+	furyctlPath string
+}
 
 func (ev *Validator) Validate(kind string) ([]string, []error) {
 	if kind == "EKSCluster" {
@@ -30,36 +45,33 @@ func (ev *Validator) Validate(kind string) ([]string, []error) {
 	return nil, nil
 }
 
-func (*Validator) checkEKSCluster() ([]string, []error) {
-	oks := make([]string, 0)
-	errs := make([]error, 0)
-
-	var missingAwsVars []string
+// checkEKSCluster resolves the AWS identity instead of looking for specific environment variables, so that every
+// credential source supported by the AWS CLI is accepted.
+func (ev *Validator) checkEKSCluster() ([]string, []error) {
+	identity, err := awscli.NewRunner(ev.executor, awscli.Paths{Awscli: "aws"}).CallerIdentity(ev.region())
+	if err != nil {
+		return nil, []error{fmt.Errorf("%w: %w", ErrAWSCredentials, err)}
+	}
 
-	if os.Getenv("AWS_PROFILE") != "" {
-		oks = append(oks, "AWS_PROFILE")
+	logrus.Debugf("AWS identity %s resolved in account %s", identity.Arn, identity.Account)
 
-		return oks, errs
-	}
+	return []string{"AWS identity " + identity.Arn}, nil
+}
 
-	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
-		missingAwsVars = append(missingAwsVars, "AWS_ACCESS_KEY_ID")
-	} else {
-		oks = append(oks, "AWS_ACCESS_KEY_ID")
+// region returns the region of the EKSCluster, so that STS is reached through its regional endpoint. It is empty when
+// the configuration cannot be read, leaving the choice to the AWS CLI.
+func (ev *Validator) region() string {
+	if ev.furyctlPath == "" {
+		return ""
 	}
 
-	if os.Getenv("AWS_SECRET_ACCESS_KEY") == "" {
-		missingAwsVars = append(missingAwsVars, "AWS_SECRET_ACCESS_KEY")
-	} else {
-		oks = append(oks, "AWS_SECRET_ACCESS_KEY")
+	conf, err := yamlx.FromFileV3[map[string]any](ev.furyctlPath)
+	if err != nil {
+		return ""
 	}
 
-	if len(missingAwsVars) > 0 {
-		errs = append(errs, fmt.Errorf("%w, either AWS_PROFILE or the following environment variables must be set: %s",
-			ErrMissingEnvVars, strings.Join(missingAwsVars, ", ")))
-
-		return oks, errs
-	}
+	spec, _ := conf["spec"].(map[string]any)
+	region, _ := spec["region"].(string)
 
-	return oks, errs
+	return region
 }
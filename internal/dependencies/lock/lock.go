@@ -0,0 +1,304 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sighupio/furyctl/internal/tool/git"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+	iox "github.com/sighupio/furyctl/internal/x/io"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+const (
+	// FileName is the name of the lock file, stored next to the configuration file.
+	FileName = "furyctl.lock"
+
+	formatVersion = 1
+)
+
+// Section is the group of dependencies an entry of the lock file belongs to.
+type Section string
+
+const (
+	SectionModules    Section = "modules"
+	SectionInstallers Section = "installers"
+	SectionTools      Section = "tools"
+)
+
+var (
+	ErrMismatch           = errors.New("dependency does not match the lock file")
+	ErrNotLocked          = errors.New("dependency is not locked")
+	ErrUnsupportedVersion = errors.New("unsupported furyctl.lock version")
+)
+
+// File is the content of the lock file.
+type File struct {
+	Version      int                          `yaml:"version"`
+	Distribution *Entry                       `yaml:"distribution,omitempty"`
+	Dependencies map[Section]map[string]Entry `yaml:"dependencies,omitempty"`
+}
+
+// Entry records how a dependency has been resolved. Commit is only known for the dependencies downloaded from a git
+// repository, Digest is the digest of the downloaded files as computed by iox.HashDir.
+type Entry struct {
+	Version string `yaml:"version"`
+	Commit  string `yaml:"commit,omitempty"`
+	Digest  string `yaml:"digest,omitempty"`
+}
+
+// Path returns the path of the lock file of the given configuration file.
+func Path(furyctlPath string) string {
+	return filepath.Join(filepath.Dir(furyctlPath), FileName)
+}
+
+// Load reads the lock file at path, a missing file is loaded as an empty one. When update is true, the entries that
+// do not match the downloaded dependencies are replaced instead of failing.
+func Load(path string, update bool) (*Lock, error) {
+	l := &Lock{
+		path:   path,
+		update: update,
+		file:   File{Version: formatVersion},
+	}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+
+	f, err := yamlx.FromFileV3[File](path)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading %s: %w", path, err)
+	}
+
+	if f.Version != formatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, f.Version)
+	}
+
+	l.file = f
+
+	return l, nil
+}
+
+// Change is an entry of the lock file that has been added or updated, Old is nil for the added ones.
+type Change struct {
+	Section Section
+	Name    string
+	Old     *Entry
+	New     Entry
+}
+
+// Lock pins the dependencies to the revisions recorded in the lock file and records the new ones.
+type Lock struct {
+	mu      sync.Mutex
+	path    string
+	update  bool
+	only    func(section Section, name string) bool
+	frozen  bool
+	file    File
+	changed bool
+	changes []Change
+}
+
+// UpdateOnly restricts the update of the entries to the dependencies match returns true for, the others are checked
+// as usual.
+func (l *Lock) UpdateOnly(match func(section Section, name string) bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.only = match
+}
+
+// Freeze makes the dependencies that are not in the lock file an error, so that the lock file is never changed.
+func (l *Lock) Freeze() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.frozen = true
+	l.update = false
+}
+
+// Changes returns the entries that have been added or updated, by section and name.
+func (l *Lock) Changes() []Change {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	changes := slices.Clone(l.changes)
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		if a.Section != b.Section {
+			return strings.Compare(string(a.Section), string(b.Section))
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return changes
+}
+
+func (l *Lock) updating(section Section, name string) bool {
+	return l.update && (l.only == nil || l.only(section, name))
+}
+
+// Commit returns the commit the dependency is pinned to, if its version has not changed.
+func (l *Lock) Commit(section Section, name, version string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.file.Dependencies[section][name]
+	if !ok || l.updating(section, name) || e.Version != version {
+		return ""
+	}
+
+	return e.Commit
+}
+
+// DistributionCommit returns the commit the distribution is pinned to, if its version has not changed.
+func (l *Lock) DistributionCommit(version string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e := l.file.Distribution
+	if e == nil || (l.update && l.only == nil) || e.Version != version {
+		return ""
+	}
+
+	return e.Commit
+}
+
+// Check compares the downloaded dependency with its entry, the dependencies that are not in the lock file yet are
+// added to it.
+func (l *Lock) Check(section Section, name string, got Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file.Dependencies == nil {
+		l.file.Dependencies = map[Section]map[string]Entry{}
+	}
+
+	if l.file.Dependencies[section] == nil {
+		l.file.Dependencies[section] = map[string]Entry{}
+	}
+
+	want, ok := l.file.Dependencies[section][name]
+	if !ok && l.frozen {
+		return fmt.Errorf("%s '%s': %w", section, name, ErrNotLocked)
+	}
+
+	e, err := l.check(want, ok, got, l.updating(section, name))
+	if err != nil {
+		return fmt.Errorf("%s '%s': %w", section, name, err)
+	}
+
+	if !ok || e != want {
+		c := Change{Section: section, Name: name, New: e}
+		if ok {
+			c.Old = &want
+		}
+
+		l.changes = append(l.changes, c)
+	}
+
+	l.file.Dependencies[section][name] = e
+
+	return nil
+}
+
+// CheckDistribution compares the downloaded distribution with its entry, see Check.
+func (l *Lock) CheckDistribution(got Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var want Entry
+
+	ok := l.file.Distribution != nil
+	if ok {
+		want = *l.file.Distribution
+	}
+
+	if !ok && l.frozen {
+		return fmt.Errorf("distribution: %w", ErrNotLocked)
+	}
+
+	e, err := l.check(want, ok, got, l.update && l.only == nil)
+	if err != nil {
+		return fmt.Errorf("distribution: %w", err)
+	}
+
+	l.file.Distribution = &e
+
+	return nil
+}
+
+// check returns the entry to record for the downloaded dependency.
+func (l *Lock) check(want Entry, ok bool, got Entry, update bool) (Entry, error) {
+	if ok && want == got {
+		return want, nil
+	}
+
+	if ok && !update {
+		switch {
+		case want.Version != got.Version:
+			return want, fmt.Errorf("%w: version %s is locked, %s is requested", ErrMismatch, want.Version, got.Version)
+
+		case want.Commit != "" && got.Commit != "" && want.Commit != got.Commit:
+			return want, fmt.Errorf("%w: commit %s is locked, %s has been downloaded", ErrMismatch, want.Commit, got.Commit)
+
+		case want.Digest != "" && got.Digest != "" && want.Digest != got.Digest:
+			return want, fmt.Errorf("%w: digest %s is locked, %s has been downloaded", ErrMismatch, want.Digest, got.Digest)
+		}
+
+		// Commits are not known for dependencies served without their repository, e.g. from a bundle, the locked
+		// entry is kept as is.
+		return want, nil
+	}
+
+	l.changed = true
+
+	return got, nil
+}
+
+// Save writes the lock file if any entry has been added or updated.
+func (l *Lock) Save() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.changed {
+		return nil
+	}
+
+	out, err := yamlx.MarshalV3(l.file)
+	if err != nil {
+		return fmt.Errorf("error while marshaling %s: %w", l.path, err)
+	}
+
+	if err := iox.WriteFile(l.path, out); err != nil {
+		return fmt.Errorf("error while writing %s: %w", l.path, err)
+	}
+
+	l.changed = false
+
+	return nil
+}
+
+// ResolveCommit returns the commit checked out in dir, or an empty string if dir is not a git repository.
+func ResolveCommit(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		return "", nil //nolint:nilerr // Not a git repository.
+	}
+
+	commit, err := git.NewRunner(execx.NewStdExecutor(), git.Paths{Git: "git", WorkDir: dir}).RevParseHead()
+	if err != nil {
+		return "", fmt.Errorf("error while resolving commit of %s: %w", dir, err)
+	}
+
+	return commit, nil
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package lock_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sighupio/furyctl/internal/dependencies/lock"
+)
+
+func TestLock_Check(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), lock.FileName)
+
+	l, err := lock.Load(path, false)
+	require.NoError(t, err)
+
+	monitoring := lock.Entry{Version: "v3.3.0", Commit: "abc123", Digest: "sha256:aaa"}
+
+	require.NoError(t, l.Check(lock.SectionModules, "monitoring", monitoring))
+	require.NoError(t, l.CheckDistribution(lock.Entry{Version: "v1.31.0", Commit: "def456"}))
+	require.NoError(t, l.Save())
+	require.FileExists(t, path)
+
+	testCases := []struct {
+		desc    string
+		update  bool
+		got     lock.Entry
+		wantErr error
+	}{
+		{
+			desc: "same revision",
+			got:  monitoring,
+		},
+		{
+			desc: "unknown commit",
+			got:  lock.Entry{Version: "v3.3.0", Digest: "sha256:aaa"},
+		},
+		{
+			desc:    "moved tag",
+			got:     lock.Entry{Version: "v3.3.0", Commit: "fff999", Digest: "sha256:bbb"},
+			wantErr: lock.ErrMismatch,
+		},
+		{
+			desc:    "changed content",
+			got:     lock.Entry{Version: "v3.3.0", Digest: "sha256:bbb"},
+			wantErr: lock.ErrMismatch,
+		},
+		{
+			desc:    "new version",
+			got:     lock.Entry{Version: "v3.4.0", Commit: "fff999", Digest: "sha256:bbb"},
+			wantErr: lock.ErrMismatch,
+		},
+		{
+			desc:   "new version with update",
+			update: true,
+			got:    lock.Entry{Version: "v3.4.0", Commit: "fff999", Digest: "sha256:bbb"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			l, err := lock.Load(path, tc.update)
+			require.NoError(t, err)
+
+			err = l.Check(lock.SectionModules, "monitoring", tc.got)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLock_Commit(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), lock.FileName)
+
+	require.NoError(t, os.WriteFile(path, []byte(`version: 1
+distribution:
+  version: v1.31.0
+  commit: def456
+dependencies:
+  modules:
+    monitoring:
+      version: v3.3.0
+      commit: abc123
+`), 0o600))
+
+	l, err := lock.Load(path, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc123", l.Commit(lock.SectionModules, "monitoring", "v3.3.0"))
+	assert.Empty(t, l.Commit(lock.SectionModules, "monitoring", "v3.4.0"))
+	assert.Empty(t, l.Commit(lock.SectionModules, "logging", "v3.3.0"))
+	assert.Equal(t, "def456", l.DistributionCommit("v1.31.0"))
+
+	// Commits are not pinned while updating the lock file.
+	l, err = lock.Load(path, true)
+	require.NoError(t, err)
+
+	assert.Empty(t, l.Commit(lock.SectionModules, "monitoring", "v3.3.0"))
+	assert.Empty(t, l.DistributionCommit("v1.31.0"))
+}
+
+func TestLock_UpdateOnly(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), lock.FileName)
+
+	require.NoError(t, os.WriteFile(path, []byte(`version: 1
+dependencies:
+  katalog:
+    monitoring/prometheus-operator:
+      version: v3.3.0
+      commit: abc123
+    logging/loki:
+      version: v3.3.0
+      commit: abc123
+`), 0o600))
+
+	l, err := lock.Load(path, true)
+	require.NoError(t, err)
+
+	l.UpdateOnly(func(_ lock.Section, name string) bool {
+		return name == "monitoring/prometheus-operator"
+	})
+
+	assert.Empty(t, l.Commit("katalog", "monitoring/prometheus-operator", "v3.3.0"))
+	assert.Equal(t, "abc123", l.Commit("katalog", "logging/loki", "v3.3.0"))
+
+	moved := lock.Entry{Version: "v3.3.0", Commit: "fff999"}
+
+	require.NoError(t, l.Check("katalog", "monitoring/prometheus-operator", moved))
+	require.ErrorIs(t, l.Check("katalog", "logging/loki", moved), lock.ErrMismatch)
+	require.NoError(t, l.Check("katalog", "ingress/nginx", moved))
+
+	assert.Equal(t, []lock.Change{
+		{Section: "katalog", Name: "ingress/nginx", New: moved},
+		{
+			Section: "katalog",
+			Name:    "monitoring/prometheus-operator",
+			Old:     &lock.Entry{Version: "v3.3.0", Commit: "abc123"},
+			New:     moved,
+		},
+	}, l.Changes())
+}
+
+func TestLock_Freeze(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), lock.FileName)
+
+	l, err := lock.Load(path, true)
+	require.NoError(t, err)
+
+	l.Freeze()
+
+	err = l.Check(lock.SectionModules, "monitoring", lock.Entry{Version: "v3.3.0", Commit: "abc123"})
+	require.ErrorIs(t, err, lock.ErrNotLocked)
+
+	require.NoError(t, l.Save())
+	assert.NoFileExists(t, path)
+}
+
+func TestLoad_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), lock.FileName)
+
+	require.NoError(t, os.WriteFile(path, []byte("version: 2\n"), 0o600))
+
+	_, err := lock.Load(path, false)
+	assert.ErrorIs(t, err, lock.ErrUnsupportedVersion)
+}
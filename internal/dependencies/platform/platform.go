@@ -0,0 +1,94 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidPlatform = errors.New("invalid platform, expected <os>/<arch>, e.g. darwin/arm64")
+
+//nolint:gochecknoglobals // Platforms the tools are published for.
+var supported = []Platform{
+	{OS: "darwin", Arch: "amd64"},
+	{OS: "darwin", Arch: "arm64"},
+	{OS: "linux", Arch: "amd64"},
+	{OS: "linux", Arch: "arm64"},
+}
+
+// Platform is an operating system and architecture pair, in the Go notation.
+type Platform struct {
+	OS   string
+	Arch string
+}
+
+// Current returns the platform furyctl is running on.
+func Current() Platform {
+	return Platform{OS: runtime.GOOS, Arch: runtime.GOARCH}
+}
+
+// Parse parses a platform in the <os>/<arch> format.
+func Parse(s string) (Platform, error) {
+	goos, goarch, ok := strings.Cut(strings.TrimSpace(s), "/")
+
+	p := Platform{OS: goos, Arch: goarch}
+
+	if !ok || !slices.Contains(supported, p) {
+		return Platform{}, fmt.Errorf("%w: '%s'", ErrInvalidPlatform, s)
+	}
+
+	return p, nil
+}
+
+// ParseList parses a comma separated list of platforms, ignoring duplicates.
+func ParseList(s string) ([]Platform, error) {
+	ps := []Platform{}
+
+	for _, item := range strings.Split(s, ",") {
+		p, err := Parse(item)
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(ps, p) {
+			ps = append(ps, p)
+		}
+	}
+
+	return ps, nil
+}
+
+// String returns the platform in the <os>/<arch> format, as used by the checksums pinned in the kfd.yaml file.
+func (p Platform) String() string {
+	return p.OS + "/" + p.Arch
+}
+
+// Dir returns the name of the folder holding the tools of the platform, inside the bin folder.
+func (p Platform) Dir() string {
+	return p.OS + "_" + p.Arch
+}
+
+// IsCurrent tells whether the platform is the one furyctl is running on.
+func (p Platform) IsCurrent() bool {
+	return p == Current()
+}
+
+// ToolPath returns the folder holding the given version of a tool for the current platform: the one inside the
+// platform folder of binPath, if the tool has been downloaded for more platforms, or the one inside binPath itself.
+func ToolPath(binPath, name, version string) string {
+	dir := filepath.Join(binPath, Current().Dir(), name, version)
+
+	if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+		return dir
+	}
+
+	return filepath.Join(binPath, name, version)
+}
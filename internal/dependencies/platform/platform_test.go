@@ -0,0 +1,83 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package platform_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
+)
+
+func TestParseList(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		in      string
+		want    []platform.Platform
+		wantErr bool
+	}{
+		{
+			desc: "single",
+			in:   "darwin/arm64",
+			want: []platform.Platform{{OS: "darwin", Arch: "arm64"}},
+		},
+		{
+			desc: "many with duplicates",
+			in:   "darwin/arm64, linux/amd64,darwin/arm64",
+			want: []platform.Platform{{OS: "darwin", Arch: "arm64"}, {OS: "linux", Arch: "amd64"}},
+		},
+		{
+			desc:    "missing arch",
+			in:      "linux",
+			wantErr: true,
+		},
+		{
+			desc:    "unsupported",
+			in:      "windows/amd64",
+			wantErr: true,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := platform.ParseList(tC.in)
+			if tC.wantErr {
+				require.ErrorIs(t, err, platform.ErrInvalidPlatform)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.want, got)
+		})
+	}
+}
+
+func TestToolPath(t *testing.T) {
+	t.Parallel()
+
+	binPath := t.TempDir()
+
+	assert.Equal(t, filepath.Join(binPath, "kubectl", "1.32.4"), platform.ToolPath(binPath, "kubectl", "1.32.4"))
+
+	dir := filepath.Join(binPath, platform.Current().Dir(), "kubectl", "1.32.4")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+
+	assert.Equal(t, dir, platform.ToolPath(binPath, "kubectl", "1.32.4"))
+
+	// The tools missing from the platform folder are taken from binPath.
+	assert.Equal(t, filepath.Join(binPath, "kubectl", "1.33.1"), platform.ToolPath(binPath, "kubectl", "1.33.1"))
+	assert.Equal(t, filepath.Join(binPath, "helm", "3.17.3"), platform.ToolPath(binPath, "helm", "3.17.3"))
+}
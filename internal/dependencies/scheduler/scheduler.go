@@ -0,0 +1,404 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sighupio/furyctl/internal/analytics"
+	"github.com/sighupio/furyctl/internal/redact"
+)
+
+const (
+	DefaultWorkers     = 8
+	DefaultRetries     = 3
+	DefaultBackoff     = time.Second
+	DefaultMaxBackoff  = 30 * time.Second
+	DefaultItemTimeout = 5 * time.Minute
+
+	DefaultProgressInterval = 10 * time.Second
+
+	bytesPerMiB = 1024 * 1024
+)
+
+var (
+	ErrCanceled = errors.New("download canceled")
+
+	// Default holds the options used by the downloaders, it is configured from the root command flags.
+	Default = Options{ //nolint:gochecknoglobals // Shared between all the downloaders.
+		Workers:     DefaultWorkers,
+		Retries:     DefaultRetries,
+		Backoff:     DefaultBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+		ItemTimeout: DefaultItemTimeout,
+
+		ProgressInterval: DefaultProgressInterval,
+	}
+
+	// Errors returned by git and the http getters that are worth a retry. Name resolution and TLS failures are
+	// not, as they are unlikely to go away by themselves.
+	transientErrRegexp = regexp.MustCompile(`(?i)(connection reset|connection refused|broken pipe|timed? ?out|` +
+		`temporary failure|unexpected eof|early eof|the remote end hung up|rpc failed|` +
+		`bad response code: (429|5\d\d)|status code:? (429|5\d\d))`)
+)
+
+// Options configures how the items are downloaded.
+type Options struct {
+	// Workers is the maximum number of items downloaded at the same time.
+	Workers int
+	// Retries is the number of times an item failing with a transient error is downloaded again.
+	Retries int
+	// Backoff is the time waited before the first retry, it doubles on every retry up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// ItemTimeout is the maximum duration of a single download attempt.
+	ItemTimeout time.Duration
+	// ProgressInterval is how often the items still downloading are reported, 0 disables the reports.
+	ProgressInterval time.Duration
+	// Tracker, if set, receives an event with the outcome of all the items.
+	Tracker *analytics.Tracker
+}
+
+// Task is an item to download. Dst, if set, is measured to report the downloaded bytes.
+type Task struct {
+	Name string
+	Dst  string
+	Run  func(ctx context.Context) error
+}
+
+// Result reports the outcome of a task.
+type Result struct {
+	Name     string
+	Bytes    int64
+	Duration time.Duration
+	Attempts int
+	Err      error
+}
+
+type fatalError struct {
+	err error
+}
+
+func (e *fatalError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fatalError) Unwrap() error {
+	return e.err
+}
+
+// Fatal marks err as fatal: the task is not retried and all the other tasks are canceled.
+func Fatal(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &fatalError{err: err}
+}
+
+// IsFatal reports whether err has been marked with Fatal.
+func IsFatal(err error) bool {
+	var ferr *fatalError
+
+	return errors.As(err, &ferr)
+}
+
+// IsTransient reports whether err is likely to go away by retrying, e.g. a timeout, a temporary network error or a
+// server side failure.
+func IsTransient(err error) bool {
+	if err == nil || IsFatal(err) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var derr *net.DNSError
+	if errors.As(err, &derr) && derr.IsNotFound {
+		return false
+	}
+
+	var nerr net.Error
+	if errors.As(err, &nerr) && nerr.Timeout() {
+		return true
+	}
+
+	var terr interface{ Temporary() bool }
+	if errors.As(err, &terr) && terr.Temporary() {
+		return true
+	}
+
+	return transientErrRegexp.MatchString(err.Error())
+}
+
+// Run downloads the tasks with at most opts.Workers of them running at the same time and returns the errors of all
+// the failed ones. The first fatal error cancels the tasks still running or waiting.
+func Run(ctx context.Context, opts Options, tasks []Task) []error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	tasksCh := make(chan Task)
+	resultsCh := make(chan Result)
+
+	wg := sync.WaitGroup{}
+
+	for range min(workers, len(tasks)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for t := range tasksCh {
+				resultsCh <- run(ctx, opts, t)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(tasksCh)
+
+		for _, t := range tasks {
+			select {
+			case tasksCh <- t:
+
+			case <-ctx.Done():
+				resultsCh <- Result{Name: t.Name, Err: fmt.Errorf("%w: %w", ErrCanceled, context.Cause(ctx))}
+			}
+		}
+	}()
+
+	errs := []error{}
+	results := make([]Result, 0, len(tasks))
+	fatal := false
+
+	for done := 1; done <= len(tasks); done++ {
+		res := <-resultsCh
+
+		report(res, done, len(tasks))
+
+		results = append(results, res)
+
+		// The tasks canceled because of a fatal error are not worth reporting.
+		if res.Err == nil || (fatal && errors.Is(res.Err, ErrCanceled)) {
+			continue
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", res.Name, res.Err))
+
+		if IsFatal(res.Err) {
+			fatal = true
+
+			cancel()
+		}
+	}
+
+	wg.Wait()
+
+	track(opts.Tracker, results)
+
+	return errs
+}
+
+func run(ctx context.Context, opts Options, t Task) Result {
+	res := Result{Name: t.Name}
+	start := time.Now()
+	backoff := opts.Backoff
+
+	stopProgress := watch(opts.ProgressInterval, t, start)
+	defer stopProgress()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			res.Err = fmt.Errorf("%w: %w", ErrCanceled, err)
+
+			break
+		}
+
+		res.Attempts++
+
+		res.Err = attempt(ctx, opts, t)
+		if res.Err != nil && ctx.Err() != nil {
+			res.Err = fmt.Errorf("%w: %w", ErrCanceled, res.Err)
+
+			break
+		}
+
+		if res.Err == nil || !IsTransient(res.Err) || res.Attempts > opts.Retries {
+			break
+		}
+
+		logrus.Debugf("Retrying %s in %s after attempt %d failed: %v", t.Name, backoff, res.Attempts, res.Err)
+
+		select {
+		case <-time.After(backoff):
+
+		case <-ctx.Done():
+		}
+
+		backoff = min(backoff*2, opts.MaxBackoff) //nolint:mnd // Exponential backoff.
+	}
+
+	res.Duration = time.Since(start)
+
+	if res.Err == nil && t.Dst != "" {
+		res.Bytes = size(t.Dst)
+	}
+
+	return res
+}
+
+func attempt(ctx context.Context, opts Options, t Task) error {
+	if opts.ItemTimeout <= 0 {
+		return t.Run(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.ItemTimeout)
+	defer cancel()
+
+	return t.Run(ctx)
+}
+
+// watch reports the progress of the task every interval until the returned function is called.
+func watch(interval time.Duration, t Task, start time.Time) func() {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	wg := sync.WaitGroup{}
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				reportProgress(t, time.Since(start))
+
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// reportProgress logs a task still running, with the bytes downloaded so far when they can be measured.
+func reportProgress(t Task, elapsed time.Duration) {
+	var bytes int64
+
+	if t.Dst != "" {
+		bytes = size(t.Dst)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"action":  "download",
+		"status":  "downloading",
+		"item":    t.Name,
+		"bytes":   bytes,
+		"seconds": elapsed.Seconds(),
+	}).Infof("Downloading %s (%.1f MiB in %.0fs)...", t.Name, float64(bytes)/bytesPerMiB, elapsed.Seconds())
+}
+
+// report logs the outcome of a task, the fields are meant for the machine readable log file.
+func report(res Result, done, total int) {
+	status := "done"
+	if res.Err != nil {
+		status = "failed"
+	}
+
+	entry := logrus.WithFields(logrus.Fields{
+		"action":   "download",
+		"status":   status,
+		"item":     res.Name,
+		"bytes":    res.Bytes,
+		"seconds":  res.Duration.Seconds(),
+		"attempts": res.Attempts,
+	})
+
+	if res.Err != nil {
+		entry.Debugf("[%d/%d] Failed to download %s: %v", done, total, res.Name, res.Err)
+
+		return
+	}
+
+	entry.Infof("[%d/%d] Downloaded %s (%.1f MiB in %.1fs)",
+		done,
+		total,
+		res.Name,
+		float64(res.Bytes)/bytesPerMiB,
+		res.Duration.Seconds(),
+	)
+}
+
+// track sends the outcome of the tasks to the analytics.
+func track(tracker *analytics.Tracker, results []Result) {
+	if tracker == nil || len(results) == 0 {
+		return
+	}
+
+	items := make([]analytics.DownloadItem, 0, len(results))
+
+	for _, res := range results {
+		item := analytics.DownloadItem{
+			Name:     res.Name,
+			Bytes:    res.Bytes,
+			Seconds:  res.Duration.Seconds(),
+			Attempts: res.Attempts,
+		}
+
+		if res.Err != nil {
+			item.Error = redact.Secrets.String(res.Err.Error())
+		}
+
+		items = append(items, item)
+	}
+
+	tracker.Track(analytics.NewDownloadEvent(items))
+}
+
+// size returns the total size of the files in path, or 0 if it cannot be measured.
+func size(path string) int64 {
+	var total int64
+
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil //nolint:nilerr // Best effort, the size is only reported.
+		}
+
+		if fi, err := d.Info(); err == nil {
+			total += fi.Size()
+		}
+
+		return nil
+	})
+
+	return total
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package scheduler_test
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sighupio/furyctl/internal/dependencies/scheduler"
+)
+
+var (
+	errTransient = errors.New("read: connection reset by peer")
+	errNotFound  = errors.New("module not found")
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string {
+	return "i/o deadline reached"
+}
+
+func (timeoutError) Timeout() bool {
+	return true
+}
+
+func (timeoutError) Temporary() bool {
+	return false
+}
+
+func testOptions(workers int) scheduler.Options {
+	return scheduler.Options{
+		Workers:     workers,
+		Retries:     2,
+		Backoff:     time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+		ItemTimeout: time.Second,
+	}
+}
+
+func TestRun_BoundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var running, peak atomic.Int32
+
+	tasks := []scheduler.Task{}
+
+	for i := range 10 {
+		tasks = append(tasks, scheduler.Task{
+			Name: fmt.Sprintf("task-%d", i),
+			Run: func(_ context.Context) error {
+				n := running.Add(1)
+				defer running.Add(-1)
+
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+
+				time.Sleep(5 * time.Millisecond)
+
+				return nil
+			},
+		})
+	}
+
+	assert.Empty(t, scheduler.Run(context.Background(), testOptions(3), tasks))
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+}
+
+func TestRun_RetriesTransientErrors(t *testing.T) {
+	t.Parallel()
+
+	var flaky, broken, missing atomic.Int32
+
+	errs := scheduler.Run(context.Background(), testOptions(2), []scheduler.Task{
+		{
+			Name: "flaky",
+			Run: func(_ context.Context) error {
+				if flaky.Add(1) < 3 {
+					return errTransient
+				}
+
+				return nil
+			},
+		},
+		{
+			Name: "broken",
+			Run: func(_ context.Context) error {
+				broken.Add(1)
+
+				return errTransient
+			},
+		},
+		{
+			Name: "missing",
+			Run: func(_ context.Context) error {
+				missing.Add(1)
+
+				return errNotFound
+			},
+		},
+	})
+
+	assert.Equal(t, int32(3), flaky.Load())
+	assert.Equal(t, int32(3), broken.Load())
+	assert.Equal(t, int32(1), missing.Load())
+
+	// All the failed items are reported.
+	require.Len(t, errs, 2)
+	assert.ErrorIs(t, errors.Join(errs...), errTransient)
+	assert.ErrorIs(t, errors.Join(errs...), errNotFound)
+}
+
+func TestRun_FatalErrorCancelsTheOthers(t *testing.T) {
+	t.Parallel()
+
+	errFatal := errors.New("checksum mismatch")
+
+	var started atomic.Int32
+
+	tasks := []scheduler.Task{
+		{
+			Name: "fatal",
+			Run: func(_ context.Context) error {
+				return scheduler.Fatal(errFatal)
+			},
+		},
+	}
+
+	for i := range 5 {
+		tasks = append(tasks, scheduler.Task{
+			Name: fmt.Sprintf("slow-%d", i),
+			Run: func(ctx context.Context) error {
+				started.Add(1)
+
+				<-ctx.Done()
+
+				return ctx.Err()
+			},
+		})
+	}
+
+	errs := scheduler.Run(context.Background(), testOptions(2), tasks)
+
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], errFatal)
+	// Only the task already picked by the other worker can have started.
+	assert.LessOrEqual(t, started.Load(), int32(2))
+}
+
+type progressHook struct {
+	item    string
+	reports atomic.Int32
+}
+
+func (*progressHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *progressHook) Fire(e *logrus.Entry) error {
+	if e.Data["item"] == h.item && e.Data["status"] == "downloading" {
+		h.reports.Add(1)
+	}
+
+	return nil
+}
+
+func TestRun_ReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	hook := &progressHook{item: "slow-item-progress"}
+
+	logrus.AddHook(hook)
+
+	opts := testOptions(1)
+	opts.ProgressInterval = 5 * time.Millisecond
+
+	errs := scheduler.Run(context.Background(), opts, []scheduler.Task{
+		{
+			Name: hook.item,
+			Dst:  t.TempDir(),
+			Run: func(_ context.Context) error {
+				time.Sleep(50 * time.Millisecond)
+
+				return nil
+			},
+		},
+	})
+
+	require.Empty(t, errs)
+	assert.Positive(t, hook.reports.Load())
+}
+
+func TestIsTransient(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err  error
+		want bool
+	}{
+		{err: errTransient, want: true},
+		{err: errors.New("bad response code: 503"), want: true},
+		{err: errors.New("fatal: the remote end hung up unexpectedly"), want: true},
+		{err: fmt.Errorf("attempt: %w", context.DeadlineExceeded), want: true},
+		{err: &url.Error{Op: "Get", URL: "https://example.com", Err: timeoutError{}}, want: true},
+		{err: &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true}, want: true},
+		{err: &net.DNSError{Err: "no such host", Name: "example.com", IsNotFound: true}, want: false},
+		{err: &url.Error{Op: "Get", URL: "https://example.com", Err: x509.UnknownAuthorityError{}}, want: false},
+		{err: errors.New("fatal: unable to access: Could not resolve host: example.com"), want: false},
+		{err: errors.New("bad response code: 404"), want: false},
+		{err: errNotFound, want: false},
+		{err: context.Canceled, want: false},
+		{err: scheduler.Fatal(errTransient), want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, scheduler.IsTransient(tc.err))
+		})
+	}
+}
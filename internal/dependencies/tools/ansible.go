@@ -46,6 +46,10 @@ func (*Ansible) SrcPath() string {
 	return ""
 }
 
+func (*Ansible) Checksum() string {
+	return ""
+}
+
 func (*Ansible) Rename(_ string) error {
 	return nil
 }
@@ -47,6 +47,10 @@ func (*Awscli) SrcPath() string {
 	return ""
 }
 
+func (*Awscli) Checksum() string {
+	return ""
+}
+
 func (*Awscli) Rename(_ string) error {
 	// Not used for this tool because it's not downloaded.
 	return nil
@@ -0,0 +1,131 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
+	iox "github.com/sighupio/furyctl/internal/x/io"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+const (
+	checksumRecordExt = ".checksum"
+
+	// UnverifiedChecksumSource marks the tools that have been downloaded without any checksum to verify them.
+	UnverifiedChecksumSource = "none"
+)
+
+var (
+	ErrChecksumMismatch      = errors.New("checksum mismatch")
+	ErrChecksumRecordMissing = errors.New("checksum record missing")
+	errMalformedRecord       = errors.New("malformed checksum record")
+)
+
+// PinnedChecksums holds the digests pinned in the kfd.yaml file, by tool name and by platform (os/arch).
+type PinnedChecksums map[string]map[string]string
+
+type kfdToolsChecksums struct {
+	Tools map[string]map[string]struct {
+		Checksums map[string]string `yaml:"checksums"`
+	} `yaml:"tools"`
+}
+
+// ReadPinnedChecksums reads the optional `checksums` field of the tools declared in the kfd.yaml file, e.g.:
+//
+//	tools:
+//	  common:
+//	    kubectl:
+//	      version: 1.31.1
+//	      checksums:
+//	        linux/amd64: sha256:57b514a7facce4ee62c93b8dc21fda8cf62ef3fed22e44ffc9d167eab843b2ae
+func ReadPinnedChecksums(kfdPath string) (PinnedChecksums, error) {
+	kfd, err := yamlx.FromFileV3[kfdToolsChecksums](kfdPath)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading tools checksums: %w", err)
+	}
+
+	pcs := PinnedChecksums{}
+
+	for _, group := range kfd.Tools {
+		for name, t := range group {
+			if len(t.Checksums) == 0 {
+				continue
+			}
+
+			pcs[strings.ToLower(name)] = t.Checksums
+		}
+	}
+
+	return pcs, nil
+}
+
+// Get returns the digest pinned for the given tool on the current platform, if any.
+func (p PinnedChecksums) Get(name string) string {
+	return p.GetForPlatform(name, platform.Current())
+}
+
+// GetForPlatform returns the digest pinned for the given tool on the given platform, if any.
+func (p PinnedChecksums) GetForPlatform(name string, pl platform.Platform) string {
+	return p[name][pl.String()]
+}
+
+// ChecksumRecordPath returns the path of the file recording how the tool installed in dir has been verified.
+func ChecksumRecordPath(dir string) string {
+	return filepath.Clean(dir) + checksumRecordExt
+}
+
+// WriteChecksumRecord records the digest of the tool installed in dir, together with the source of the checksum
+// its download has been verified against, so that later changes to the installed files can be detected.
+func WriteChecksumRecord(dir, source string) error {
+	digest, err := iox.HashDir(dir)
+	if err != nil {
+		return err
+	}
+
+	if source == "" {
+		source = UnverifiedChecksumSource
+	}
+
+	if err := iox.WriteFile(ChecksumRecordPath(dir), []byte(digest+" "+source+"\n")); err != nil {
+		return fmt.Errorf("error while recording checksum of %s: %w", dir, err)
+	}
+
+	return nil
+}
+
+// VerifyChecksumRecord compares the tool installed in dir with its checksum record and returns the source of the
+// checksum its download has been verified against.
+func VerifyChecksumRecord(dir string) (string, error) {
+	record, err := os.ReadFile(ChecksumRecordPath(dir))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrChecksumRecordMissing
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("error while reading checksum record of %s: %w", dir, err)
+	}
+
+	want, source, ok := strings.Cut(strings.TrimSpace(string(record)), " ")
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errMalformedRecord, ChecksumRecordPath(dir))
+	}
+
+	got, err := iox.HashDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if got != want {
+		return source, fmt.Errorf("%w: %s has been modified after being downloaded", ErrChecksumMismatch, dir)
+	}
+
+	return source, nil
+}
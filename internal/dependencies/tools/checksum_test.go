@@ -0,0 +1,93 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package tools_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/sighupio/furyctl/internal/dependencies/tools"
+)
+
+func Test_ReadPinnedChecksums(t *testing.T) {
+	t.Parallel()
+
+	kfdPath := filepath.Join(t.TempDir(), "kfd.yaml")
+
+	kfd := `version: v1.31.0
+tools:
+  common:
+    kubectl:
+      version: 1.31.1
+      checksums:
+        ` + runtime.GOOS + "/" + runtime.GOARCH + `: sha256:0123
+    kustomize:
+      version: 3.10.0
+  eks:
+    awscli:
+      version: "*"
+`
+
+	if err := os.WriteFile(kfdPath, []byte(kfd), 0o644); err != nil {
+		t.Fatalf("error writing kfd.yaml: %v", err)
+	}
+
+	pcs, err := tools.ReadPinnedChecksums(kfdPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := pcs.Get("kubectl"); got != "sha256:0123" {
+		t.Errorf("wrong kubectl checksum: want = sha256:0123, got = %s", got)
+	}
+
+	if got := pcs.Get("kustomize"); got != "" {
+		t.Errorf("expected no kustomize checksum, got = %s", got)
+	}
+}
+
+func Test_ChecksumRecord(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "kubectl", "1.31.1")
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("error creating dir: %v", err)
+	}
+
+	if _, err := tools.VerifyChecksumRecord(dir); !errors.Is(err, tools.ErrChecksumRecordMissing) {
+		t.Fatalf("expected missing record error, got: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte("kubectl"), 0o755); err != nil {
+		t.Fatalf("error writing binary: %v", err)
+	}
+
+	if err := tools.WriteChecksumRecord(dir, "sha256:0123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	source, err := tools.VerifyChecksumRecord(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if source != "sha256:0123" {
+		t.Errorf("wrong source: want = sha256:0123, got = %s", source)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "kubectl"), []byte("tampered"), 0o755); err != nil {
+		t.Fatalf("error writing binary: %v", err)
+	}
+
+	if _, err := tools.VerifyChecksumRecord(dir); !errors.Is(err, tools.ErrChecksumMismatch) {
+		t.Fatalf("expected checksum mismatch error, got: %v", err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/semver"
 	"github.com/sighupio/furyctl/internal/tool/furyagent"
 	iox "github.com/sighupio/furyctl/internal/x/io"
@@ -45,6 +46,10 @@ func (*Furyagent) SupportsDownload() bool {
 	return true
 }
 
+func (f *Furyagent) setPlatform(p platform.Platform) {
+	f.os, f.arch = p.OS, p.Arch
+}
+
 func (f *Furyagent) SrcPath() string {
 	return fmt.Sprintf(
 		"https://github.com/sighupio/furyagent/releases/download/%s/furyagent-%s-%s",
@@ -54,6 +59,11 @@ func (f *Furyagent) SrcPath() string {
 	)
 }
 
+// Checksum returns an empty string because furyagent does not publish checksums for its releases.
+func (*Furyagent) Checksum() string {
+	return ""
+}
+
 func (f *Furyagent) Rename(basePath string) error {
 	oldPath := filepath.Join(basePath, fmt.Sprintf("furyagent-%s-%s", f.os, f.arch))
 	newPath := filepath.Join(basePath, "furyagent")
@@ -47,6 +47,10 @@ func (*Git) SrcPath() string {
 	return ""
 }
 
+func (*Git) Checksum() string {
+	return ""
+}
+
 func (*Git) Rename(_ string) error {
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/semver"
 	"github.com/sighupio/furyctl/internal/tool/helm"
 	iox "github.com/sighupio/furyctl/internal/x/io"
@@ -45,6 +46,10 @@ func (*Helm) SupportsDownload() bool {
 	return true
 }
 
+func (h *Helm) setPlatform(p platform.Platform) {
+	h.os, h.arch = p.OS, p.Arch
+}
+
 func (h *Helm) SrcPath() string {
 	return fmt.Sprintf(
 		"https://get.helm.sh/helm-%s-%s-%s.tar.gz",
@@ -54,6 +59,10 @@ func (h *Helm) SrcPath() string {
 	)
 }
 
+func (h *Helm) Checksum() string {
+	return "file:" + h.SrcPath() + ".sha256sum"
+}
+
 func (h *Helm) Rename(basePath string) error {
 	oldPath := filepath.Join(basePath, fmt.Sprintf("%s-%s/helm", h.os, h.arch))
 	newPath := filepath.Join(basePath, "helm")
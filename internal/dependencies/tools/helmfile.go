@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/semver"
 	"github.com/sighupio/furyctl/internal/tool/helmfile"
 )
@@ -43,6 +44,10 @@ func (*Helmfile) SupportsDownload() bool {
 	return true
 }
 
+func (h *Helmfile) setPlatform(p platform.Platform) {
+	h.os, h.arch = p.OS, p.Arch
+}
+
 func (h *Helmfile) SrcPath() string {
 	return fmt.Sprintf(
 		"https://github.com/helmfile/helmfile/releases/download/%s/helmfile_%s_%s_%s.tar.gz",
@@ -53,6 +58,14 @@ func (h *Helmfile) SrcPath() string {
 	)
 }
 
+func (h *Helmfile) Checksum() string {
+	return fmt.Sprintf(
+		"file:https://github.com/helmfile/helmfile/releases/download/%s/helmfile_%s_checksums.txt",
+		semver.EnsurePrefix(h.version),
+		h.version,
+	)
+}
+
 func (*Helmfile) Rename(_ string) error {
 	return nil
 }
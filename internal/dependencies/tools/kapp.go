@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/semver"
 	"github.com/sighupio/furyctl/internal/tool/kapp"
 	iox "github.com/sighupio/furyctl/internal/x/io"
@@ -45,6 +46,10 @@ func (*Kapp) SupportsDownload() bool {
 	return true
 }
 
+func (k *Kapp) setPlatform(p platform.Platform) {
+	k.os, k.arch = p.OS, p.Arch
+}
+
 func (k *Kapp) SrcPath() string {
 	return fmt.Sprintf(
 		"https://github.com/carvel-dev/kapp/releases/download/%s/kapp-%s-%s",
@@ -54,6 +59,13 @@ func (k *Kapp) SrcPath() string {
 	)
 }
 
+func (k *Kapp) Checksum() string {
+	return fmt.Sprintf(
+		"file:https://github.com/carvel-dev/kapp/releases/download/%s/checksums.txt",
+		semver.EnsurePrefix(k.version),
+	)
+}
+
 func (k *Kapp) Rename(basePath string) error {
 	oldPath := filepath.Join(basePath, fmt.Sprintf("kapp-%s-%s", k.os, k.arch))
 	newPath := filepath.Join(basePath, "kapp")
@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/semver"
 	"github.com/sighupio/furyctl/internal/tool/kubectl"
 )
@@ -46,6 +47,10 @@ func (*Kubectl) SupportsDownload() bool {
 	return true
 }
 
+func (k *Kubectl) setPlatform(p platform.Platform) {
+	k.os, k.arch = p.OS, p.Arch
+}
+
 func (k *Kubectl) SrcPath() string {
 	return fmt.Sprintf(
 		"https://dl.k8s.io/release/%s/bin/%s/%s/kubectl",
@@ -55,6 +60,10 @@ func (k *Kubectl) SrcPath() string {
 	)
 }
 
+func (k *Kubectl) Checksum() string {
+	return "file:" + k.SrcPath() + ".sha256"
+}
+
 func (*Kubectl) Rename(_ string) error {
 	return nil
 }
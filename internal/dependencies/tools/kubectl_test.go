@@ -57,6 +57,19 @@ func Test_Kubectl_SrcPath(t *testing.T) {
 	}
 }
 
+func Test_Kubectl_Checksum(t *testing.T) {
+	wantChecksum := fmt.Sprintf(
+		"file:https://dl.k8s.io/release/v1.24.9/bin/%s/%s/kubectl.sha256",
+		runtime.GOOS,
+		runtime.GOARCH,
+	)
+
+	fa := tools.NewKubectl(newKubectlRunner(), "1.24.9")
+	if fa.Checksum() != wantChecksum {
+		t.Errorf("Wrong kubectl checksum: want = %s, got = %s", wantChecksum, fa.Checksum())
+	}
+}
+
 func Test_Kubectl_Rename(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "furyctl-test-")
 	if err != nil {
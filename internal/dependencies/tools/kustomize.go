@@ -7,23 +7,15 @@ package tools
 import (
 	"fmt"
 	"regexp"
-	"runtime"
 	"strings"
 
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/semver"
 	"github.com/sighupio/furyctl/internal/tool/kustomize"
 )
 
 func NewKustomize(runner *kustomize.Runner, version string) *Kustomize {
-	arch := runtime.GOARCH
-	// Older versions of kustomize did not provide ARM64 binaries.
-	if version == "3.5.3" {
-		arch = "amd64"
-	}
-
-	return &Kustomize{
-		arch:    arch,
-		os:      runtime.GOOS,
+	k := &Kustomize{
 		version: version,
 		checker: &checker{
 			regex:  regexp.MustCompile(`v(\S+)`),
@@ -36,6 +28,10 @@ func NewKustomize(runner *kustomize.Runner, version string) *Kustomize {
 			},
 		},
 	}
+
+	k.setPlatform(platform.Current())
+
+	return k
 }
 
 type Kustomize struct {
@@ -49,6 +45,15 @@ func (*Kustomize) SupportsDownload() bool {
 	return true
 }
 
+func (k *Kustomize) setPlatform(p platform.Platform) {
+	k.os, k.arch = p.OS, p.Arch
+
+	// Older versions of kustomize did not provide ARM64 binaries.
+	if k.version == "3.5.3" {
+		k.arch = "amd64"
+	}
+}
+
 func (k *Kustomize) SrcPath() string {
 	return fmt.Sprintf(
 		"https://github.com/kubernetes-sigs/kustomize/releases/download/kustomize/%s/kustomize_%s_%s_%s.tar.gz",
@@ -59,6 +64,13 @@ func (k *Kustomize) SrcPath() string {
 	)
 }
 
+func (k *Kustomize) Checksum() string {
+	return fmt.Sprintf(
+		"file:https://github.com/kubernetes-sigs/kustomize/releases/download/kustomize/%s/checksums.txt",
+		semver.EnsurePrefix(k.version),
+	)
+}
+
 func (*Kustomize) Rename(_ string) error {
 	return nil
 }
@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/semver"
 	"github.com/sighupio/furyctl/internal/tool/terraform"
 )
@@ -43,6 +44,10 @@ func (*OpenTofu) SupportsDownload() bool {
 	return true
 }
 
+func (t *OpenTofu) setPlatform(p platform.Platform) {
+	t.os, t.arch = p.OS, p.Arch
+}
+
 func (t *OpenTofu) SrcPath() string {
 	return fmt.Sprintf(
 		"https://github.com/opentofu/opentofu/releases/download/v%s/tofu_%s_%s_%s.zip",
@@ -53,6 +58,14 @@ func (t *OpenTofu) SrcPath() string {
 	)
 }
 
+func (t *OpenTofu) Checksum() string {
+	return fmt.Sprintf(
+		"file:https://github.com/opentofu/opentofu/releases/download/v%s/tofu_%s_SHA256SUMS",
+		semver.EnsureNoPrefix(t.version),
+		semver.EnsureNoPrefix(t.version),
+	)
+}
+
 func (*OpenTofu) Rename(_ string) error {
 	return nil
 }
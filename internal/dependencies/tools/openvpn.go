@@ -46,6 +46,10 @@ func (*Openvpn) SrcPath() string {
 	return ""
 }
 
+func (*Openvpn) Checksum() string {
+	return ""
+}
+
 func (*Openvpn) Rename(_ string) error {
 	return nil
 }
@@ -46,6 +46,10 @@ func (*Sed) SrcPath() string {
 	return ""
 }
 
+func (*Sed) Checksum() string {
+	return ""
+}
+
 func (*Sed) Rename(_ string) error {
 	return nil
 }
@@ -32,6 +32,10 @@ func (*Shell) SrcPath() string {
 	return ""
 }
 
+func (*Shell) Checksum() string {
+	return ""
+}
+
 func (*Shell) Rename(_ string) error {
 	return nil
 }
@@ -7,9 +7,9 @@ package tools
 import (
 	"fmt"
 	"regexp"
-	"runtime"
 	"strings"
 
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/semver"
 	"github.com/sighupio/furyctl/internal/tool/terraform"
 )
@@ -30,15 +30,7 @@ func hasTerraformDarwinArm64Support(version string) bool {
 }
 
 func NewTerraform(runner *terraform.Runner, version string) *Terraform {
-	arch := runtime.GOARCH
-
-	if !hasTerraformDarwinArm64Support(version) {
-		arch = "amd64"
-	}
-
-	return &Terraform{
-		arch:    arch,
-		os:      runtime.GOOS,
+	t := &Terraform{
 		version: version,
 		checker: &checker{
 			regex:  regexp.MustCompile("Terraform .*"),
@@ -51,6 +43,10 @@ func NewTerraform(runner *terraform.Runner, version string) *Terraform {
 			},
 		},
 	}
+
+	t.setPlatform(platform.Current())
+
+	return t
 }
 
 type Terraform struct {
@@ -64,6 +60,14 @@ func (*Terraform) SupportsDownload() bool {
 	return true
 }
 
+func (t *Terraform) setPlatform(p platform.Platform) {
+	t.os, t.arch = p.OS, p.Arch
+
+	if !hasTerraformDarwinArm64Support(t.version) {
+		t.arch = "amd64"
+	}
+}
+
 func (t *Terraform) SrcPath() string {
 	return fmt.Sprintf(
 		"https://releases.hashicorp.com/terraform/%s/terraform_%s_%s_%s.zip",
@@ -74,6 +78,14 @@ func (t *Terraform) SrcPath() string {
 	)
 }
 
+func (t *Terraform) Checksum() string {
+	return fmt.Sprintf(
+		"file:https://releases.hashicorp.com/terraform/%s/terraform_%s_SHA256SUMS",
+		semver.EnsureNoPrefix(t.version),
+		semver.EnsureNoPrefix(t.version),
+	)
+}
+
 func (*Terraform) Rename(_ string) error {
 	return nil
 }
@@ -57,6 +57,15 @@ func Test_Terraform_SrcPath(t *testing.T) {
 	}
 }
 
+func Test_Terraform_Checksum(t *testing.T) {
+	wantChecksum := "file:https://releases.hashicorp.com/terraform/1.2.9/terraform_1.2.9_SHA256SUMS"
+
+	fa := tools.NewTerraform(newTerraformRunner(), "v1.2.9")
+	if fa.Checksum() != wantChecksum {
+		t.Errorf("Wrong terraform checksum: want = %s, got = %s", wantChecksum, fa.Checksum())
+	}
+}
+
 func Test_Terraform_Rename(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "furyctl-test-")
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"regexp"
 
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/semver"
 	"github.com/sighupio/furyctl/internal/tool"
 	"github.com/sighupio/furyctl/internal/tool/ansible"
@@ -42,11 +43,19 @@ var (
 
 type Tool interface {
 	SrcPath() string
+	// Checksum returns the checksum of the file at SrcPath, in the format accepted by go-getter: either a digest
+	// such as "sha256:<hex>" or "file:<url>" for a published checksums file. It is empty if none is available.
+	Checksum() string
 	Rename(basePath string) error
 	CheckBinVersion() error
 	SupportsDownload() bool
 }
 
+// platformTool is implemented by the tools whose download depends on the platform they run on.
+type platformTool interface {
+	setPlatform(p platform.Platform)
+}
+
 func NewFactory(executor execx.Executor, paths FactoryPaths) *Factory {
 	return &Factory{
 		executor: executor,
@@ -219,6 +228,18 @@ func (f *Factory) Create(name tool.Name, version string) Tool {
 	return nil
 }
 
+// CreateForPlatform creates the tool like Create, downloading it for the given platform instead of the current one.
+// The version of the tools created for another platform cannot be checked, as they cannot run.
+func (f *Factory) CreateForPlatform(name tool.Name, version string, p platform.Platform) Tool {
+	t := f.Create(name, version)
+
+	if pt, ok := t.(platformTool); ok {
+		pt.setPlatform(p)
+	}
+
+	return t
+}
+
 type checker struct {
 	regex   *regexp.Regexp
 	runner  tool.Runner
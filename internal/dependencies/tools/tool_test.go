@@ -11,6 +11,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/dependencies/tools"
 	itool "github.com/sighupio/furyctl/internal/tool"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
@@ -86,6 +87,45 @@ func Test_Factory_Create(t *testing.T) {
 	}
 }
 
+func Test_Factory_CreateForPlatform(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		version     string
+		platform    platform.Platform
+		wantSrcPath string
+	}{
+		{
+			desc:        "kubectl",
+			version:     "1.30.0",
+			platform:    platform.Platform{OS: "darwin", Arch: "arm64"},
+			wantSrcPath: "https://dl.k8s.io/release/v1.30.0/bin/darwin/arm64/kubectl",
+		},
+		{
+			desc:        "kustomize",
+			version:     "3.5.3",
+			platform:    platform.Platform{OS: "darwin", Arch: "arm64"},
+			wantSrcPath: "https://github.com/kubernetes-sigs/kustomize/releases/download/kustomize/v3.5.3/kustomize_v3.5.3_darwin_amd64.tar.gz",
+		},
+		{
+			desc:        "terraform",
+			version:     "1.4.6",
+			platform:    platform.Platform{OS: "linux", Arch: "arm64"},
+			wantSrcPath: "https://releases.hashicorp.com/terraform/1.4.6/terraform_1.4.6_linux_arm64.zip",
+		},
+	}
+	for _, tC := range testCases {
+		f := tools.NewFactory(execx.NewStdExecutor(), tools.FactoryPaths{
+			Bin: "",
+		})
+		t.Run(tC.desc, func(t *testing.T) {
+			tool := f.CreateForPlatform(itool.Name(tC.desc), tC.version, tC.platform)
+			if tool.SrcPath() != tC.wantSrcPath {
+				t.Errorf("Wrong %s src path: want = %s, got = %s", tC.desc, tC.wantSrcPath, tool.SrcPath())
+			}
+		})
+	}
+}
+
 func TestHelperProcess(t *testing.T) {
 	args := os.Args
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
 	"github.com/sighupio/furyctl/internal/apis"
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/distribution"
 	itool "github.com/sighupio/furyctl/internal/tool"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
@@ -117,46 +118,116 @@ func (tv *Validator) validateTools(i any, kfdManifest config.KFD) ([]string, []e
 
 		toolName := strings.ToLower(toolCfgs.Type().Field(i).Name)
 
-		if (toolName == "helm" || toolName == "helmfile") &&
-			!distribution.HasFeature(kfdManifest, distribution.FeaturePlugins) {
+		if !isToolEnabled(toolName, kfdManifest) {
 			continue
 		}
 
-		if (toolName == "yq") && !distribution.HasFeature(kfdManifest, distribution.FeatureYqSupport) {
+		tool := tv.toolFactory.Create(itool.Name(toolName), toolCfg.Version)
+
+		if tool == nil {
+			errs = append(
+				errs,
+				fmt.Errorf("%s version %s: %w", toolName, toolCfg.Version, ErrToolNotFound),
+			)
+
 			continue
 		}
 
-		if (toolName == "kapp") && !distribution.HasFeature(kfdManifest, distribution.FeatureKappSupport) {
+		if err := tool.CheckBinVersion(); err != nil {
+			errs = append(errs, err)
+
 			continue
 		}
 
-		if (toolName == "terraform") && distribution.HasFeature(kfdManifest, distribution.FeatureOpenTofuSupport) {
+		oks = append(oks, toolName)
+	}
+
+	return oks, errs
+}
+
+// ChecksumStatus tells how a downloaded tool has been verified, Source is empty if it has not been verified.
+type ChecksumStatus struct {
+	Tool   string
+	Source string
+}
+
+// ValidateChecksums checks that the downloaded tools have not been changed since their download and reports the
+// checksum each of them has been verified against.
+func (tv *Validator) ValidateChecksums(kfdManifest config.KFD, miniConf config.Furyctl) ([]ChecksumStatus, []error) {
+	sts, errs := tv.validateChecksums(kfdManifest.Tools.Common, kfdManifest)
+
+	if miniConf.Kind == "EKSCluster" {
+		eksSts, eksErrs := tv.validateChecksums(kfdManifest.Tools.Eks, kfdManifest)
+		sts = append(sts, eksSts...)
+		errs = append(errs, eksErrs...)
+	}
+
+	return sts, errs
+}
+
+func (tv *Validator) validateChecksums(i any, kfdManifest config.KFD) ([]ChecksumStatus, []error) {
+	var errs []error
+
+	sts := make([]ChecksumStatus, 0)
+
+	toolCfgs := reflect.ValueOf(i)
+	for i := range toolCfgs.NumField() {
+		toolCfg, ok := toolCfgs.Field(i).Interface().(config.KFDTool)
+		if !ok {
 			continue
 		}
 
-		if (toolName == "opentofu") && !distribution.HasFeature(kfdManifest, distribution.FeatureOpenTofuSupport) {
+		toolName := strings.ToLower(toolCfgs.Type().Field(i).Name)
+
+		if !isToolEnabled(toolName, kfdManifest) {
 			continue
 		}
 
 		tool := tv.toolFactory.Create(itool.Name(toolName), toolCfg.Version)
+		if tool == nil || !tool.SupportsDownload() {
+			continue
+		}
 
-		if tool == nil {
-			errs = append(
-				errs,
-				fmt.Errorf("%s version %s: %w", toolName, toolCfg.Version, ErrToolNotFound),
-			)
+		source, err := VerifyChecksumRecord(platform.ToolPath(tv.toolFactory.paths.Bin, toolName, toolCfg.Version))
+		if errors.Is(err, ErrChecksumRecordMissing) {
+			sts = append(sts, ChecksumStatus{Tool: toolName})
 
 			continue
 		}
 
-		if err := tool.CheckBinVersion(); err != nil {
-			errs = append(errs, err)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", toolName, err))
 
 			continue
 		}
 
-		oks = append(oks, toolName)
+		if source == UnverifiedChecksumSource {
+			source = ""
+		}
+
+		sts = append(sts, ChecksumStatus{Tool: toolName, Source: source})
 	}
 
-	return oks, errs
+	return sts, errs
+}
+
+func isToolEnabled(name string, kfdManifest config.KFD) bool {
+	switch name {
+	case "helm", "helmfile":
+		return distribution.HasFeature(kfdManifest, distribution.FeaturePlugins)
+
+	case "yq":
+		return distribution.HasFeature(kfdManifest, distribution.FeatureYqSupport)
+
+	case "kapp":
+		return distribution.HasFeature(kfdManifest, distribution.FeatureKappSupport)
+
+	case "terraform":
+		return !distribution.HasFeature(kfdManifest, distribution.FeatureOpenTofuSupport)
+
+	case "opentofu":
+		return distribution.HasFeature(kfdManifest, distribution.FeatureOpenTofuSupport)
+	}
+
+	return true
 }
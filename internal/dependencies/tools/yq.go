@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/semver"
 	"github.com/sighupio/furyctl/internal/tool/yq"
 	iox "github.com/sighupio/furyctl/internal/x/io"
@@ -45,6 +46,10 @@ func (*Yq) SupportsDownload() bool {
 	return true
 }
 
+func (y *Yq) setPlatform(p platform.Platform) {
+	y.os, y.arch = p.OS, p.Arch
+}
+
 func (y *Yq) SrcPath() string {
 	return fmt.Sprintf(
 		"https://github.com/mikefarah/yq/releases/download/%s/yq_%s_%s.tar.gz",
@@ -54,6 +59,11 @@ func (y *Yq) SrcPath() string {
 	)
 }
 
+// Checksum returns an empty string because yq publishes its checksums in a custom format.
+func (*Yq) Checksum() string {
+	return ""
+}
+
 func (y *Yq) Rename(basePath string) error {
 	oldPath := filepath.Join(basePath, fmt.Sprintf("yq_%s_%s", y.os, y.arch))
 	newPath := filepath.Join(basePath, "yq")
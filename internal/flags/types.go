@@ -59,6 +59,10 @@ const (
 	// Default timeout values.
 	DefaultTimeoutSeconds         = 3600
 	DefaultPodRunningCheckTimeout = 300
+	DefaultGracePeriodSeconds     = 300
+
+	// DefaultCertificatesWarnDays is the number of days before the expiration when certificates are reported.
+	DefaultCertificatesWarnDays = 30
 
 	// ValidationSeverityFatal indicates a critical error that should stop execution.
 	ValidationSeverityFatal ValidationSeverity = "fatal"
@@ -114,6 +118,9 @@ func GetSupportedFlags() SupportedFlags {
 			"outdir":           {Type: FlagTypeString, DefaultValue: "", Description: "Output directory"},
 			"log":              {Type: FlagTypeString, DefaultValue: "", Description: "Log file path"},
 			"gitProtocol":      {Type: FlagTypeString, DefaultValue: "https", Description: "Git protocol to use"},
+			"mirrors":          {Type: FlagTypeString, DefaultValue: "", Description: "Download mirrors file path"},
+			"downloadWorkers":  {Type: FlagTypeInt, DefaultValue: 8, Description: "Maximum number of concurrent downloads"},
+			"downloadRetries":  {Type: FlagTypeInt, DefaultValue: 3, Description: "Retries of the failed downloads"},
 		},
 		Apply: map[string]FlagInfo{
 			"phase": {Type: FlagTypeString, DefaultValue: "", Description: "Limit execution to specific phase"},
@@ -124,6 +131,7 @@ func GetSupportedFlags() SupportedFlags {
 			},
 			"distroLocation":      {Type: FlagTypeString, DefaultValue: "", Description: "Distribution location"},
 			"distroPatches":       {Type: FlagTypeString, DefaultValue: "", Description: "Distribution patches location"},
+			"bundle":              {Type: FlagTypeString, DefaultValue: "", Description: "Bundle to download from"},
 			"binPath":             {Type: FlagTypeString, DefaultValue: "", Description: "Binary path"},
 			"skipNodesUpgrade":    {Type: FlagTypeBool, DefaultValue: false, Description: "Skip nodes upgrade"},
 			"skipDepsDownload":    {Type: FlagTypeBool, DefaultValue: false, Description: "Skip dependencies download"},
@@ -143,6 +151,11 @@ func GetSupportedFlags() SupportedFlags {
 				DefaultValue: DefaultPodRunningCheckTimeout,
 				Description:  "Pod running check timeout",
 			},
+			"gracePeriod": {
+				Type:         FlagTypeInt,
+				DefaultValue: DefaultGracePeriodSeconds,
+				Description:  "Grace period in seconds for running tools to stop on interrupt",
+			},
 			"upgrade":             {Type: FlagTypeBool, DefaultValue: false, Description: "Enable upgrade mode"},
 			"upgradePathLocation": {Type: FlagTypeString, DefaultValue: "", Description: "Upgrade path location"},
 			"upgradeNode":         {Type: FlagTypeString, DefaultValue: "", Description: "Specific node to upgrade"},
@@ -152,6 +165,7 @@ func GetSupportedFlags() SupportedFlags {
 			"startFrom":           {Type: FlagTypeString, DefaultValue: "", Description: "Start execution from specific phase"},
 			"distroLocation":      {Type: FlagTypeString, DefaultValue: "", Description: "Distribution location"},
 			"distroPatches":       {Type: FlagTypeString, DefaultValue: "", Description: "Distribution patches location"},
+			"bundle":              {Type: FlagTypeString, DefaultValue: "", Description: "Bundle to download from"},
 			"binPath":             {Type: FlagTypeString, DefaultValue: "", Description: "Binary path"},
 			"dryRun":              {Type: FlagTypeBool, DefaultValue: false, Description: "Dry run mode"},
 			"skipVpnConfirmation": {Type: FlagTypeBool, DefaultValue: false, Description: "Skip VPN confirmation"},
@@ -168,32 +182,83 @@ func GetSupportedFlags() SupportedFlags {
 				DefaultValue: false,
 				Description:  "Create PKI only for Kubernetes control plane",
 			},
+			"caCert":     {Type: FlagTypeString, DefaultValue: "", Description: "CA certificate to import in the PKI"},
+			"caKey":      {Type: FlagTypeString, DefaultValue: "", Description: "CA private key to import in the PKI"},
+			"etcdCaCert": {Type: FlagTypeString, DefaultValue: "", Description: "etcd CA certificate to import"},
+			"etcdCaKey":  {Type: FlagTypeString, DefaultValue: "", Description: "etcd CA private key to import"},
+			"controlplaneCaCert": {
+				Type:         FlagTypeString,
+				DefaultValue: "",
+				Description:  "Kubernetes control plane CA certificate to import",
+			},
+			"controlplaneCaKey": {
+				Type:         FlagTypeString,
+				DefaultValue: "",
+				Description:  "Kubernetes control plane CA private key to import",
+			},
+			"user":      {Type: FlagTypeString, DefaultValue: "", Description: "User the kubeconfig is issued to"},
+			"group":     {Type: FlagTypeStringSlice, DefaultValue: []string{}, Description: "Groups of the user"},
+			"ttl":       {Type: FlagTypeString, DefaultValue: "8h", Description: "Validity of the issued kubeconfig"},
+			"auditFile": {Type: FlagTypeString, DefaultValue: "", Description: "Audit file of the issued kubeconfigs"},
 		},
 		Get: map[string]FlagInfo{
 			"binPath":            {Type: FlagTypeString, DefaultValue: "", Description: "Binary path"},
 			"distroLocation":     {Type: FlagTypeString, DefaultValue: "", Description: "Distribution location"},
 			"skipDepsDownload":   {Type: FlagTypeBool, DefaultValue: false, Description: "Skip dependencies download"},
 			"skipDepsValidation": {Type: FlagTypeBool, DefaultValue: false, Description: "Skip dependencies validation"},
+			"skipNodes":          {Type: FlagTypeBool, DefaultValue: false, Description: "Skip reading certificates from nodes"},
+			"warnDays": {
+				Type:         FlagTypeInt,
+				DefaultValue: DefaultCertificatesWarnDays,
+				Description:  "Days before expiration when certificates are reported",
+			},
+			"output": {Type: FlagTypeString, DefaultValue: "table", Description: "Output format"},
+			"merge":  {Type: FlagTypeBool, DefaultValue: false, Description: "Merge the kubeconfig into the user's one"},
+			"contextName": {
+				Type:         FlagTypeString,
+				DefaultValue: "{{.Name}}",
+				Description:  "Template of the name of the merged context",
+			},
+			"setCurrentContext": {Type: FlagTypeBool, DefaultValue: false, Description: "Set the merged context as current"},
 		},
 		Diff: map[string]FlagInfo{
 			"phase":               {Type: FlagTypeString, DefaultValue: "", Description: "Limit execution to specific phase"},
 			"distroLocation":      {Type: FlagTypeString, DefaultValue: "", Description: "Distribution location"},
 			"distroPatches":       {Type: FlagTypeString, DefaultValue: "", Description: "Distribution patches location"},
+			"bundle":              {Type: FlagTypeString, DefaultValue: "", Description: "Bundle to download from"},
 			"binPath":             {Type: FlagTypeString, DefaultValue: "", Description: "Binary path"},
 			"upgradePathLocation": {Type: FlagTypeString, DefaultValue: "", Description: "Upgrade path location"},
 		},
-		Tools: map[string]FlagInfo{},
+		Tools: map[string]FlagInfo{
+			"binPath":        {Type: FlagTypeString, DefaultValue: "", Description: "Binary path"},
+			"distroLocation": {Type: FlagTypeString, DefaultValue: "", Description: "Distribution location"},
+			"kubeconfig":     {Type: FlagTypeString, DefaultValue: "", Description: "Kubeconfig file path"},
+			"phase":          {Type: FlagTypeString, DefaultValue: "", Description: "Phase folder to run the tool in"},
+		},
 		Validate: map[string]FlagInfo{
 			"distroLocation": {Type: FlagTypeString, DefaultValue: "", Description: "Distribution location"},
 			"distroPatches":  {Type: FlagTypeString, DefaultValue: "", Description: "Distribution patches location"},
+			"bundle":         {Type: FlagTypeString, DefaultValue: "", Description: "Bundle to download from"},
 		},
 		Download: map[string]FlagInfo{
-			"binPath":        {Type: FlagTypeString, DefaultValue: "", Description: "Binary path"},
-			"distroLocation": {Type: FlagTypeString, DefaultValue: "", Description: "Distribution location"},
-			"distroPatches":  {Type: FlagTypeString, DefaultValue: "", Description: "Distribution patches location"},
+			"binPath":             {Type: FlagTypeString, DefaultValue: "", Description: "Binary path"},
+			"distroLocation":      {Type: FlagTypeString, DefaultValue: "", Description: "Distribution location"},
+			"distroPatches":       {Type: FlagTypeString, DefaultValue: "", Description: "Distribution patches location"},
+			"output":              {Type: FlagTypeString, DefaultValue: "", Description: "Bundle output path"},
+			"platform":            {Type: FlagTypeString, DefaultValue: "", Description: "Platforms to download the tools for"},
+			"upgradePathLocation": {Type: FlagTypeString, DefaultValue: "", Description: "Upgrade path location"},
+			"updateLock":          {Type: FlagTypeBool, DefaultValue: false, Description: "Update the lock file"},
 		},
 		Connect: map[string]FlagInfo{},
-		Renew:   map[string]FlagInfo{},
-		Dump:    map[string]FlagInfo{},
+		Renew: map[string]FlagInfo{
+			"onlyExpiringWithin": {
+				Type:         FlagTypeString,
+				DefaultValue: "",
+				Description:  "Renew only the certificates expiring within this threshold",
+			},
+			"node":   {Type: FlagTypeStringSlice, DefaultValue: []string{}, Description: "Nodes to renew certificates on"},
+			"serial": {Type: FlagTypeBool, DefaultValue: false, Description: "Renew one node at a time"},
+		},
+		Dump: map[string]FlagInfo{},
 	}
 }
@@ -243,7 +243,7 @@ func (*Validator) validateSpecificFlag(flagName string, value any) error {
 			}
 		}
 
-	case "timeout", "podRunningCheckTimeout":
+	case "timeout", "podRunningCheckTimeout", "gracePeriod":
 		if val, ok := value.(int); ok {
 			if val <= 0 {
 				return fmt.Errorf("%w: %s must be greater than 0, got %v", ErrMustBePositiveInteger, flagName, val)
@@ -346,7 +346,7 @@ func getValidationSeverity(flagName string, err error) ValidationSeverity {
 	}
 
 	// Timeout validation errors are always fatal.
-	if flagName == "timeout" || flagName == "podRunningCheckTimeout" {
+	if flagName == "timeout" || flagName == "podRunningCheckTimeout" || flagName == "gracePeriod" {
 		return ValidationSeverityFatal
 	}
 
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"os"
 	"strings"
@@ -15,6 +16,10 @@ import (
 
 	"github.com/hashicorp/go-getter"
 	"github.com/sirupsen/logrus"
+
+	"github.com/sighupio/furyctl/internal/dependencies/lock"
+	"github.com/sighupio/furyctl/internal/mirror"
+	gogetterx "github.com/sighupio/furyctl/internal/x/go-getter"
 )
 
 const (
@@ -36,6 +41,12 @@ var (
 
 type Downloader struct {
 	HTTPS bool
+	lock  *lock.Lock
+}
+
+// UseLock pins the packages to the commits recorded in l, and checks every downloaded package against it.
+func (d *Downloader) UseLock(l *lock.Lock) {
+	d.lock = l
 }
 
 func NewDownloader(gitProtocol string) Downloader {
@@ -95,6 +106,14 @@ func (d *Downloader) downloadProcess(wg *sync.WaitGroup, data Package, errChan c
 
 	logrus.Debugf("worker %d : received data %v", i, data)
 
+	if url, fallbackURL, ok := d.mirroredURLs(data); ok {
+		if err := d.downloadFromMirror(data, url, fallbackURL); err != nil {
+			errChan <- err
+		}
+
+		return
+	}
+
 	if d.HTTPS {
 		repoPrefix := httpsRepoPrefix
 		if data.Kind == externalKind {
@@ -185,7 +204,7 @@ func (d *Downloader) downloadProcess(wg *sync.WaitGroup, data Package, errChan c
 
 		url = pU.getConsumableURL()
 
-		if err := get(url, data.Dir, getter.ClientModeDir); err != nil {
+		if err := d.getPackage(data, url); err != nil {
 			o := humanReadableSource(pU.getConsumableURL())
 
 			pU.Prefix = fallbackSSHRepoPrefix
@@ -198,7 +217,7 @@ func (d *Downloader) downloadProcess(wg *sync.WaitGroup, data Package, errChan c
 
 			url = pU.getConsumableURL()
 
-			if err := get(url, data.Dir, getter.ClientModeDir); err != nil {
+			if err := d.getPackage(data, url); err != nil {
 				errChan <- fmt.Errorf(
 					"%w: error downloading %s for '%s' version '%s'. Both urls '%s' and '%s' have failed."+
 						" Please check that the repository exists and that your credentials are"+
@@ -216,7 +235,7 @@ func (d *Downloader) downloadProcess(wg *sync.WaitGroup, data Package, errChan c
 		}
 	}
 
-	downloadErr := get(url, data.Dir, getter.ClientModeDir)
+	downloadErr := d.getPackage(data, url)
 	if downloadErr != nil {
 		if err := os.RemoveAll(data.Dir); err != nil {
 			logrus.Errorf("error removing directory '%s': %s", data.Dir, err.Error())
@@ -225,6 +244,68 @@ func (d *Downloader) downloadProcess(wg *sync.WaitGroup, data Package, errChan c
 	}
 }
 
+// mirroredURLs returns the urls of the package and of its fallback on the configured mirror, if any. Mirrors are
+// not checked in advance like GitHub, so both urls are tried in turn.
+func (d *Downloader) mirroredURLs(data Package) (string, string, bool) {
+	repoPrefix, fallbackRepoPrefix := sshRepoPrefix, fallbackSSHRepoPrefix
+	if d.HTTPS {
+		repoPrefix, fallbackRepoPrefix = httpsRepoPrefix, fallbackHTTPSRepoPrefix
+	}
+
+	if data.Kind == externalKind {
+		repoPrefix, fallbackRepoPrefix = data.URL, data.URL
+	}
+
+	pU := newPackageURL(
+		repoPrefix,
+		strings.Split(data.Name, "/"),
+		data.Kind,
+		data.Version,
+		data.Registry,
+		data.ProviderOpt,
+		data.ProviderKind)
+
+	url, ok := mirror.Default.Rewrite(mirror.ClassModules, "", pU.getConsumableURL())
+	if !ok {
+		return "", "", false
+	}
+
+	pU.Prefix = fallbackRepoPrefix
+
+	fallbackURL, _ := mirror.Default.Rewrite(mirror.ClassModules, "", pU.getConsumableURL())
+
+	return url, fallbackURL, true
+}
+
+func (d *Downloader) downloadFromMirror(data Package, url, fallbackURL string) error {
+	err := d.getPackage(data, url)
+	if err == nil || fallbackURL == url {
+		return err
+	}
+
+	logrus.Infof(
+		"downloading '%s' failed, falling back to '%s' and retrying",
+		humanReadableSource(url),
+		humanReadableSource(fallbackURL),
+	)
+
+	if err := d.getPackage(data, fallbackURL); err != nil {
+		return fmt.Errorf(
+			"%w: error downloading %s for '%s' version '%s'. Both urls '%s' and '%s' have failed."+
+				" Please check that the repository exists on the mirror and that your credentials are"+
+				" correctly configured",
+			err,
+			data.Kind,
+			data.Name,
+			data.Version,
+			humanReadableSource(url),
+			humanReadableSource(fallbackURL),
+		)
+	}
+
+	return nil
+}
+
 func humanReadableSource(src string) string {
 	humanReadableSrc := src
 
@@ -248,6 +329,16 @@ func get(src, dest string, mode getter.ClientMode) error {
 		return fmt.Errorf("%w: %v", ErrGettingWD, err)
 	}
 
+	header, err := mirror.Default.Authenticate(src)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDownloadRepo, err)
+	}
+
+	gitEnv, err := mirror.Default.GitEnv(src)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDownloadRepo, err)
+	}
+
 	client := &getter.Client{
 		Src:  src,
 		Dst:  dest + ".tmp",
@@ -255,6 +346,13 @@ func get(src, dest string, mode getter.ClientMode) error {
 		Mode: mode,
 	}
 
+	if header != nil {
+		client.Getters = maps.Clone(getter.Getters)
+		client.Getters["http"] = &getter.HttpGetter{Netrc: true, Header: header}
+		client.Getters["https"] = &getter.HttpGetter{Netrc: true, Header: header}
+		client.Getters["git"] = &gogetterx.GitGetter{Env: gitEnv}
+	}
+
 	logrus.Debugf("downloading temporary file '%s' into '%s'", client.Src, client.Dst)
 
 	h := humanReadableSource(src)
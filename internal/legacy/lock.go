@@ -0,0 +1,122 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package legacy
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+
+	"github.com/sighupio/furyctl/internal/dependencies/lock"
+	"github.com/sighupio/furyctl/internal/mirror"
+	"github.com/sighupio/furyctl/internal/tool/git"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+	iox "github.com/sighupio/furyctl/internal/x/io"
+)
+
+// LockFileName is the name of the lock file of the packages, stored next to the Furyfile.
+const LockFileName = "Furyfile.lock"
+
+var (
+	commitRegexp = regexp.MustCompile("^[0-9a-f]{40}$")
+	refRegexp    = regexp.MustCompile(`([?&])ref=[^&]*`)
+)
+
+// LockPath returns the path of the lock file of the given Furyfile.
+func LockPath(furyfilePath string) string {
+	return filepath.Join(filepath.Dir(furyfilePath), LockFileName)
+}
+
+// LockSection returns the section of the lock file the package is recorded in.
+func (p Package) LockSection() lock.Section {
+	return lock.Section(p.Kind)
+}
+
+// LockName returns the name the package is recorded with in the lock file: its folder inside the one of its kind.
+func (p Package) LockName() string {
+	return strings.TrimPrefix(newDir("", p).getConsumableDirectory(), "/"+p.Kind+"/")
+}
+
+// getPackage downloads the package from src. When a lock is in use the package is pinned to the locked commit, or
+// to the one its version currently resolves to, and the downloaded content is checked against the lock.
+func (d *Downloader) getPackage(data Package, src string) error {
+	if d.lock == nil {
+		return get(src, data.Dir, getter.ClientModeDir)
+	}
+
+	commit := d.lock.Commit(data.LockSection(), data.LockName(), data.Version)
+	if commit == "" {
+		var err error
+
+		if commit, err = resolveCommit(src); err != nil {
+			return fmt.Errorf("%w: %s '%s': %w", ErrDownloadRepo, data.Kind, data.Name, err)
+		}
+	}
+
+	if err := get(refRegexp.ReplaceAllString(src, "${1}ref="+commit), data.Dir, getter.ClientModeDir); err != nil {
+		return err
+	}
+
+	digest, err := iox.HashDir(data.Dir)
+	if err != nil {
+		return fmt.Errorf("%w: %s '%s': %w", ErrDownloadRepo, data.Kind, data.Name, err)
+	}
+
+	if err := d.lock.Check(data.LockSection(), data.LockName(), lock.Entry{
+		Version: data.Version,
+		Commit:  commit,
+		Digest:  digest,
+	}); err != nil {
+		return fmt.Errorf("error checking Furyfile.lock: %w", err)
+	}
+
+	return nil
+}
+
+// resolveCommit returns the commit the ref of the go-getter source src points to in its repository.
+func resolveCommit(src string) (string, error) {
+	repo, ref := repoAndRef(src)
+	if commitRegexp.MatchString(ref) {
+		return ref, nil
+	}
+
+	// The credentials reach git through the environment of the command, the repository url is left as is.
+	env, err := mirror.Default.GitEnv(repo)
+	if err != nil {
+		return "", fmt.Errorf("error while resolving %s: %w", ref, err)
+	}
+
+	commit, err := git.NewRunner(execx.NewStdExecutor(), git.Paths{Git: "git"}).WithEnv(env).LsRemote(repo, ref)
+	if err != nil {
+		return "", fmt.Errorf("error while resolving %s in %s: %w", ref, humanReadableSource(repo), err)
+	}
+
+	return commit, nil
+}
+
+// repoAndRef splits a go-getter git source into the url of the repository and the ref to check out.
+func repoAndRef(src string) (string, string) {
+	repo, query, _ := strings.Cut(strings.TrimPrefix(src, "git::"), "?")
+
+	start := 0
+	if i := strings.Index(repo, "://"); i >= 0 {
+		start = i + len("://")
+	}
+
+	if i := strings.Index(repo[start:], "//"); i >= 0 {
+		repo = repo[:start+i]
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return strings.TrimSuffix(repo, "/"), ""
+	}
+
+	return strings.TrimSuffix(repo, "/"), values.Get("ref")
+}
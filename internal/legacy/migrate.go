@@ -0,0 +1,531 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package legacy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sighupio/fury-distribution/pkg/apis/config"
+	"github.com/sighupio/furyctl/internal/semver"
+	iox "github.com/sighupio/furyctl/internal/x/io"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+const (
+	modulesConfigPath = "spec.distribution.modules"
+	yamlIndent        = 2
+
+	// Distance of two module versions that cannot be compared.
+	unknownVersionDistance = 1_000_000
+)
+
+var (
+	ErrNoCandidates = errors.New("no distribution version to compare the modules with")
+	ErrEmptyConfig  = errors.New("configuration file is empty")
+)
+
+// moduleTypeRule maps the packages of a module whose name contains match to the value of a field of the module in
+// the configuration. The first rule matching a field wins.
+type moduleTypeRule struct {
+	module string
+	match  string
+	field  string
+	value  string
+}
+
+//nolint:gochecknoglobals // Mapping of the Furyfile packages to the module types of the configuration.
+var moduleTypeRules = []moduleTypeRule{
+	{module: "networking", match: "calico", field: "networking.type", value: "calico"},
+	{module: "networking", match: "cilium", field: "networking.type", value: "cilium"},
+	{module: "ingress", match: "dual-nginx", field: "ingress.nginx.type", value: "dual"},
+	{module: "ingress", match: "nginx", field: "ingress.nginx.type", value: "single"},
+	{module: "logging", match: "opensearch", field: "logging.type", value: "opensearch"},
+	{module: "logging", match: "loki", field: "logging.type", value: "loki"},
+	{module: "monitoring", match: "mimir", field: "monitoring.type", value: "mimir"},
+	{module: "monitoring", match: "prometheus-agent", field: "monitoring.type", value: "prometheusAgent"},
+	{module: "monitoring", match: "prometheus", field: "monitoring.type", value: "prometheus"},
+	{module: "opa", match: "gatekeeper", field: "policy.type", value: "gatekeeper"},
+	{module: "opa", match: "kyverno", field: "policy.type", value: "kyverno"},
+	{module: "dr", match: "velero", field: "dr.type", value: "on-premises"},
+	{module: "tracing", match: "tempo", field: "tracing.type", value: "tempo"},
+	{module: "auth", match: "pomerium", field: "auth.provider.type", value: "sso"},
+}
+
+// DistributionCandidate is a distribution version the Furyfile can be migrated to, with the versions of its modules.
+type DistributionCandidate struct {
+	Version string
+	Modules map[string]string
+}
+
+// Migration is the result of mapping a Furyfile to the configuration of a KFDDistribution cluster.
+type Migration struct {
+	// DistributionVersion is the distribution version whose modules are the nearest to the ones of the Furyfile.
+	DistributionVersion string
+	// Fields are the values to set in the configuration, by path under spec.distribution.modules.
+	Fields map[string]string
+	// Notes describe what could not be mapped and needs manual attention.
+	Notes []string
+}
+
+// KustomizeProject is what a kustomize project deploys from the vendor folder, and what it adds on top of it.
+type KustomizeProject struct {
+	// Packages are the folders of the vendored packages used as resources, relative to the vendor folder.
+	Packages []string
+	// Resources are the resources that are not vendored packages.
+	Resources []string
+	// Patches are the files patching the resources.
+	Patches []string
+}
+
+type kustomization struct {
+	Resources             []string `yaml:"resources"`
+	Bases                 []string `yaml:"bases"`
+	PatchesStrategicMerge []string `yaml:"patchesStrategicMerge"`
+	PatchesJSON6902       []struct {
+		Path string `yaml:"path"`
+	} `yaml:"patchesJson6902"`
+	Patches []struct {
+		Path string `yaml:"path"`
+	} `yaml:"patches"`
+}
+
+// ScanKustomizeProject reads the kustomization files in dir, except for the ones in the vendor folder, and returns
+// what they deploy. Paths are relative to dir.
+func ScanKustomizeProject(dir, vendorFolder string) (KustomizeProject, error) {
+	p := KustomizeProject{}
+
+	absVendor, err := filepath.Abs(filepath.Join(dir, vendorFolder))
+	if err != nil {
+		return p, fmt.Errorf("error while scanning kustomize project: %w", err)
+	}
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if abs, err := filepath.Abs(path); err == nil && abs == absVendor {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if name := d.Name(); name != "kustomization.yaml" && name != "kustomization.yml" && name != "Kustomization" {
+			return nil
+		}
+
+		k, err := yamlx.FromFileV3[kustomization](path)
+		if err != nil {
+			return err //nolint:wrapcheck // Wrapped below.
+		}
+
+		kdir := filepath.Dir(path)
+
+		for _, r := range append(k.Resources, k.Bases...) {
+			p.addResource(dir, kdir, absVendor, r)
+		}
+
+		patches := slices.Clone(k.PatchesStrategicMerge)
+		for _, patch := range k.PatchesJSON6902 {
+			patches = append(patches, patch.Path)
+		}
+
+		for _, patch := range k.Patches {
+			if patch.Path != "" {
+				patches = append(patches, patch.Path)
+			}
+		}
+
+		for _, patch := range patches {
+			p.Patches = append(p.Patches, relPath(dir, filepath.Join(kdir, patch)))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return p, fmt.Errorf("error while scanning kustomize project: %w", err)
+	}
+
+	sort.Strings(p.Packages)
+	sort.Strings(p.Resources)
+	sort.Strings(p.Patches)
+
+	p.Packages = slices.Compact(p.Packages)
+	p.Resources = slices.Compact(p.Resources)
+	p.Patches = slices.Compact(p.Patches)
+
+	return p, nil
+}
+
+func (p *KustomizeProject) addResource(dir, kdir, absVendor, resource string) {
+	// Remote resources are left as they are.
+	if strings.Contains(resource, "://") || strings.HasPrefix(resource, "git@") {
+		p.Resources = append(p.Resources, resource)
+
+		return
+	}
+
+	abs, err := filepath.Abs(filepath.Join(kdir, resource))
+	if err != nil {
+		p.Resources = append(p.Resources, resource)
+
+		return
+	}
+
+	if rel, err := filepath.Rel(absVendor, abs); err == nil && !strings.HasPrefix(rel, "..") {
+		p.Packages = append(p.Packages, filepath.ToSlash(rel))
+
+		return
+	}
+
+	// Resources that are kustomizations of the project itself are scanned on their own.
+	if _, err := os.Stat(filepath.Join(abs, "kustomization.yaml")); err == nil && isInside(dir, abs) {
+		return
+	}
+
+	p.Resources = append(p.Resources, relPath(dir, abs))
+}
+
+// UsedPackages returns the packages the project deploys, and the ones it does not. A project without any vendored
+// package is considered to deploy all of them.
+func (p KustomizeProject) UsedPackages(pkgs []Package, vendorFolder string) ([]Package, []Package) {
+	if len(p.Packages) == 0 {
+		return pkgs, nil
+	}
+
+	used, unused := []Package{}, []Package{}
+
+	for _, pkg := range pkgs {
+		dir := strings.TrimPrefix(pkg.Dir, vendorFolder+"/")
+
+		if slices.ContainsFunc(p.Packages, func(r string) bool {
+			return r == dir || strings.HasPrefix(r, dir+"/") || strings.HasPrefix(dir, r+"/")
+		}) {
+			used = append(used, pkg)
+		} else {
+			unused = append(unused, pkg)
+		}
+	}
+
+	return used, unused
+}
+
+// ModuleVersions returns the version of each module the packages belong to, and the notes about the packages that
+// are not part of a module or whose module is used with more versions.
+func ModuleVersions(pkgs []Package) (map[string]string, []string) {
+	versions := map[string]string{}
+	notes := []string{}
+
+	for _, p := range pkgs {
+		switch p.Kind {
+		case "katalog", "modules":
+
+		case "roles":
+			notes = append(notes, fmt.Sprintf(
+				"role '%s' is not part of KFDDistribution, the nodes are not managed by furyctl for this kind", p.Name))
+
+			continue
+
+		default:
+			notes = append(notes, fmt.Sprintf(
+				"%s package '%s' is not part of the distribution, add it as a plugin in spec.plugins", p.Kind, p.Name))
+
+			continue
+		}
+
+		module, _, _ := strings.Cut(p.Name, "/")
+
+		v, ok := versions[module]
+		if ok && v != p.Version {
+			notes = append(notes, fmt.Sprintf(
+				"module '%s' is vendored with versions %s and %s, %s is used", module, v, p.Version, v))
+
+			continue
+		}
+
+		versions[module] = p.Version
+	}
+
+	return versions, notes
+}
+
+// KFDModuleVersions returns the versions of the modules of the distribution, by module name.
+func KFDModuleVersions(kfd config.KFD) map[string]string {
+	mods := reflect.ValueOf(kfd.Modules)
+	versions := map[string]string{}
+
+	for i := range mods.NumField() {
+		if v, ok := mods.Field(i).Interface().(string); ok && v != "" {
+			versions[strings.ToLower(mods.Type().Field(i).Name)] = v
+		}
+	}
+
+	return versions
+}
+
+// NearestDistribution returns the candidate whose module versions are the nearest to the given ones. Candidates are
+// expected from the newest, which wins on ties.
+func NearestDistribution(modules map[string]string, candidates []DistributionCandidate) (DistributionCandidate, error) {
+	if len(candidates) == 0 {
+		return DistributionCandidate{}, ErrNoCandidates
+	}
+
+	best, bestDistance := 0, -1
+
+	for i, c := range candidates {
+		distance := 0
+
+		for module, v := range modules {
+			if cv, ok := c.Modules[module]; ok {
+				distance += versionDistance(v, cv)
+			}
+		}
+
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+
+	return candidates[best], nil
+}
+
+func versionDistance(a, b string) int {
+	if semver.EnsurePrefix(a) == semver.EnsurePrefix(b) {
+		return 0
+	}
+
+	va, errA := semver.NewVersion(a)
+	vb, errB := semver.NewVersion(b)
+
+	if errA != nil || errB != nil {
+		return unknownVersionDistance
+	}
+
+	sa, sb := va.Segments(), vb.Segments()
+	weights := []int{10000, 100, 1}
+	distance := 0
+
+	for i, w := range weights {
+		if i < len(sa) && i < len(sb) {
+			d := sa[i] - sb[i]
+			if d < 0 {
+				d = -d
+			}
+
+			distance += d * w
+		}
+	}
+
+	return distance
+}
+
+// Migrate maps the packages used by a Furyfile project to the configuration of a KFDDistribution cluster of the
+// given distribution version.
+func Migrate(pkgs []Package, modules map[string]string, candidate DistributionCandidate) Migration {
+	m := Migration{
+		DistributionVersion: candidate.Version,
+		Fields:              map[string]string{},
+	}
+
+	for _, rule := range moduleTypeRules {
+		m.Fields[rule.field] = "none"
+	}
+
+	for _, rule := range moduleTypeRules {
+		if m.Fields[rule.field] != "none" {
+			continue
+		}
+
+		if slices.ContainsFunc(pkgs, func(p Package) bool {
+			module, _, _ := strings.Cut(p.Name, "/")
+
+			return module == rule.module && strings.Contains(p.Name, rule.match)
+		}) {
+			m.Fields[rule.field] = rule.value
+		}
+	}
+
+	mapped := []string{}
+	for _, rule := range moduleTypeRules {
+		mapped = append(mapped, rule.module)
+	}
+
+	names := make([]string, 0, len(modules))
+	for module := range modules {
+		names = append(names, module)
+	}
+
+	sort.Strings(names)
+
+	for _, module := range names {
+		v := modules[module]
+
+		cv, ok := candidate.Modules[module]
+		if !ok {
+			m.Notes = append(m.Notes, fmt.Sprintf(
+				"module '%s' is not part of distribution %s, add it as a plugin in spec.plugins", module, candidate.Version))
+
+			continue
+		}
+
+		if !slices.Contains(mapped, module) {
+			m.Notes = append(m.Notes, fmt.Sprintf(
+				"module '%s' cannot be mapped automatically, configure spec.distribution.modules by hand", module))
+		}
+
+		if semver.EnsurePrefix(v) != semver.EnsurePrefix(cv) {
+			m.Notes = append(m.Notes, fmt.Sprintf(
+				"module '%s' is vendored at %s, distribution %s ships %s: check its changelog", module, v, candidate.Version, cv))
+		}
+	}
+
+	if m.Fields["auth.provider.type"] == "sso" {
+		m.Notes = append(m.Notes, "auth provider has been set to sso, configure spec.distribution.modules.auth by hand")
+	}
+
+	return m
+}
+
+// ProjectNotes returns the notes about the parts of the kustomize project that are not mapped to the configuration.
+func ProjectNotes(p KustomizeProject, unused []Package) []string {
+	notes := []string{}
+
+	for _, pkg := range unused {
+		notes = append(notes, fmt.Sprintf(
+			"%s package '%s' is vendored but not deployed by the kustomize project, it has been ignored", pkg.Kind, pkg.Name))
+	}
+
+	for _, r := range p.Resources {
+		notes = append(notes, fmt.Sprintf(
+			"resource '%s' is not part of the distribution, add it as a kustomize plugin in spec.plugins.kustomize", r))
+	}
+
+	for _, patch := range p.Patches {
+		notes = append(notes, fmt.Sprintf(
+			"patch '%s' has to be moved to spec.distribution.customPatches", patch))
+	}
+
+	return notes
+}
+
+// SetModuleFields sets the fields of the modules in the configuration file at path, keeping its comments.
+func SetModuleFields(path string, fields map[string]string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error while reading %s: %w", path, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return fmt.Errorf("error while parsing %s: %w", path, err)
+	}
+
+	if len(doc.Content) == 0 {
+		return fmt.Errorf("%w: %s", ErrEmptyConfig, path)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		setNodeValue(doc.Content[0], strings.Split(modulesConfigPath+"."+k, "."), fields[k])
+	}
+
+	var out bytes.Buffer
+
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(yamlIndent)
+
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("error while marshaling %s: %w", path, err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("error while marshaling %s: %w", path, err)
+	}
+
+	if err := iox.WriteFile(path, out.Bytes()); err != nil {
+		return fmt.Errorf("error while writing %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// setNodeValue sets the scalar at the given path of the mapping node, creating the missing mappings.
+func setNodeValue(node *yaml.Node, path []string, value string) {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value != path[0] {
+			continue
+		}
+
+		child := node.Content[i+1]
+
+		if len(path) == 1 {
+			child.Kind, child.Tag, child.Value, child.Content = yaml.ScalarNode, "!!str", value, nil
+
+			return
+		}
+
+		if child.Kind != yaml.MappingNode {
+			child.Kind, child.Tag, child.Value, child.Content = yaml.MappingNode, "!!map", "", nil
+		}
+
+		setNodeValue(child, path[1:], value)
+
+		return
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path[0]}
+
+	if len(path) == 1 {
+		node.Content = append(node.Content, key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+
+		return
+	}
+
+	child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	node.Content = append(node.Content, key, child)
+
+	setNodeValue(child, path[1:], value)
+}
+
+func relPath(base, path string) string {
+	absBase, errBase := filepath.Abs(base)
+	absPath, errPath := filepath.Abs(path)
+
+	if errBase != nil || errPath != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(absBase, absPath)
+	if err != nil {
+		return path
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+func isInside(base, path string) bool {
+	rel := relPath(base, path)
+
+	return rel != ".." && !strings.HasPrefix(rel, "../")
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package legacy_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sighupio/furyctl/internal/legacy"
+)
+
+func TestNearestDistribution(t *testing.T) {
+	t.Parallel()
+
+	candidates := []legacy.DistributionCandidate{
+		{Version: "v1.31.0", Modules: map[string]string{"monitoring": "v3.3.0", "logging": "v4.0.0"}},
+		{Version: "v1.30.0", Modules: map[string]string{"monitoring": "v3.2.0", "logging": "v3.4.1"}},
+		{Version: "v1.29.4", Modules: map[string]string{"monitoring": "v3.1.0", "logging": "v3.4.1"}},
+	}
+
+	testCases := []struct {
+		desc    string
+		modules map[string]string
+		want    string
+	}{
+		{
+			desc:    "exact match",
+			modules: map[string]string{"monitoring": "v3.2.0", "logging": "v3.4.1"},
+			want:    "v1.30.0",
+		},
+		{
+			desc:    "nearest match",
+			modules: map[string]string{"monitoring": "v3.1.2", "logging": "v3.4.0"},
+			want:    "v1.29.4",
+		},
+		{
+			desc:    "newest wins on ties",
+			modules: map[string]string{"ingress": "v2.0.0"},
+			want:    "v1.31.0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := legacy.NearestDistribution(tc.modules, candidates)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got.Version)
+		})
+	}
+
+	_, err := legacy.NearestDistribution(map[string]string{}, nil)
+	require.ErrorIs(t, err, legacy.ErrNoCandidates)
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	pkgs := []legacy.Package{
+		{Name: "networking/calico", Version: "v2.0.0", Kind: "katalog"},
+		{Name: "ingress/dual-nginx", Version: "v3.0.0", Kind: "katalog"},
+		{Name: "monitoring/prometheus-operator", Version: "v3.2.0", Kind: "katalog"},
+		{Name: "monitoring/prometheus-operated", Version: "v3.2.0", Kind: "katalog"},
+		{Name: "aws/aws-node-termination-handler", Version: "v4.0.0", Kind: "katalog"},
+		{Name: "node/os", Version: "v1.0.0", Kind: "roles"},
+	}
+
+	modules, notes := legacy.ModuleVersions(pkgs)
+	assert.Equal(t, map[string]string{
+		"networking": "v2.0.0",
+		"ingress":    "v3.0.0",
+		"monitoring": "v3.2.0",
+		"aws":        "v4.0.0",
+	}, modules)
+	assert.Len(t, notes, 1)
+
+	m := legacy.Migrate(pkgs, modules, legacy.DistributionCandidate{
+		Version: "v1.30.0",
+		Modules: map[string]string{"networking": "v2.0.0", "ingress": "v3.0.0", "monitoring": "v3.3.0"},
+	})
+
+	assert.Equal(t, "v1.30.0", m.DistributionVersion)
+	assert.Equal(t, "calico", m.Fields["networking.type"])
+	assert.Equal(t, "dual", m.Fields["ingress.nginx.type"])
+	assert.Equal(t, "prometheus", m.Fields["monitoring.type"])
+	assert.Equal(t, "none", m.Fields["logging.type"])
+	assert.Equal(t, []string{
+		"module 'aws' is not part of distribution v1.30.0, add it as a plugin in spec.plugins",
+		"module 'monitoring' is vendored at v3.2.0, distribution v1.30.0 ships v3.3.0: check its changelog",
+	}, m.Notes)
+}
+
+func TestScanKustomizeProject(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "kustomization.yaml"), `resources:
+  - vendor/katalog/monitoring/prometheus-operator
+  - vendor/katalog/ingress/nginx
+  - resources/ingress.yml
+patchesStrategicMerge:
+  - patches/replicas.yml
+`)
+	writeFile(t, filepath.Join(dir, "vendor", "katalog", "ingress", "nginx", "kustomization.yaml"), `resources:
+  - deploy.yml
+`)
+
+	p, err := legacy.ScanKustomizeProject(dir, "vendor")
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"katalog/monitoring/prometheus-operator", "katalog/ingress/nginx"}, p.Packages)
+	assert.Equal(t, []string{"resources/ingress.yml"}, p.Resources)
+	assert.Equal(t, []string{"patches/replicas.yml"}, p.Patches)
+
+	used, unused := p.UsedPackages([]legacy.Package{
+		{Name: "monitoring/prometheus-operator", Dir: "vendor/katalog/monitoring/prometheus-operator"},
+		{Name: "logging/loki", Dir: "vendor/katalog/logging/loki"},
+	}, "vendor")
+
+	require.Len(t, used, 1)
+	assert.Equal(t, "monitoring/prometheus-operator", used[0].Name)
+	require.Len(t, unused, 1)
+	assert.Equal(t, "logging/loki", unused[0].Name)
+}
+
+func TestSetModuleFields(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "furyctl.yaml")
+
+	writeFile(t, path, `apiVersion: kfd.sighup.io/v1alpha2
+kind: KFDDistribution
+spec:
+  distribution:
+    modules:
+      # The networking module.
+      networking:
+        type: none
+`)
+
+	err := legacy.SetModuleFields(path, map[string]string{
+		"networking.type":    "cilium",
+		"ingress.nginx.type": "single",
+	})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, `apiVersion: kfd.sighup.io/v1alpha2
+kind: KFDDistribution
+spec:
+  distribution:
+    modules:
+      # The networking module.
+      networking:
+        type: cilium
+      ingress:
+        nginx:
+          type: single
+`, string(got))
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
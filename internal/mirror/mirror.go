@@ -0,0 +1,325 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mirror
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/sighupio/furyctl/internal/redact"
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+// Class is the kind of artifact a download belongs to.
+type Class string
+
+const (
+	ClassDistribution Class = "distribution"
+	ClassModules      Class = "modules"
+	ClassInstallers   Class = "installers"
+	ClassTools        Class = "tools"
+
+	// Username used when the credential helper only returns a password, e.g. a token.
+	defaultUsername = "oauth2"
+)
+
+var (
+	ErrInvalidConfig     = errors.New("invalid mirrors configuration")
+	ErrCredentialHelper  = errors.New("credential helper failed")
+	ErrMissingCredential = errors.New("credential helper did not return a password")
+
+	// Default holds the mirrors shared by all the downloaders, it is configured from the root command flags.
+	Default = New() //nolint:gochecknoglobals // Shared between all the downloaders.
+
+	forcedGetterRegexp = regexp.MustCompile(`^[A-Za-z0-9]+::`)
+)
+
+// Config is the content of the mirrors file, e.g.:
+//
+//	rules:
+//	  - class: modules
+//	    prefix: https://github.com/sighupio/
+//	    replace: https://git.example.com/sighupio/
+//	  - class: tools
+//	    tool: kubectl
+//	    prefix: https://dl.k8s.io/
+//	    replace: https://artifacts.example.com/dl.k8s.io/
+//	credentials:
+//	  - host: git.example.com
+//	    helper: git credential fill
+type Config struct {
+	Rules       []Rule       `yaml:"rules"`
+	Credentials []Credential `yaml:"credentials"`
+}
+
+// Rule replaces the prefix of the sources of the given class, or of a single tool, with replace. Forced getters
+// such as `git::` are not part of the prefix.
+type Rule struct {
+	Class   Class  `yaml:"class"`
+	Tool    string `yaml:"tool,omitempty"`
+	Prefix  string `yaml:"prefix"`
+	Replace string `yaml:"replace"`
+}
+
+// Credential selects the helper that provides the credentials for the given host. The helper is invoked with the
+// git credential protocol, so any git credential helper can be used through `git credential fill`.
+type Credential struct {
+	Host   string `yaml:"host"`
+	Helper string `yaml:"helper"`
+}
+
+type credential struct {
+	username string
+	password string
+}
+
+// authorization returns the value of the Authorization header carrying the credential.
+func (c credential) authorization() string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password))
+}
+
+// Mirrors rewrites the download sources according to the configured rules and authenticates them with the
+// configured credential helpers.
+type Mirrors struct {
+	mu          sync.Mutex
+	cfg         Config
+	credentials map[string]credential
+}
+
+func New() *Mirrors {
+	return &Mirrors{
+		credentials: map[string]credential{},
+	}
+}
+
+// Load reads the mirrors file at path and configures m with it.
+func (m *Mirrors) Load(path string) error {
+	cfg, err := yamlx.FromFileV3[Config](path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	return m.Configure(cfg)
+}
+
+func (m *Mirrors) Configure(cfg Config) error {
+	for i, r := range cfg.Rules {
+		switch r.Class {
+		case ClassDistribution, ClassModules, ClassInstallers, ClassTools:
+
+		default:
+			return fmt.Errorf("%w: rule %d: unknown class '%s'", ErrInvalidConfig, i, r.Class)
+		}
+
+		if r.Tool != "" && r.Class != ClassTools {
+			return fmt.Errorf("%w: rule %d: tool can only be set on rules of class '%s'", ErrInvalidConfig, i, ClassTools)
+		}
+
+		if r.Prefix == "" || r.Replace == "" {
+			return fmt.Errorf("%w: rule %d: prefix and replace are required", ErrInvalidConfig, i)
+		}
+	}
+
+	for i, c := range cfg.Credentials {
+		if c.Host == "" || c.Helper == "" {
+			return fmt.Errorf("%w: credential %d: host and helper are required", ErrInvalidConfig, i)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cfg = cfg
+	m.credentials = map[string]credential{}
+
+	return nil
+}
+
+// Rewrite returns src with the prefix of the first matching rule replaced. Rules for the given tool take precedence
+// over the ones for the whole class. The second value reports whether any rule matched.
+func (m *Mirrors) Rewrite(class Class, tool, src string) (string, bool) {
+	m.mu.Lock()
+	rules := m.cfg.Rules
+	m.mu.Unlock()
+
+	getter := forcedGetterRegexp.FindString(src)
+	rest := strings.TrimPrefix(src, getter)
+
+	for _, toolOnly := range []bool{true, false} {
+		for _, r := range rules {
+			if r.Class != class || (r.Tool != "") != toolOnly || (toolOnly && r.Tool != tool) {
+				continue
+			}
+
+			if strings.HasPrefix(rest, r.Prefix) {
+				rewritten := getter + r.Replace + strings.TrimPrefix(rest, r.Prefix)
+
+				logrus.Debugf("Using mirror '%s' for '%s'", rewritten, src)
+
+				return rewritten, true
+			}
+		}
+	}
+
+	return src, false
+}
+
+// Authenticate loads the credentials returned by the helper configured for the host of src, if any, and returns them
+// as an Authorization header for the http getters. It is nil when src is not authenticated. Only sources using the
+// https scheme are authenticated, see GitEnv for the git ones.
+func (m *Mirrors) Authenticate(src string) (http.Header, error) {
+	_, cred, err := m.authenticate(src)
+	if err != nil || cred == nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", cred.authorization())
+
+	return header, nil
+}
+
+// GitEnv returns the GIT_CONFIG_* environment variables that hand the credentials of the host of src to git as an
+// http.<url>.extraHeader entry, after the ones already set by the user, so that they are neither part of the command
+// line nor written to the configuration of the cloned repositories. They must only be added to the environment of the
+// git commands fetching src, so that the other processes do not inherit them. It is nil when src is not authenticated.
+func (m *Mirrors) GitEnv(src string) ([]string, error) {
+	host, cred, err := m.authenticate(src)
+	if err != nil || cred == nil {
+		return nil, err
+	}
+
+	base := 0
+
+	if count := os.Getenv("GIT_CONFIG_COUNT"); count != "" {
+		base, err = strconv.Atoi(count)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid GIT_CONFIG_COUNT '%s'", ErrInvalidConfig, count)
+		}
+	}
+
+	return []string{
+		fmt.Sprintf("GIT_CONFIG_KEY_%d=http.https://%s/.extraHeader", base, host),
+		fmt.Sprintf("GIT_CONFIG_VALUE_%d=Authorization: %s", base, cred.authorization()),
+		fmt.Sprintf("GIT_CONFIG_COUNT=%d", base+1),
+	}, nil
+}
+
+// authenticate returns the host of src and its credentials, nil when src is not authenticated.
+func (m *Mirrors) authenticate(src string) (string, *credential, error) {
+	getter := forcedGetterRegexp.FindString(src)
+
+	u, err := url.Parse(strings.TrimPrefix(src, getter))
+	if err != nil || u.Scheme != "https" || u.User != nil {
+		return "", nil, nil //nolint:nilerr // Sources that are not urls are left to the getters.
+	}
+
+	helper := m.helper(u.Host)
+	if helper == "" {
+		return "", nil, nil
+	}
+
+	cred, err := m.credential(helper, u.Host)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return u.Host, &cred, nil
+}
+
+func (m *Mirrors) helper(host string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range m.cfg.Credentials {
+		if strings.EqualFold(c.Host, host) {
+			return c.Helper
+		}
+	}
+
+	return ""
+}
+
+func (m *Mirrors) credential(helper, host string) (credential, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cred, ok := m.credentials[host]; ok {
+		return cred, nil
+	}
+
+	cred, err := runHelper(helper, host)
+	if err != nil {
+		return credential{}, err
+	}
+
+	redact.Secrets.Add(cred.password, cred.authorization())
+
+	m.credentials[host] = cred
+
+	return cred, nil
+}
+
+// runHelper asks the credentials for host to the helper, using the git credential protocol.
+func runHelper(helper, host string) (credential, error) {
+	args := strings.Fields(helper)
+
+	cmd := execx.NewCmd(args[0], execx.CmdOptions{
+		Args:      args[1:],
+		Sensitive: true,
+	})
+
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=https\nhost=%s\n\n", host))
+
+	if err := cmd.Run(); err != nil {
+		return credential{}, fmt.Errorf("%w for host %s: %w", ErrCredentialHelper, host, err)
+	}
+
+	out, ok := cmd.Stdout.(*bytes.Buffer)
+	if !ok {
+		return credential{}, execx.ErrCastingToBuffer
+	}
+
+	cred := credential{}
+
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "username":
+			cred.username = value
+
+		case "password":
+			cred.password = value
+		}
+	}
+
+	if cred.password == "" {
+		return credential{}, fmt.Errorf("%w for host %s", ErrMissingCredential, host)
+	}
+
+	if cred.username == "" {
+		cred.username = defaultUsername
+	}
+
+	return cred, nil
+}
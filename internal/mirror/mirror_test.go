@@ -0,0 +1,186 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package mirror_test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sighupio/furyctl/internal/mirror"
+)
+
+func TestMirrors_Rewrite(t *testing.T) {
+	t.Parallel()
+
+	m := mirror.New()
+
+	require.NoError(t, m.Configure(mirror.Config{
+		Rules: []mirror.Rule{
+			{
+				Class:   mirror.ClassModules,
+				Prefix:  "https://github.com/sighupio/",
+				Replace: "https://git.example.com/sighupio/",
+			},
+			{
+				Class:   mirror.ClassTools,
+				Prefix:  "https://dl.k8s.io/",
+				Replace: "https://artifacts.example.com/k8s/",
+			},
+			{
+				Class:   mirror.ClassTools,
+				Tool:    "kubectl",
+				Prefix:  "https://dl.k8s.io/",
+				Replace: "https://artifacts.example.com/kubectl/",
+			},
+		},
+	}))
+
+	testCases := []struct {
+		desc         string
+		class        mirror.Class
+		tool         string
+		src          string
+		want         string
+		wantMirrored bool
+	}{
+		{
+			desc:         "forced getter is kept",
+			class:        mirror.ClassModules,
+			src:          "git::https://github.com/sighupio/fury-kubernetes-monitoring?ref=v3.0.0&depth=1",
+			want:         "git::https://git.example.com/sighupio/fury-kubernetes-monitoring?ref=v3.0.0&depth=1",
+			wantMirrored: true,
+		},
+		{
+			desc:  "rules of other classes are ignored",
+			class: mirror.ClassInstallers,
+			src:   "git::https://github.com/sighupio/fury-eks-installer?ref=v3.0.0&depth=1",
+			want:  "git::https://github.com/sighupio/fury-eks-installer?ref=v3.0.0&depth=1",
+		},
+		{
+			desc:         "tool rules take precedence",
+			class:        mirror.ClassTools,
+			tool:         "kubectl",
+			src:          "https://dl.k8s.io/release/v1.31.1/bin/linux/amd64/kubectl",
+			want:         "https://artifacts.example.com/kubectl/release/v1.31.1/bin/linux/amd64/kubectl",
+			wantMirrored: true,
+		},
+		{
+			desc:         "class rules apply to the other tools",
+			class:        mirror.ClassTools,
+			tool:         "kustomize",
+			src:          "https://dl.k8s.io/kustomize.tar.gz",
+			want:         "https://artifacts.example.com/k8s/kustomize.tar.gz",
+			wantMirrored: true,
+		},
+		{
+			desc:  "no matching prefix",
+			class: mirror.ClassTools,
+			tool:  "helm",
+			src:   "https://get.helm.sh/helm-v3.16.1-linux-amd64.tar.gz",
+			want:  "https://get.helm.sh/helm-v3.16.1-linux-amd64.tar.gz",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got, mirrored := m.Rewrite(tc.class, tc.tool, tc.src)
+
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantMirrored, mirrored)
+		})
+	}
+}
+
+func TestMirrors_Configure(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+		cfg  mirror.Config
+	}{
+		{
+			desc: "unknown class",
+			cfg:  mirror.Config{Rules: []mirror.Rule{{Class: "charts", Prefix: "a", Replace: "b"}}},
+		},
+		{
+			desc: "tool on a class other than tools",
+			cfg: mirror.Config{
+				Rules: []mirror.Rule{{Class: mirror.ClassModules, Tool: "kubectl", Prefix: "a", Replace: "b"}},
+			},
+		},
+		{
+			desc: "missing replace",
+			cfg:  mirror.Config{Rules: []mirror.Rule{{Class: mirror.ClassModules, Prefix: "a"}}},
+		},
+		{
+			desc: "missing helper",
+			cfg:  mirror.Config{Credentials: []mirror.Credential{{Host: "git.example.com"}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, mirror.New().Configure(tc.cfg), mirror.ErrInvalidConfig)
+		})
+	}
+}
+
+//nolint:paralleltest // It sets the environment of the process.
+func TestMirrors_Authenticate(t *testing.T) {
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+
+	helper := filepath.Join(t.TempDir(), "helper.sh")
+
+	script := "#!/bin/sh\ncat >/dev/null\necho username=furyctl\necho password=s3cr3t\n"
+
+	require.NoError(t, os.WriteFile(helper, []byte(script), 0o755))
+
+	m := mirror.New()
+
+	require.NoError(t, m.Configure(mirror.Config{
+		Credentials: []mirror.Credential{{Host: "git.example.com", Helper: helper + " get"}},
+	}))
+
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte("furyctl:s3cr3t"))
+
+	header, err := m.Authenticate("git::https://git.example.com/sighupio/fury-distribution?ref=v1.31.0")
+	require.NoError(t, err)
+	assert.Equal(t, auth, header.Get("Authorization"))
+
+	// The credentials reach git through the environment of its commands, after the entries set by the user.
+	env, err := m.GitEnv("git::https://git.example.com/sighupio/fury-distribution?ref=v1.31.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"GIT_CONFIG_KEY_1=http.https://git.example.com/.extraHeader",
+		"GIT_CONFIG_VALUE_1=Authorization: " + auth,
+		"GIT_CONFIG_COUNT=2",
+	}, env)
+	assert.Equal(t, "1", os.Getenv("GIT_CONFIG_COUNT"))
+
+	// Other hosts and schemes are not authenticated.
+	for _, src := range []string{
+		"https://github.com/sighupio/fury-distribution",
+		"git::git@git.example.com:sighupio/fury-distribution",
+	} {
+		header, err := m.Authenticate(src)
+		require.NoError(t, err)
+		assert.Nil(t, header)
+
+		env, err := m.GitEnv(src)
+		require.NoError(t, err)
+		assert.Nil(t, env)
+	}
+}
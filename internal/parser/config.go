@@ -12,15 +12,17 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/sighupio/furyctl/internal/redact"
 	httpx "github.com/sighupio/furyctl/internal/x/http"
 )
 
 const (
-	Path  = "path"
-	Env   = "env"
-	File  = "file"
-	HTTP  = "http"
-	HTTPS = "https"
+	Path     = "path"
+	Env      = "env"
+	File     = "file"
+	HTTP     = "http"
+	HTTPS    = "https"
+	TfOutput = "tfoutput"
 )
 
 var (
@@ -49,6 +51,16 @@ func (p *ConfigParser) ParseDynamicValue(val any) (any, error) {
 			return v, nil
 		}
 
+		// A value made of a single terraform output reference takes the type of the output, e.g. a list.
+		if ref, ok := wholeTfOutputRef(v); ok {
+			resolved, err := TfOutputs.ResolveValue(ref, v)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing dynamic value %s: %w", v, err)
+			}
+
+			return resolved, nil
+		}
+
 		return p.ParseMultipleDynamicValues(v)
 
 	case []any:
@@ -129,6 +141,8 @@ func (p *ConfigParser) parseDynamicString(strVal string) (string, error) {
 
 			envVar = strings.TrimRight(envVar, "\n")
 
+			redact.Secrets.Add(envVar)
+
 			return envVar, nil
 
 		case File:
@@ -144,7 +158,14 @@ func (p *ConfigParser) parseDynamicString(strVal string) (string, error) {
 				return "", fmt.Errorf("%w: %w", ErrCannotParseDynamicValue, err)
 			}
 
-			return strings.TrimRight(string(val), "\n"), nil
+			content := strings.TrimRight(string(val), "\n")
+
+			redact.Secrets.Add(content)
+
+			return content, nil
+
+		case TfOutput:
+			return TfOutputs.Resolve(sourceValue, strVal)
 
 		case HTTP, HTTPS:
 			f, err := httpx.DownloadFile(strings.Trim(strVal, "{}"))
@@ -157,7 +178,11 @@ func (p *ConfigParser) parseDynamicString(strVal string) (string, error) {
 				return "", fmt.Errorf("%w: %w", ErrCannotParseDynamicValue, err)
 			}
 
-			return strings.TrimRight(string(val), "\n"), nil
+			content := strings.TrimRight(string(val), "\n")
+
+			redact.Secrets.Add(content)
+
+			return content, nil
 
 		default:
 			return strVal, nil
@@ -18,6 +18,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/sighupio/furyctl/internal/parser"
+	"github.com/sighupio/furyctl/internal/redact"
 )
 
 func TestNewConfigParser(t *testing.T) {
@@ -191,3 +192,45 @@ func TestConfigParser_ParseDynamicValue(t *testing.T) {
 
 	os.RemoveAll(pathTmpDir)
 }
+
+func TestConfigParser_ParseDynamicValue_RedactsResolvedValues(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	if err := os.WriteFile(path.Join(tmpDir, "secret.txt"), []byte("file-s3cr3t\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := parser.NewConfigParser(tmpDir).ParseDynamicValue("{file://./secret.txt}")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "file-s3cr3t", res)
+	assert.Equal(t, "password: [REDACTED]", redact.Secrets.String("password: file-s3cr3t"))
+}
+
+//nolint:paralleltest // The terraform outputs are resolved by the shared parser.TfOutputs resolver.
+func TestConfigParser_ParseDynamicValue_TfOutput(t *testing.T) {
+	outputsPath := t.TempDir()
+
+	outputs := `{"private_subnets": {"sensitive": false, "type": ["list", "string"], "value": ["subnet-1", "subnet-2"]}}`
+
+	if err := os.WriteFile(path.Join(outputsPath, "output.json"), []byte(outputs), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	parser.TfOutputs.Register(parser.TfOutputPhase{Name: "infrastructure", OutputsPath: outputsPath})
+	defer parser.TfOutputs.Reset()
+
+	cfgParser := parser.NewConfigParser("dummy/base/dir")
+
+	got, err := cfgParser.ParseDynamicValue("{tfoutput://infrastructure/private_subnets}")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"subnet-1", "subnet-2"}, got)
+
+	got, err = cfgParser.ParseDynamicValue("subnets: {tfoutput://infrastructure/private_subnets}")
+
+	assert.NoError(t, err)
+	assert.Equal(t, `subnets: ["subnet-1","subnet-2"]`, got)
+}
@@ -0,0 +1,358 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+const tfOutputFileName = "output.json"
+
+var (
+	ErrTfOutputMalformed      = errors.New("malformed terraform output reference, expected {tfoutput://<phase>/<output-name>}")
+	ErrTfOutputNotConfigured  = errors.New("terraform output references are not supported in this context")
+	ErrTfOutputUnknownPhase   = errors.New("terraform output references an unknown phase")
+	ErrTfOutputPhaseOrder     = errors.New("terraform output referenced before its producing phase")
+	ErrTfOutputNotAvailable   = errors.New("terraform outputs not available")
+	ErrTfOutputNotFound       = errors.New("terraform output not found")
+	ErrTfOutputCannotEncode   = errors.New("cannot encode terraform output value")
+	TfOutputs                 = NewTfOutputResolver() //nolint:gochecknoglobals // Shared between all the config parsers.
+	errTfOutputCannotReadFile = errors.New("cannot read terraform outputs")
+)
+
+// TfOutputPhase is a step of the cluster lifecycle, listed in execution order.
+// OutputsPath is the folder containing the output.json file written by the phase's
+// terraform runner, it is empty for phases that do not produce outputs.
+type TfOutputPhase struct {
+	Name        string
+	OutputsPath string
+}
+
+// TfOutputReference is a {tfoutput://<phase>/<output-name>} dynamic value found in a configuration.
+type TfOutputReference struct {
+	Path   string
+	Phase  string
+	Output string
+}
+
+// TfOutputResolver resolves {tfoutput://<phase>/<output-name>} dynamic values reading the
+// outputs of the phases that already completed.
+type TfOutputResolver struct {
+	mu           sync.RWMutex
+	phases       []TfOutputPhase
+	currentPhase string
+	validator    func() error
+}
+
+func NewTfOutputResolver() *TfOutputResolver {
+	return &TfOutputResolver{}
+}
+
+// Register sets the phases known to the resolver, in execution order.
+func (r *TfOutputResolver) Register(phases ...TfOutputPhase) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.phases = phases
+	r.currentPhase = ""
+}
+
+// SetValidator sets the function used to validate the configuration once the outputs produced by
+// the phases before the current one can be resolved.
+func (r *TfOutputResolver) SetValidator(validator func() error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.validator = validator
+}
+
+// SetCurrentPhase sets the phase whose templates are being rendered. References to outputs of the
+// current phase or of the following ones are left untouched, as they cannot exist yet.
+// The configuration is validated again, if a validator is set, as more outputs may be resolved now.
+func (r *TfOutputResolver) SetCurrentPhase(name string) error {
+	r.mu.Lock()
+	r.currentPhase = name
+	validator := r.validator
+	r.mu.Unlock()
+
+	if validator == nil {
+		return nil
+	}
+
+	if err := validator(); err != nil {
+		return fmt.Errorf("error validating the terraform outputs used by the %s phase: %w", name, err)
+	}
+
+	return nil
+}
+
+// Reset removes all the registered phases and the validator.
+func (r *TfOutputResolver) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.phases = nil
+	r.currentPhase = ""
+	r.validator = nil
+}
+
+// CheckOrder verifies that the consumer phase runs after the phase producing the referenced output.
+func (r *TfOutputResolver) CheckOrder(consumer string, ref TfOutputReference) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	producerIdx, err := r.producerIndex(ref.Phase)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ref.Path, err)
+	}
+
+	consumerIdx := r.phaseIndex(consumer)
+	if consumerIdx == -1 {
+		return fmt.Errorf(
+			"%w: '%s' references '%s/%s' but it is used before any phase runs",
+			ErrTfOutputPhaseOrder,
+			ref.Path,
+			ref.Phase,
+			ref.Output,
+		)
+	}
+
+	if consumerIdx <= producerIdx {
+		return fmt.Errorf(
+			"%w: '%s' belongs to the %s phase, which runs before the %s phase has produced '%s'",
+			ErrTfOutputPhaseOrder,
+			ref.Path,
+			consumer,
+			ref.Phase,
+			ref.Output,
+		)
+	}
+
+	return nil
+}
+
+// Resolve returns the value of the output referenced by the <phase>/<output-name> string, or the
+// original dynamic value if the producing phase has not been reached yet. Values that are not
+// strings are encoded as JSON, so that they can be embedded in a larger string.
+func (r *TfOutputResolver) Resolve(ref, dynamicValue string) (string, error) {
+	value, err := r.ResolveValue(ref, dynamicValue)
+	if err != nil {
+		return "", err
+	}
+
+	return tfOutputValueToString(value)
+}
+
+// ResolveValue is like Resolve but returns the output value with its terraform type, e.g. a list
+// or a number, to be used when the reference is the whole configuration value.
+func (r *TfOutputResolver) ResolveValue(ref, dynamicValue string) (any, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	phase, output, err := splitTfOutputRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.phases) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTfOutputNotConfigured, dynamicValue)
+	}
+
+	producerIdx, err := r.producerIndex(phase)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.currentPhase != "" && r.phaseIndex(r.currentPhase) <= producerIdx {
+		return dynamicValue, nil
+	}
+
+	outputs, err := readTfOutputs(r.phases[producerIdx].OutputsPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf(
+				"%w: the %s phase has not been applied yet, cannot resolve %s",
+				ErrTfOutputNotAvailable,
+				phase,
+				dynamicValue,
+			)
+		}
+
+		return nil, err
+	}
+
+	out, ok := outputs[output]
+	if !ok || out == nil {
+		return nil, fmt.Errorf(
+			"%w: '%s' is not an output of the %s phase, available outputs are: %s",
+			ErrTfOutputNotFound,
+			output,
+			phase,
+			strings.Join(sortedKeys(outputs), ", "),
+		)
+	}
+
+	return out.Value, nil
+}
+
+func (r *TfOutputResolver) producerIndex(phase string) (int, error) {
+	idx := r.phaseIndex(phase)
+	if idx == -1 || r.phases[idx].OutputsPath == "" {
+		producers := []string{}
+
+		for _, p := range r.phases {
+			if p.OutputsPath != "" {
+				producers = append(producers, p.Name)
+			}
+		}
+
+		return -1, fmt.Errorf(
+			"%w '%s', options are: %s",
+			ErrTfOutputUnknownPhase,
+			phase,
+			strings.Join(producers, ", "),
+		)
+	}
+
+	return idx, nil
+}
+
+func (r *TfOutputResolver) phaseIndex(phase string) int {
+	return slices.IndexFunc(r.phases, func(p TfOutputPhase) bool {
+		return p.Name == phase
+	})
+}
+
+// FindTfOutputReferences walks the given configuration and returns all the terraform output
+// references it contains, along with the dotted path where they are used.
+func FindTfOutputReferences(conf any) ([]TfOutputReference, error) {
+	refs := []TfOutputReference{}
+
+	if err := findTfOutputReferences(conf, "", &refs); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Path < refs[j].Path
+	})
+
+	return refs, nil
+}
+
+func findTfOutputReferences(value any, path string, refs *[]TfOutputReference) error {
+	rv := reflect.ValueOf(value)
+
+	//nolint:exhaustive // Only collections and strings can hold references.
+	switch rv.Kind() {
+	case reflect.Map:
+		for _, k := range rv.MapKeys() {
+			if err := findTfOutputReferences(
+				rv.MapIndex(k).Interface(),
+				fmt.Sprintf("%s.%v", path, k.Interface()),
+				refs,
+			); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice:
+		for i := range rv.Len() {
+			if err := findTfOutputReferences(rv.Index(i).Interface(), fmt.Sprintf("%s[%d]", path, i), refs); err != nil {
+				return err
+			}
+		}
+
+	case reflect.String:
+		for _, dynamicValue := range DynamicRegexp.FindAllString(rv.String(), -1) {
+			ref, ok := strings.CutPrefix(strings.Trim(dynamicValue, "{}"), TfOutput+"://")
+			if !ok {
+				continue
+			}
+
+			phase, output, err := splitTfOutputRef(ref)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+
+			*refs = append(*refs, TfOutputReference{
+				Path:   path,
+				Phase:  phase,
+				Output: output,
+			})
+		}
+	}
+
+	return nil
+}
+
+// wholeTfOutputRef returns the <phase>/<output-name> string when value is made of a single terraform output reference.
+func wholeTfOutputRef(value string) (string, bool) {
+	if DynamicRegexp.FindString(value) != value {
+		return "", false
+	}
+
+	return strings.CutPrefix(strings.Trim(value, "{}"), TfOutput+"://")
+}
+
+func splitTfOutputRef(ref string) (string, string, error) {
+	phase, output, ok := strings.Cut(ref, "/")
+	if !ok || phase == "" || output == "" || strings.Contains(output, "/") {
+		return "", "", fmt.Errorf("%w, got '%s'", ErrTfOutputMalformed, ref)
+	}
+
+	return phase, output, nil
+}
+
+func readTfOutputs(outputsPath string) (map[string]*tfjson.StateOutput, error) {
+	var outputs map[string]*tfjson.StateOutput
+
+	outJSON, err := os.ReadFile(filepath.Join(outputsPath, tfOutputFileName))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errTfOutputCannotReadFile, err)
+	}
+
+	if err := json.Unmarshal(outJSON, &outputs); err != nil {
+		return nil, fmt.Errorf("%w: %w", errTfOutputCannotReadFile, err)
+	}
+
+	return outputs, nil
+}
+
+// tfOutputValueToString returns strings as they are and encodes any other value as JSON.
+func tfOutputValueToString(value any) (string, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	out, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrTfOutputCannotEncode, err)
+	}
+
+	return string(out), nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package parser_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sighupio/furyctl/internal/parser"
+)
+
+func newTestTfOutputResolver(t *testing.T) *parser.TfOutputResolver {
+	t.Helper()
+
+	infraOutputs := t.TempDir()
+
+	outputs := `{
+		"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-123"},
+		"private_subnets": {"sensitive": false, "type": ["list", "string"], "value": ["subnet-1", "subnet-2"]}
+	}`
+
+	if err := os.WriteFile(filepath.Join(infraOutputs, "output.json"), []byte(outputs), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	r := parser.NewTfOutputResolver()
+
+	r.Register(
+		parser.TfOutputPhase{Name: "preflight"},
+		parser.TfOutputPhase{Name: "infrastructure", OutputsPath: infraOutputs},
+		parser.TfOutputPhase{Name: "kubernetes", OutputsPath: filepath.Join(t.TempDir(), "missing")},
+		parser.TfOutputPhase{Name: "distribution"},
+	)
+
+	return r
+}
+
+func TestTfOutputResolver_Resolve(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		currentPhase string
+		ref          string
+		want         string
+		wantErr      error
+	}{
+		{
+			name:         "string output",
+			currentPhase: "kubernetes",
+			ref:          "infrastructure/vpc_id",
+			want:         "vpc-123",
+		},
+		{
+			name:         "list output",
+			currentPhase: "kubernetes",
+			ref:          "infrastructure/private_subnets",
+			want:         `["subnet-1","subnet-2"]`,
+		},
+		{
+			name:         "no current phase",
+			currentPhase: "",
+			ref:          "infrastructure/vpc_id",
+			want:         "vpc-123",
+		},
+		{
+			name:         "deferred while rendering the producing phase",
+			currentPhase: "infrastructure",
+			ref:          "infrastructure/vpc_id",
+			want:         "{tfoutput://infrastructure/vpc_id}",
+		},
+		{
+			name:         "deferred while rendering a previous phase",
+			currentPhase: "preflight",
+			ref:          "kubernetes/cluster_endpoint",
+			want:         "{tfoutput://kubernetes/cluster_endpoint}",
+		},
+		{
+			name:         "phase not applied yet",
+			currentPhase: "distribution",
+			ref:          "kubernetes/cluster_endpoint",
+			wantErr:      parser.ErrTfOutputNotAvailable,
+		},
+		{
+			name:         "unknown output",
+			currentPhase: "kubernetes",
+			ref:          "infrastructure/unknown",
+			wantErr:      parser.ErrTfOutputNotFound,
+		},
+		{
+			name:         "unknown phase",
+			currentPhase: "kubernetes",
+			ref:          "plugins/foo",
+			wantErr:      parser.ErrTfOutputUnknownPhase,
+		},
+		{
+			name:         "phase without outputs",
+			currentPhase: "kubernetes",
+			ref:          "preflight/foo",
+			wantErr:      parser.ErrTfOutputUnknownPhase,
+		},
+		{
+			name:         "malformed reference",
+			currentPhase: "kubernetes",
+			ref:          "infrastructure",
+			wantErr:      parser.ErrTfOutputMalformed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := newTestTfOutputResolver(t)
+
+			assert.NoError(t, r.SetCurrentPhase(tc.currentPhase))
+
+			got, err := r.Resolve(tc.ref, "{tfoutput://"+tc.ref+"}")
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTfOutputResolver_ResolveValue(t *testing.T) {
+	t.Parallel()
+
+	r := newTestTfOutputResolver(t)
+
+	assert.NoError(t, r.SetCurrentPhase("kubernetes"))
+
+	got, err := r.ResolveValue("infrastructure/private_subnets", "{tfoutput://infrastructure/private_subnets}")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"subnet-1", "subnet-2"}, got)
+
+	assert.NoError(t, r.SetCurrentPhase("infrastructure"))
+
+	got, err = r.ResolveValue("infrastructure/private_subnets", "{tfoutput://infrastructure/private_subnets}")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "{tfoutput://infrastructure/private_subnets}", got)
+}
+
+func TestTfOutputResolver_SetCurrentPhase(t *testing.T) {
+	t.Parallel()
+
+	errInvalid := errors.New("invalid")
+
+	r := newTestTfOutputResolver(t)
+
+	validated := []string{}
+
+	r.SetValidator(func() error {
+		validated = append(validated, "validated")
+
+		return nil
+	})
+
+	assert.NoError(t, r.SetCurrentPhase("kubernetes"))
+	assert.Equal(t, []string{"validated"}, validated)
+
+	r.SetValidator(func() error {
+		return errInvalid
+	})
+
+	err := r.SetCurrentPhase("distribution")
+
+	assert.ErrorIs(t, err, errInvalid)
+	assert.ErrorContains(t, err, "distribution phase")
+
+	r.Reset()
+
+	assert.NoError(t, r.SetCurrentPhase("distribution"))
+}
+
+func TestTfOutputResolver_ResolveNotConfigured(t *testing.T) {
+	t.Parallel()
+
+	r := parser.NewTfOutputResolver()
+
+	_, err := r.Resolve("infrastructure/vpc_id", "{tfoutput://infrastructure/vpc_id}")
+
+	assert.ErrorIs(t, err, parser.ErrTfOutputNotConfigured)
+}
+
+func TestTfOutputResolver_CheckOrder(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		consumer string
+		phase    string
+		wantErr  error
+	}{
+		{
+			name:     "consumer after producer",
+			consumer: "distribution",
+			phase:    "infrastructure",
+		},
+		{
+			name:     "consumer is the producer",
+			consumer: "infrastructure",
+			phase:    "infrastructure",
+			wantErr:  parser.ErrTfOutputPhaseOrder,
+		},
+		{
+			name:     "consumer before producer",
+			consumer: "infrastructure",
+			phase:    "kubernetes",
+			wantErr:  parser.ErrTfOutputPhaseOrder,
+		},
+		{
+			name:     "unknown consumer",
+			consumer: "metadata",
+			phase:    "infrastructure",
+			wantErr:  parser.ErrTfOutputPhaseOrder,
+		},
+		{
+			name:     "unknown producer",
+			consumer: "distribution",
+			phase:    "foo",
+			wantErr:  parser.ErrTfOutputUnknownPhase,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := newTestTfOutputResolver(t)
+
+			err := r.CheckOrder(tc.consumer, parser.TfOutputReference{
+				Path:   ".spec.foo",
+				Phase:  tc.phase,
+				Output: "bar",
+			})
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFindTfOutputReferences(t *testing.T) {
+	t.Parallel()
+
+	conf := map[string]any{
+		"metadata": map[string]any{
+			"name": "test",
+		},
+		"spec": map[string]any{
+			"kubernetes": map[string]any{
+				"vpcId":   "{tfoutput://infrastructure/vpc_id}",
+				"subnets": []any{"{env://SUBNET}", "prefix-{tfoutput://infrastructure/subnet}"},
+			},
+		},
+	}
+
+	refs, err := parser.FindTfOutputReferences(conf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []parser.TfOutputReference{
+		{Path: ".spec.kubernetes.subnets[1]", Phase: "infrastructure", Output: "subnet"},
+		{Path: ".spec.kubernetes.vpcId", Phase: "infrastructure", Output: "vpc_id"},
+	}, refs)
+
+	_, err = parser.FindTfOutputReferences(map[string]any{"foo": "{tfoutput://infrastructure}"})
+
+	assert.ErrorIs(t, err, parser.ErrTfOutputMalformed)
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package redact
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// Mask is the placeholder that replaces secret values.
+	Mask = "[REDACTED]"
+
+	// Values shorter than this are not tracked, to avoid masking common words and numbers everywhere.
+	minSecretLength = 6
+)
+
+var (
+	ErrCannotParseSchema = errors.New("cannot parse schema")
+	Secrets              = NewRegistry() //nolint:gochecknoglobals // Shared between loggers, commands and stores.
+
+	// Names of the configuration fields known to hold secrets, the distribution schemas do not mark them.
+	defaultSensitiveFields = []string{ //nolint:gochecknoglobals // Read-only list.
+		"bindPW",
+		"clientSecret",
+		"COOKIE_SECRET",
+		"IDP_CLIENT_SECRET",
+		"password",
+		"secretAccessKey",
+		"sessionSecurityKey",
+		"SHARED_SECRET",
+		"SIGNING_KEY",
+	}
+)
+
+// Registry keeps track of the secret values that must never be written in clear text to logs, the
+// terminal or the cluster state.
+type Registry struct {
+	mu       sync.RWMutex
+	values   map[string]struct{}
+	fields   map[string]struct{}
+	replacer *strings.Replacer
+}
+
+func NewRegistry() *Registry {
+	r := &Registry{
+		values: map[string]struct{}{},
+		fields: map[string]struct{}{},
+	}
+
+	r.AddSensitiveFields(defaultSensitiveFields...)
+
+	return r
+}
+
+// Add tracks the given secret values, together with their base64 encoding.
+func (r *Registry) Add(values ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, value := range values {
+		r.add(value)
+		r.add(base64.StdEncoding.EncodeToString([]byte(value)))
+	}
+}
+
+func (r *Registry) add(value string) {
+	if len(value) < minSecretLength {
+		return
+	}
+
+	if _, ok := r.values[value]; ok {
+		return
+	}
+
+	r.values[value] = struct{}{}
+	r.replacer = nil
+}
+
+// AddSensitiveFields marks the given configuration field names as holding secrets.
+func (r *Registry) AddSensitiveFields(names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range names {
+		r.fields[name] = struct{}{}
+	}
+}
+
+// IsSensitiveField tells whether the given configuration field name holds secrets.
+func (r *Registry) IsSensitiveField(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.fields[name]
+
+	return ok
+}
+
+// AddFromConfig walks the given configuration and tracks the values of all the sensitive fields.
+func (r *Registry) AddFromConfig(conf any) {
+	rv := reflect.ValueOf(conf)
+
+	//nolint:exhaustive // Only collections can hold sensitive fields.
+	switch rv.Kind() {
+	case reflect.Map:
+		for _, k := range rv.MapKeys() {
+			value := rv.MapIndex(k).Interface()
+
+			if s, ok := value.(string); ok && r.IsSensitiveField(fmt.Sprintf("%v", k.Interface())) {
+				r.Add(s)
+
+				continue
+			}
+
+			r.AddFromConfig(value)
+		}
+
+	case reflect.Slice:
+		for i := range rv.Len() {
+			r.AddFromConfig(rv.Index(i).Interface())
+		}
+	}
+}
+
+// String replaces all the tracked secrets contained in s with Mask.
+func (r *Registry) String(s string) string {
+	replacer := r.getReplacer()
+	if replacer == nil {
+		return s
+	}
+
+	return replacer.Replace(s)
+}
+
+// Bytes replaces all the tracked secrets contained in p with Mask, it can be used as a writer transform.
+// Secrets split across different writes are not detected.
+func (r *Registry) Bytes(p []byte) ([]byte, error) {
+	replacer := r.getReplacer()
+	if replacer == nil {
+		return p, nil
+	}
+
+	return []byte(replacer.Replace(string(p))), nil
+}
+
+// Value returns Mask if the given value contains a tracked secret, the value itself otherwise.
+func (r *Registry) Value(value any) any {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	if r.String(s) != s {
+		return Mask
+	}
+
+	return value
+}
+
+// Reset forgets all the tracked values and restores the default sensitive fields.
+func (r *Registry) Reset() {
+	r.mu.Lock()
+
+	r.values = map[string]struct{}{}
+	r.fields = map[string]struct{}{}
+	r.replacer = nil
+
+	r.mu.Unlock()
+
+	r.AddSensitiveFields(defaultSensitiveFields...)
+}
+
+func (r *Registry) getReplacer() *strings.Replacer {
+	r.mu.RLock()
+
+	if r.replacer != nil || len(r.values) == 0 {
+		defer r.mu.RUnlock()
+
+		return r.replacer
+	}
+
+	r.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	values := make([]string, 0, len(r.values))
+
+	for v := range r.values {
+		values = append(values, v)
+	}
+
+	// Longer values go first, so that a secret containing another one is masked as a whole.
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+
+		return values[i] < values[j]
+	})
+
+	oldnew := make([]string, 0, len(values)*2) //nolint:mnd // Each value has its replacement.
+
+	for _, v := range values {
+		oldnew = append(oldnew, v, Mask)
+	}
+
+	r.replacer = strings.NewReplacer(oldnew...)
+
+	return r.replacer
+}
+
+// SensitiveFieldsFromSchema returns the names of the properties marked as write-only or with the
+// password format in the given JSON schema.
+func SensitiveFieldsFromSchema(schema []byte) ([]string, error) {
+	var s any
+
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCannotParseSchema, err)
+	}
+
+	fields := map[string]struct{}{}
+
+	findSensitiveFields(s, fields)
+
+	names := make([]string, 0, len(fields))
+
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func findSensitiveFields(node any, fields map[string]struct{}) {
+	switch n := node.(type) {
+	case map[string]any:
+		if props, ok := n["properties"].(map[string]any); ok {
+			for name, prop := range props {
+				p, ok := prop.(map[string]any)
+				if !ok {
+					continue
+				}
+
+				if writeOnly, ok := p["writeOnly"].(bool); ok && writeOnly {
+					fields[name] = struct{}{}
+				}
+
+				if format, ok := p["format"].(string); ok && format == "password" {
+					fields[name] = struct{}{}
+				}
+			}
+		}
+
+		for _, v := range n {
+			findSensitiveFields(v, fields)
+		}
+
+	case []any:
+		for _, v := range n {
+			findSensitiveFields(v, fields)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package redact_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sighupio/furyctl/internal/redact"
+)
+
+func TestRegistry_String(t *testing.T) {
+	t.Parallel()
+
+	r := redact.NewRegistry()
+
+	r.Add("s3cr3t-token", "short", "-----BEGIN KEY-----\nline-one-of-key\n-----END KEY-----")
+
+	testCases := []struct {
+		desc  string
+		input string
+		want  string
+	}{
+		{
+			desc:  "no secrets",
+			input: "nothing to hide",
+			want:  "nothing to hide",
+		},
+		{
+			desc:  "secret",
+			input: "token=s3cr3t-token",
+			want:  "token=" + redact.Mask,
+		},
+		{
+			desc:  "base64 encoded secret",
+			input: "data: " + base64.StdEncoding.EncodeToString([]byte("s3cr3t-token")),
+			want:  "data: " + redact.Mask,
+		},
+		{
+			desc:  "short values are not tracked",
+			input: "short",
+			want:  "short",
+		},
+		{
+			desc:  "multi-line secret",
+			input: "got -----BEGIN KEY-----\nline-one-of-key\n-----END KEY-----",
+			want:  "got " + redact.Mask,
+		},
+		{
+			desc:  "single lines of a multi-line secret are not tracked",
+			input: "-----END KEY-----",
+			want:  "-----END KEY-----",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, r.String(tc.input))
+
+			got, err := r.Bytes([]byte(tc.input))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestRegistry_AddFromConfig(t *testing.T) {
+	t.Parallel()
+
+	r := redact.NewRegistry()
+
+	r.AddSensitiveFields("apiKey")
+
+	r.AddFromConfig(map[string]any{
+		"spec": map[string]any{
+			"auth": []any{
+				map[string]any{"password": "my-password", "username": "my-username"},
+			},
+			"apiKey": "my-api-key",
+		},
+	})
+
+	assert.Equal(t, redact.Mask, r.Value("my-password"))
+	assert.Equal(t, redact.Mask, r.Value("my-api-key"))
+	assert.Equal(t, "my-username", r.Value("my-username"))
+	assert.Equal(t, 42, r.Value(42))
+	assert.True(t, r.IsSensitiveField("password"))
+	assert.False(t, r.IsSensitiveField("username"))
+
+	r.Reset()
+
+	assert.Equal(t, "my-password", r.Value("my-password"))
+	assert.False(t, r.IsSensitiveField("apiKey"))
+	assert.True(t, r.IsSensitiveField("password"))
+}
+
+func TestSensitiveFieldsFromSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := `{
+		"properties": {
+			"spec": {
+				"type": "object",
+				"properties": {
+					"token": {"type": "string", "writeOnly": true},
+					"password": {"type": "string", "format": "password"},
+					"name": {"type": "string"}
+				}
+			}
+		}
+	}`
+
+	fields, err := redact.SensitiveFieldsFromSchema([]byte(schema))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"password", "token"}, fields)
+
+	_, err = redact.SensitiveFieldsFromSchema([]byte("not json"))
+
+	assert.ErrorIs(t, err, redact.ErrCannotParseSchema)
+}
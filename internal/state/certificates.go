@@ -0,0 +1,102 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/util/retry"
+
+	kubex "github.com/sighupio/furyctl/internal/x/kube"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+const (
+	certificatesConfigMapName = "furyctl-certificates"
+	certificatesConfigMapKey  = "renewals"
+
+	// maxCertificatesRenewals is the number of renewals kept in the cluster, older ones are dropped.
+	maxCertificatesRenewals = 20
+)
+
+// CertificatesRenewal records the certificates rotated by a `furyctl renew certificates` run.
+type CertificatesRenewal struct {
+	Date         time.Time            `yaml:"date"`
+	Nodes        []string             `yaml:"nodes"`
+	Certificates []RenewedCertificate `yaml:"certificates"`
+}
+
+type RenewedCertificate struct {
+	Node             string    `yaml:"node"`
+	Path             string    `yaml:"path"`
+	PreviousNotAfter time.Time `yaml:"previousNotAfter"`
+	NotAfter         time.Time `yaml:"notAfter"`
+}
+
+// StoreCertificatesRenewal appends the renewal to the ones recorded in the cluster.
+func (s *KubeStore) StoreCertificatesRenewal(renewal CertificatesRenewal) error {
+	renewals, err := s.GetCertificatesRenewals()
+	if err != nil {
+		return err
+	}
+
+	renewals = append(renewals, renewal)
+	if len(renewals) > maxCertificatesRenewals {
+		renewals = renewals[len(renewals)-maxCertificatesRenewals:]
+	}
+
+	x, err := yamlx.MarshalV3(renewals)
+	if err != nil {
+		return fmt.Errorf("error while marshalling certificates renewals: %w", err)
+	}
+
+	configMap := corev1ac.ConfigMap(certificatesConfigMapName, stateNamespace).
+		WithData(map[string]string{certificatesConfigMapKey: string(x)})
+
+	logrus.Info("Saving renewed certificates in the cluster...")
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		_, err := s.Client.CoreV1().ConfigMaps(stateNamespace).Apply(context.Background(), configMap, metav1.ApplyOptions{
+			FieldManager: kubex.FieldManager,
+			Force:        true,
+		})
+
+		return err //nolint:wrapcheck // Wrapped below, the retry needs the API error.
+	}); err != nil {
+		return fmt.Errorf("error while saving renewed certificates in the cluster: %w", err)
+	}
+
+	return nil
+}
+
+// GetCertificatesRenewals returns the renewals recorded in the cluster, from the oldest to the newest.
+func (s *KubeStore) GetCertificatesRenewals() ([]CertificatesRenewal, error) {
+	renewals := []CertificatesRenewal{}
+
+	configMap, err := s.Client.CoreV1().ConfigMaps(stateNamespace).Get(
+		context.Background(),
+		certificatesConfigMapName,
+		metav1.GetOptions{},
+	)
+	if k8serrors.IsNotFound(err) {
+		return renewals, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error while getting renewed certificates from the cluster: %w", err)
+	}
+
+	if err := yamlx.UnmarshalV3([]byte(configMap.Data[certificatesConfigMapKey]), &renewals); err != nil {
+		return nil, fmt.Errorf("error while unmarshalling certificates renewals: %w", err)
+	}
+
+	return renewals, nil
+}
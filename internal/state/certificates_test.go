@@ -0,0 +1,54 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/sighupio/furyctl/internal/state"
+)
+
+func TestKubeStore_StoreCertificatesRenewal(t *testing.T) {
+	t.Parallel()
+
+	store := state.NewKubeStore("", "", fake.NewClientset())
+
+	renewals, err := store.GetCertificatesRenewals()
+	require.NoError(t, err)
+	assert.Empty(t, renewals)
+
+	date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := range 25 {
+		require.NoError(t, store.StoreCertificatesRenewal(state.CertificatesRenewal{
+			Date:  date.AddDate(0, 0, i),
+			Nodes: []string{"master1"},
+			Certificates: []state.RenewedCertificate{
+				{
+					Node:             "master1",
+					Path:             "/etc/kubernetes/pki/apiserver.crt",
+					PreviousNotAfter: date,
+					NotAfter:         date.AddDate(1, 0, i),
+				},
+			},
+		}))
+	}
+
+	renewals, err = store.GetCertificatesRenewals()
+	require.NoError(t, err)
+
+	// Only the most recent renewals are kept.
+	require.Len(t, renewals, 20)
+	assert.Equal(t, date.AddDate(0, 0, 5), renewals[0].Date)
+	assert.Equal(t, date.AddDate(0, 0, 24), renewals[19].Date)
+	assert.Equal(t, "master1", renewals[19].Certificates[0].Node)
+}
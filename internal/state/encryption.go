@@ -0,0 +1,115 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	encryptedPrefix = "furyctl:aes-256-gcm:"
+	saltSize        = 16
+	keySize         = 32
+	scryptN         = 32768
+	scryptR         = 8
+	scryptP         = 1
+)
+
+var (
+	// EncryptionKey is the passphrase used to encrypt the rendered configuration stored in the cluster,
+	// when empty the rendered configuration is stored in clear text.
+	EncryptionKey = "" //nolint:gochecknoglobals // This variable is shared between all the store instances.
+
+	ErrStateEncrypted       = errors.New("the rendered configuration stored in the cluster is encrypted")
+	ErrStateCannotEncrypt   = errors.New("cannot encrypt the rendered configuration")
+	ErrStateCannotDecrypt   = errors.New("cannot decrypt the rendered configuration")
+	errStateCiphertextShort = errors.New("ciphertext too short")
+)
+
+// encrypt seals the data with AES-256-GCM, using a key derived from the passphrase with scrypt.
+// The output contains the prefix, the salt, the nonce and the ciphertext.
+func encrypt(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStateCannotEncrypt, err)
+	}
+
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStateCannotEncrypt, err)
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStateCannotEncrypt, err)
+	}
+
+	out := append([]byte(encryptedPrefix), salt...)
+	out = append(out, nonce...)
+
+	return gcm.Seal(out, nonce, data, nil), nil
+}
+
+func decrypt(data []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("%w, set the encryption key with --state-encryption-key", ErrStateEncrypted)
+	}
+
+	data = bytes.TrimPrefix(data, []byte(encryptedPrefix))
+
+	if len(data) < saltSize {
+		return nil, fmt.Errorf("%w: %w", ErrStateCannotDecrypt, errStateCiphertextShort)
+	}
+
+	gcm, err := newGCM(passphrase, data[:saltSize])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStateCannotDecrypt, err)
+	}
+
+	data = data[saltSize:]
+
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("%w: %w", ErrStateCannotDecrypt, errStateCiphertextShort)
+	}
+
+	out, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w, is the encryption key correct? %w", ErrStateCannotDecrypt, err)
+	}
+
+	return out, nil
+}
+
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedPrefix))
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("error while deriving key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating gcm: %w", err)
+	}
+
+	return gcm, nil
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("spec:\n  password: s3cr3t-value\n")
+
+	encrypted, err := encrypt(data, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, isEncrypted(encrypted))
+	assert.NotContains(t, string(encrypted), "s3cr3t-value")
+
+	decrypted, err := decrypt(encrypted, "passphrase")
+
+	assert.NoError(t, err)
+	assert.Equal(t, data, decrypted)
+
+	_, err = decrypt(encrypted, "wrong-passphrase")
+
+	assert.ErrorIs(t, err, ErrStateCannotDecrypt)
+
+	_, err = decrypt(encrypted, "")
+
+	assert.ErrorIs(t, err, ErrStateEncrypted)
+
+	assert.False(t, isEncrypted(data))
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	kubex "github.com/sighupio/furyctl/internal/x/kube"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+const (
+	stateNamespace   = "kube-system"
+	kfdSecretName    = "furyctl-kfd"
+	configSecretName = "furyctl-config"
+)
+
+var ErrMissingStateKey = errors.New("key missing from the state stored in the cluster")
+
+// KubeStore saves the state in the cluster through the Kubernetes API, without writing it to disk.
+type KubeStore struct {
+	DistroPath string
+	ConfigPath string
+	Client     kubernetes.Interface
+}
+
+func NewKubeStore(distroPath, configPath string, client kubernetes.Interface) *KubeStore {
+	return &KubeStore{
+		DistroPath: distroPath,
+		ConfigPath: configPath,
+		Client:     client,
+	}
+}
+
+func (s *KubeStore) StoreKFD() error {
+	x, err := os.ReadFile(path.Join(s.DistroPath, "kfd.yaml"))
+	if err != nil {
+		return fmt.Errorf("error while reading config file: %w", err)
+	}
+
+	logrus.Info("Saving distribution configuration file in the cluster...")
+
+	if err := s.applySecret(kfdSecretName, map[string][]byte{"kfd": x}); err != nil {
+		return fmt.Errorf("error while saving distribution configuration file in the cluster: %w", err)
+	}
+
+	return nil
+}
+
+func (s *KubeStore) StoreConfig(rendered map[string]any) error {
+	x, err := os.ReadFile(s.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("error while reading config file: %w", err)
+	}
+
+	renderedYaml, err := yamlx.MarshalV3(rendered)
+	if err != nil {
+		return fmt.Errorf("error while marshalling config file: %w", err)
+	}
+
+	if EncryptionKey != "" {
+		renderedYaml, err = encrypt(renderedYaml, EncryptionKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	logrus.Info("Saving furyctl configuration file in the cluster...")
+
+	if err := s.applySecret(configSecretName, map[string][]byte{
+		"config":   x,
+		"rendered": renderedYaml,
+	}); err != nil {
+		return fmt.Errorf("error while saving furyctl configuration file in the cluster: %w", err)
+	}
+
+	return nil
+}
+
+func (s *KubeStore) GetConfig() ([]byte, error) {
+	return s.getBaseConfig("config")
+}
+
+func (s *KubeStore) GetRenderedConfig() ([]byte, error) {
+	return s.getBaseConfig("rendered")
+}
+
+func (s *KubeStore) getBaseConfig(key string) ([]byte, error) {
+	secret, err := s.Client.CoreV1().Secrets(stateNamespace).Get(context.Background(), configSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error while getting current cluster config: %w", err)
+	}
+
+	data, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("error while getting current cluster config: %w: %s", ErrMissingStateKey, key)
+	}
+
+	if isEncrypted(data) {
+		return decrypt(data, EncryptionKey)
+	}
+
+	return data, nil
+}
+
+func (s *KubeStore) applySecret(name string, data map[string][]byte) error {
+	secret := corev1ac.Secret(name, stateNamespace).
+		WithType(corev1.SecretTypeOpaque).
+		WithData(data)
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		_, err := s.Client.CoreV1().Secrets(stateNamespace).Apply(context.Background(), secret, metav1.ApplyOptions{
+			FieldManager: kubex.FieldManager,
+			Force:        true,
+		})
+
+		return err //nolint:wrapcheck // Wrapped below, the retry needs the API error.
+	}); err != nil {
+		return fmt.Errorf("error while applying secret %s: %w", name, err)
+	}
+
+	return nil
+}
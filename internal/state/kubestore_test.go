@@ -0,0 +1,72 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package state_test
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/sighupio/furyctl/internal/state"
+)
+
+func TestKubeStore_StoreKFD(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewClientset()
+	store := state.NewKubeStore("test_data", "", client)
+
+	require.NoError(t, store.StoreKFD())
+
+	secret, err := client.CoreV1().Secrets("kube-system").Get(context.Background(), "furyctl-kfd", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	want, err := os.ReadFile(path.Join("test_data", "kfd.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, want, secret.Data["kfd"])
+}
+
+func TestKubeStore_StoreConfig(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewClientset()
+	store := state.NewKubeStore("", path.Join("test_data", "furyctl.yaml"), client)
+
+	require.NoError(t, store.StoreConfig(map[string]any{"kind": "OnPremises"}))
+
+	// Storing again updates the secret applied before.
+	require.NoError(t, store.StoreConfig(map[string]any{"kind": "EKSCluster"}))
+
+	cfg, err := store.GetConfig()
+	require.NoError(t, err)
+
+	want, err := os.ReadFile(path.Join("test_data", "furyctl.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, want, cfg)
+
+	rendered, err := store.GetRenderedConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, "kind: EKSCluster\n", string(rendered))
+}
+
+func TestKubeStore_GetConfig_Missing(t *testing.T) {
+	t.Parallel()
+
+	store := state.NewKubeStore("", "", fake.NewClientset())
+
+	_, err := store.GetConfig()
+	require.Error(t, err)
+}
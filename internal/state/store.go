@@ -9,9 +9,12 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sync"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
 
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/tool/kubectl"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
 	iox "github.com/sighupio/furyctl/internal/x/io"
@@ -26,6 +29,7 @@ type Storer interface {
 	GetRenderedConfig() ([]byte, error)
 }
 
+// Store saves the state in the cluster with kubectl, through manifests written to the work directory.
 type Store struct {
 	DistroPath    string
 	ConfigPath    string
@@ -33,9 +37,23 @@ type Store struct {
 	KubectlRunner *kubectl.Runner
 }
 
-func NewStore(distroPath, configPath, workDir, kubectlVersion, binPath string) *Store {
+// NewStore returns a store saving the state in the cluster through the Kubernetes API, falling back to kubectl
+// when no client can be created from the kubeconfig. The client is created on use and again whenever KUBECONFIG
+// changes, as the kubeconfig of a cluster may not exist yet, or not be selected yet, when the store is created.
+func NewStore(distroPath, configPath, workDir, kubectlVersion, binPath string) Storer {
+	return &fallbackStore{
+		newClient: kubex.NewClient,
+		newStore: func(client kubernetes.Interface) Storer {
+			return NewKubeStore(distroPath, configPath, client)
+		},
+		fallback: NewKubectlStore(distroPath, configPath, workDir, kubectlVersion, binPath),
+	}
+}
+
+// NewKubectlStore returns a store saving the state in the cluster with kubectl.
+func NewKubectlStore(distroPath, configPath, workDir, kubectlVersion, binPath string) *Store {
 	runner := kubectl.NewRunner(execx.NewStdExecutor(), kubectl.Paths{
-		Kubectl: path.Join(binPath, "kubectl", kubectlVersion, "kubectl"),
+		Kubectl: path.Join(platform.ToolPath(binPath, "kubectl", kubectlVersion), "kubectl"),
 		WorkDir: workDir,
 	}, true, true, false)
 
@@ -90,6 +108,13 @@ func (s *Store) StoreConfig(rendered map[string]any) error {
 		return fmt.Errorf("error while marshalling config file: %w", err)
 	}
 
+	if EncryptionKey != "" {
+		renderedYaml, err = encrypt(renderedYaml, EncryptionKey)
+		if err != nil {
+			return err
+		}
+	}
+
 	data := map[string]string{
 		"config":   base64.StdEncoding.EncodeToString(x),
 		"rendered": base64.StdEncoding.EncodeToString(renderedYaml),
@@ -152,5 +177,80 @@ func (s *Store) getBaseConfig(key string) ([]byte, error) {
 		return nil, fmt.Errorf("error while decoding current cluster config: %w", err)
 	}
 
+	if isEncrypted(decodedConfig) {
+		return decrypt(decodedConfig, EncryptionKey)
+	}
+
 	return decodedConfig, nil
 }
+
+type fallbackStore struct {
+	newClient func() (kubernetes.Interface, error)
+	newStore  func(client kubernetes.Interface) Storer
+	fallback  Storer
+
+	mu         sync.Mutex
+	kubeconfig string
+	storer     Storer
+}
+
+// get returns the store for the kubeconfig currently set in KUBECONFIG, creating it again when the variable changes,
+// e.g. when the preflight phase points it to the kubeconfig of the cluster.
+func (s *fallbackStore) get() Storer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kubeconfig := os.Getenv("KUBECONFIG")
+	if s.storer != nil && kubeconfig == s.kubeconfig {
+		return s.storer
+	}
+
+	s.kubeconfig = kubeconfig
+
+	client, err := s.newClient()
+	if err != nil {
+		logrus.Debugf("cannot create kubernetes client, using kubectl to store the state: %v", err)
+
+		s.storer = s.fallback
+
+		return s.storer
+	}
+
+	s.storer = s.newStore(client)
+
+	return s.storer
+}
+
+func (s *fallbackStore) StoreKFD() error {
+	if err := s.get().StoreKFD(); err != nil {
+		return fmt.Errorf("error while storing kfd manifest: %w", err)
+	}
+
+	return nil
+}
+
+func (s *fallbackStore) StoreConfig(rendered map[string]any) error {
+	if err := s.get().StoreConfig(rendered); err != nil {
+		return fmt.Errorf("error while storing configuration: %w", err)
+	}
+
+	return nil
+}
+
+func (s *fallbackStore) GetConfig() ([]byte, error) {
+	data, err := s.get().GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error while getting configuration: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *fallbackStore) GetRenderedConfig() ([]byte, error) {
+	data, err := s.get().GetRenderedConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error while getting rendered configuration: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ansible
+
+import "strings"
+
+// ParseListHosts parses the output of an ad-hoc command run with the --list-hosts flag, which prints a
+// "hosts (N):" header followed by one indented host per line.
+func ParseListHosts(out []byte) []string {
+	hosts := []string{}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "hosts (") || strings.HasPrefix(line, "[") {
+			continue
+		}
+
+		hosts = append(hosts, line)
+	}
+
+	return hosts
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package ansible_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sighupio/furyctl/internal/tool/ansible"
+)
+
+func TestParseListHosts(t *testing.T) {
+	t.Parallel()
+
+	out := "[WARNING]: some warning\n" +
+		"  hosts (3):\n" +
+		"    master1\n" +
+		"    worker1\n" +
+		"    haproxy1\n"
+
+	assert.Equal(t, []string{"master1", "worker1", "haproxy1"}, ansible.ParseListHosts([]byte(out)))
+	assert.Equal(t, []string{}, ansible.ParseListHosts([]byte("  hosts (0):\n")))
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ansible
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//nolint:gochecknoglobals // Compiled once.
+var onelineRegexp = regexp.MustCompile(`^(\S+) \| ([A-Z!]+) \| rc=(-?\d+) \| \(stdout\) (.*?)(?: \(stderr\) .*)?$`)
+
+// HostResult is the result of an ad-hoc command run on a host.
+type HostResult struct {
+	Host   string
+	Status string
+	RC     int
+	Stdout string
+}
+
+// ParseOneline parses the output of an ad-hoc shell or command module run with the --one-line flag, which prints
+// one line per host with the newlines of stdout escaped.
+func ParseOneline(out []byte) []HostResult {
+	results := []HostResult{}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		m := onelineRegexp.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			continue
+		}
+
+		rc, err := strconv.Atoi(m[3])
+		if err != nil {
+			continue
+		}
+
+		stdout := strings.NewReplacer(`\n`, "\n", `\r`, "\r").Replace(m[4])
+
+		results = append(results, HostResult{
+			Host:   m[1],
+			Status: m[2],
+			RC:     rc,
+			Stdout: stdout,
+		})
+	}
+
+	return results
+}
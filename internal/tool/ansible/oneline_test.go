@@ -0,0 +1,30 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package ansible_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sighupio/furyctl/internal/tool/ansible"
+)
+
+func TestParseOneline(t *testing.T) {
+	t.Parallel()
+
+	out := "master1 | CHANGED | rc=0 | (stdout) /etc/a.crt AAA\\n/etc/b.crt BBB\n" +
+		"etcd1 | FAILED | rc=2 | (stdout)  (stderr) permission denied\n" +
+		"[WARNING]: some warning\n"
+
+	want := []ansible.HostResult{
+		{Host: "master1", Status: "CHANGED", RC: 0, Stdout: "/etc/a.crt AAA\n/etc/b.crt BBB"},
+		{Host: "etcd1", Status: "FAILED", RC: 2, Stdout: ""},
+	}
+
+	assert.Equal(t, want, ansible.ParseOneline([]byte(out)))
+}
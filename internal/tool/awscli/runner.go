@@ -5,6 +5,7 @@
 package awscli
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -17,6 +18,13 @@ type Paths struct {
 	WorkDir string
 }
 
+// CallerIdentity is the AWS identity the credentials in use resolve to.
+type CallerIdentity struct {
+	Account string `json:"Account"`
+	Arn     string `json:"Arn"`
+	UserID  string `json:"UserId"`
+}
+
 type Runner struct {
 	executor execx.Executor
 	paths    Paths
@@ -131,6 +139,31 @@ func (r *Runner) Configure(sensitive bool, params ...string) (string, error) {
 	return out, nil
 }
 
+// CallerIdentity resolves the identity of the credentials found by the AWS credential chain, whatever their source:
+// environment variables, profiles, SSO, web identity or instance roles.
+func (r *Runner) CallerIdentity(region string) (CallerIdentity, error) {
+	var identity CallerIdentity
+
+	args := []string{"sts", "get-caller-identity", "--output", "json"}
+
+	if region != "" {
+		args = append(args, "--region", region)
+	}
+
+	cmd, id := r.newCmd(args, false)
+	defer r.deleteCmd(id)
+
+	if err := cmd.Run(); err != nil {
+		return identity, fmt.Errorf("error executing awscli sts get-caller-identity: %w", err)
+	}
+
+	if err := json.Unmarshal(cmd.Log.Out.Bytes(), &identity); err != nil {
+		return identity, fmt.Errorf("error parsing AWS caller identity: %w", err)
+	}
+
+	return identity, nil
+}
+
 func (r *Runner) Version() (string, error) {
 	args := []string{"--version"}
 
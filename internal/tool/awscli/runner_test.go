@@ -33,6 +33,28 @@ func Test_Runner_Version(t *testing.T) {
 	}
 }
 
+func Test_Runner_CallerIdentity(t *testing.T) {
+	r := awscli.NewRunner(execx.NewFakeExecutor("TestHelperProcess"), awscli.Paths{
+		Awscli:  "aws",
+		WorkDir: os.TempDir(),
+	})
+
+	got, err := r.CallerIdentity("eu-west-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := awscli.CallerIdentity{
+		Account: "123456789012",
+		Arn:     "arn:aws:iam::123456789012:user/furyctl",
+		UserID:  "AIDAEXAMPLE",
+	}
+
+	if got != want {
+		t.Errorf("expected identity '%+v', got '%+v'", want, got)
+	}
+}
+
 func TestHelperProcess(t *testing.T) {
 	args := os.Args
 
@@ -47,6 +69,11 @@ func TestHelperProcess(t *testing.T) {
 		switch subcmd {
 		case "--version":
 			fmt.Fprintf(os.Stdout, "v1.2.3")
+		case "sts":
+			fmt.Fprintf(
+				os.Stdout,
+				`{"UserId":"AIDAEXAMPLE","Account":"123456789012","Arn":"arn:aws:iam::123456789012:user/furyctl"}`,
+			)
 		default:
 			fmt.Fprintf(os.Stdout, "subcommand '%s' not found", subcmd)
 		}
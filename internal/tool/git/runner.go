@@ -5,13 +5,17 @@
 package git
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 
 	execx "github.com/sighupio/furyctl/internal/x/exec"
 )
 
+var ErrRefNotFound = errors.New("ref not found in remote repository")
+
 type Paths struct {
 	Git     string
 	WorkDir string
@@ -20,6 +24,7 @@ type Paths struct {
 type Runner struct {
 	executor execx.Executor
 	paths    Paths
+	env      []string
 	cmds     map[string]*execx.Cmd
 }
 
@@ -31,6 +36,13 @@ func NewRunner(executor execx.Executor, paths Paths) *Runner {
 	}
 }
 
+// WithEnv adds env to the environment of the git commands run by r.
+func (r *Runner) WithEnv(env []string) *Runner {
+	r.env = env
+
+	return r
+}
+
 func (r *Runner) CmdPath() string {
 	return r.paths.Git
 }
@@ -38,6 +50,7 @@ func (r *Runner) CmdPath() string {
 func (r *Runner) newCmd(args []string) (*execx.Cmd, string) {
 	cmd := execx.NewCmd(r.paths.Git, execx.CmdOptions{
 		Args:     args,
+		Env:      r.env,
 		Executor: r.executor,
 		WorkDir:  r.paths.WorkDir,
 	})
@@ -64,6 +77,58 @@ func (r *Runner) Version() (string, error) {
 	return out, nil
 }
 
+// RevParseHead returns the commit checked out in the working directory.
+func (r *Runner) RevParseHead() (string, error) {
+	cmd, id := r.newCmd([]string{"rev-parse", "HEAD"})
+	defer r.deleteCmd(id)
+
+	out, err := execx.CombinedOutput(cmd)
+	if err != nil {
+		return "", fmt.Errorf("error getting git commit: %w", err)
+	}
+
+	return strings.TrimSpace(out), nil
+}
+
+// LsRemote returns the commit the given ref points to in the remote repository, following annotated tags. The
+// default branch is used when ref is empty.
+func (r *Runner) LsRemote(repo, ref string) (string, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	cmd, id := r.newCmd([]string{"ls-remote", "--", repo, ref, ref + "^{}"})
+	defer r.deleteCmd(id)
+
+	out, err := execx.CombinedOutput(cmd)
+	if err != nil {
+		return "", fmt.Errorf("error listing remote refs: %w", err)
+	}
+
+	commit := ""
+
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		sha, name, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+
+		if strings.HasSuffix(name, "^{}") {
+			return sha, nil
+		}
+
+		if commit == "" {
+			commit = sha
+		}
+	}
+
+	if commit == "" {
+		return "", fmt.Errorf("%w: %s", ErrRefNotFound, ref)
+	}
+
+	return commit, nil
+}
+
 func (r *Runner) Stop() error {
 	for _, cmd := range r.cmds {
 		if err := cmd.Stop(); err != nil {
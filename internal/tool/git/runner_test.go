@@ -33,6 +33,42 @@ func Test_Runner_Version(t *testing.T) {
 	}
 }
 
+func Test_Runner_RevParseHead(t *testing.T) {
+	r := git.NewRunner(execx.NewFakeExecutor("TestHelperProcess"), git.Paths{
+		Git:     "git",
+		WorkDir: os.TempDir(),
+	})
+
+	got, err := r.RevParseHead()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "0123456789abcdef0123456789abcdef01234567"
+
+	if got != want {
+		t.Errorf("expected commit '%s', got '%s'", want, got)
+	}
+}
+
+func Test_Runner_LsRemote(t *testing.T) {
+	r := git.NewRunner(execx.NewFakeExecutor("TestHelperProcess"), git.Paths{
+		Git:     "git",
+		WorkDir: os.TempDir(),
+	})
+
+	got, err := r.LsRemote("https://github.com/sighupio/fury-kubernetes-monitoring.git", "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "89abcdef0123456789abcdef0123456789abcdef"
+
+	if got != want {
+		t.Errorf("expected commit '%s', got '%s'", want, got)
+	}
+}
+
 func TestHelperProcess(t *testing.T) {
 	args := os.Args
 
@@ -47,6 +83,11 @@ func TestHelperProcess(t *testing.T) {
 		switch subcmd {
 		case "version":
 			fmt.Fprintf(os.Stdout, "git version 2.39.0")
+		case "rev-parse":
+			fmt.Fprintf(os.Stdout, "0123456789abcdef0123456789abcdef01234567\n")
+		case "ls-remote":
+			fmt.Fprintf(os.Stdout, "0123456789abcdef0123456789abcdef01234567\trefs/tags/v1.0.0\n")
+			fmt.Fprintf(os.Stdout, "89abcdef0123456789abcdef0123456789abcdef\trefs/tags/v1.0.0^{}\n")
 		default:
 			fmt.Fprintf(os.Stdout, "subcommand '%s' not found", subcmd)
 		}
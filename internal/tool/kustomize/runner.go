@@ -66,6 +66,19 @@ func (r *Runner) Version() (string, error) {
 	return out, nil
 }
 
+// Build returns the manifests built from the kustomization in the work directory. Resources outside of it are allowed,
+// as the rendered manifests reference the vendored modules.
+func (r *Runner) Build() ([]byte, error) {
+	cmd, id := r.newCmd([]string{"build", "--load-restrictor", "LoadRestrictionsNone", "."})
+	defer r.deleteCmd(id)
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error building kustomization: %w", err)
+	}
+
+	return cmd.Log.Out.Bytes(), nil
+}
+
 func (r *Runner) Stop() error {
 	for _, cmd := range r.cmds {
 		if err := cmd.Stop(); err != nil {
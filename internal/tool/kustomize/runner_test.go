@@ -33,6 +33,24 @@ func Test_Runner_Version(t *testing.T) {
 	}
 }
 
+func Test_Runner_Build(t *testing.T) {
+	r := kustomize.NewRunner(execx.NewFakeExecutor("TestHelperProcess"), kustomize.Paths{
+		Kustomize: "kustomize",
+		WorkDir:   os.TempDir(),
+	})
+
+	got, err := r.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "kind: Namespace\nmetadata:\n  name: monitoring\n"
+
+	if string(got) != want {
+		t.Errorf("expected manifests '%s', got '%s'", want, string(got))
+	}
+}
+
 func TestHelperProcess(t *testing.T) {
 	args := os.Args
 
@@ -47,6 +65,8 @@ func TestHelperProcess(t *testing.T) {
 		switch subcmd {
 		case "version":
 			fmt.Fprintf(os.Stdout, "v1.2.3")
+		case "build":
+			fmt.Fprintf(os.Stdout, "kind: Namespace\nmetadata:\n  name: monitoring\n")
 		default:
 			fmt.Fprintf(os.Stdout, "subcommand '%s' not found", subcmd)
 		}
@@ -7,6 +7,7 @@ package tool
 import (
 	"path/filepath"
 
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/tool/ansible"
 	"github.com/sighupio/furyctl/internal/tool/awscli"
 	"github.com/sighupio/furyctl/internal/tool/furyagent"
@@ -83,7 +84,7 @@ func (rf *RunnerFactory) Create(name Name, version, workDir string) Runner {
 
 	case Furyagent:
 		return furyagent.NewRunner(rf.executor, furyagent.Paths{
-			Furyagent: filepath.Join(rf.paths.Bin, string(name), version, string(name)),
+			Furyagent: filepath.Join(platform.ToolPath(rf.paths.Bin, string(name), version), string(name)),
 			WorkDir:   workDir,
 		})
 
@@ -103,7 +104,7 @@ func (rf *RunnerFactory) Create(name Name, version, workDir string) Runner {
 		return kubectl.NewRunner(
 			rf.executor,
 			kubectl.Paths{
-				Kubectl: filepath.Join(rf.paths.Bin, string(name), version, string(name)),
+				Kubectl: filepath.Join(platform.ToolPath(rf.paths.Bin, string(name), version), string(name)),
 				WorkDir: workDir,
 			},
 			false, true, true,
@@ -111,31 +112,31 @@ func (rf *RunnerFactory) Create(name Name, version, workDir string) Runner {
 
 	case Kustomize:
 		return kustomize.NewRunner(rf.executor, kustomize.Paths{
-			Kustomize: filepath.Join(rf.paths.Bin, string(name), version, string(name)),
+			Kustomize: filepath.Join(platform.ToolPath(rf.paths.Bin, string(name), version), string(name)),
 			WorkDir:   workDir,
 		})
 
 	case Openvpn:
 		return openvpn.NewRunner(rf.executor, openvpn.Paths{
-			Openvpn: filepath.Join(rf.paths.Bin, string(name), version, string(name)),
+			Openvpn: filepath.Join(platform.ToolPath(rf.paths.Bin, string(name), version), string(name)),
 			WorkDir: workDir,
 		})
 
 	case Terraform:
 		return terraform.NewRunner(rf.executor, terraform.Paths{
-			Terraform: filepath.Join(rf.paths.Bin, string(name), version, string(name)),
+			Terraform: filepath.Join(platform.ToolPath(rf.paths.Bin, string(name), version), string(name)),
 			WorkDir:   workDir,
 		})
 
 	case OpenTofu:
 		return terraform.NewRunner(rf.executor, terraform.Paths{
-			Terraform: filepath.Join(rf.paths.Bin, string(name), version, "tofu"),
+			Terraform: filepath.Join(platform.ToolPath(rf.paths.Bin, string(name), version), "tofu"),
 			WorkDir:   workDir,
 		})
 
 	case Yq:
 		return yq.NewRunner(rf.executor, yq.Paths{
-			Yq:      filepath.Join(rf.paths.Bin, string(name), version, string(name)),
+			Yq:      filepath.Join(platform.ToolPath(rf.paths.Bin, string(name), version), string(name)),
 			WorkDir: workDir,
 		})
 
@@ -147,13 +148,13 @@ func (rf *RunnerFactory) Create(name Name, version, workDir string) Runner {
 
 	case Helm:
 		return helm.NewRunner(rf.executor, helm.Paths{
-			Helm:    filepath.Join(rf.paths.Bin, string(name), version, string(name)),
+			Helm:    filepath.Join(platform.ToolPath(rf.paths.Bin, string(name), version), string(name)),
 			WorkDir: workDir,
 		})
 
 	case Helmfile:
 		return helmfile.NewRunner(rf.executor, helmfile.Paths{
-			Helmfile: filepath.Join(rf.paths.Bin, string(name), version, string(name)),
+			Helmfile: filepath.Join(platform.ToolPath(rf.paths.Bin, string(name), version), string(name)),
 			WorkDir:  workDir,
 		})
 
@@ -161,7 +162,7 @@ func (rf *RunnerFactory) Create(name Name, version, workDir string) Runner {
 		return kapp.NewRunner(
 			rf.executor,
 			kapp.Paths{
-				Kapp:    filepath.Join(rf.paths.Bin, string(name), version, string(name)),
+				Kapp:    filepath.Join(platform.ToolPath(rf.paths.Bin, string(name), version), string(name)),
 				WorkDir: workDir,
 			},
 			false,
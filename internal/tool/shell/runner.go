@@ -5,6 +5,7 @@
 package shell
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -35,9 +36,10 @@ func (r *Runner) CmdPath() string {
 	return r.paths.Shell
 }
 
-func (r *Runner) newCmd(args []string) (*execx.Cmd, string) {
+func (r *Runner) newCmd(ctx context.Context, args []string) (*execx.Cmd, string) {
 	cmd := execx.NewCmd(r.paths.Shell, execx.CmdOptions{
 		Args:     args,
+		Context:  ctx,
 		Executor: r.executor,
 		WorkDir:  r.paths.WorkDir,
 	})
@@ -57,7 +59,12 @@ func (*Runner) Version() (string, error) {
 }
 
 func (r *Runner) Run(args ...string) (string, error) {
-	cmd, id := r.newCmd(args)
+	return r.RunContext(context.Background(), args...)
+}
+
+// RunContext runs the shell like Run, stopping it when ctx is canceled.
+func (r *Runner) RunContext(ctx context.Context, args ...string) (string, error) {
+	cmd, id := r.newCmd(ctx, args)
 	defer r.deleteCmd(id)
 
 	out, err := execx.CombinedOutput(cmd)
@@ -5,10 +5,12 @@
 package terraform
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/google/uuid"
 	tfjson "github.com/hashicorp/terraform-json"
@@ -45,11 +47,14 @@ func (r *Runner) CmdPath() string {
 	return r.paths.Terraform
 }
 
-func (r *Runner) newCmd(args []string) (*execx.Cmd, string) {
+// newCmd creates a terraform command. The output of sensitive commands, such as the ones printing the state, is
+// neither logged nor printed and must be read with sensitiveOutput.
+func (r *Runner) newCmd(args []string, sensitive bool) (*execx.Cmd, string) {
 	cmd := execx.NewCmd(r.paths.Terraform, execx.CmdOptions{
-		Args:     args,
-		Executor: r.executor,
-		WorkDir:  r.paths.WorkDir,
+		Args:      args,
+		Executor:  r.executor,
+		WorkDir:   r.paths.WorkDir,
+		Sensitive: sensitive,
 	})
 
 	id := uuid.NewString()
@@ -69,7 +74,7 @@ func (r *Runner) Init() error {
 		args = append(args, "-no-color")
 	}
 
-	cmd, id := r.newCmd(args)
+	cmd, id := r.newCmd(args, false)
 	defer r.deleteCmd(id)
 
 	if err := cmd.Run(); err != nil {
@@ -89,7 +94,7 @@ func (r *Runner) Plan(timestamp int64, params ...string) ([]byte, error) {
 
 	args = append(args, "-no-color", "-out", "plan/terraform.plan")
 
-	cmd, id := r.newCmd(args)
+	cmd, id := r.newCmd(args, false)
 	defer r.deleteCmd(id)
 
 	if err := cmd.Run(); err != nil {
@@ -110,7 +115,7 @@ func (r *Runner) Plan(timestamp int64, params ...string) ([]byte, error) {
 }
 
 func (r *Runner) Apply(timestamp int64) error {
-	cmd, applyID := r.newCmd([]string{"apply", "-no-color", "-json", "plan/terraform.plan"})
+	cmd, applyID := r.newCmd([]string{"apply", "-no-color", "-json", "plan/terraform.plan"}, false)
 	defer r.deleteCmd(applyID)
 
 	if err := cmd.Run(); err != nil {
@@ -131,7 +136,7 @@ func (r *Runner) Apply(timestamp int64) error {
 func (r *Runner) Output() (OutputJSON, error) {
 	var oj OutputJSON
 
-	cmd, outputID := r.newCmd([]string{"output", "-json"})
+	cmd, outputID := r.newCmd([]string{"output", "-json"}, false)
 	defer r.deleteCmd(outputID)
 
 	if err := cmd.Run(); err != nil {
@@ -153,8 +158,32 @@ func (r *Runner) Output() (OutputJSON, error) {
 	return oj, nil
 }
 
+// ShowPlan returns the plan saved by the last Plan call, as described by terraform show -json. The plan holds the
+// prior state with its sensitive values, so it is not logged.
+func (r *Runner) ShowPlan() (*tfjson.Plan, error) {
+	cmd, id := r.newCmd([]string{"show", "-json", "plan/terraform.plan"}, true)
+	defer r.deleteCmd(id)
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("cannot show terraform plan: %w", err)
+	}
+
+	out, err := sensitiveOutput(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan tfjson.Plan
+
+	if err := json.Unmarshal(out, &plan); err != nil {
+		return nil, fmt.Errorf("error unmarshalling terraform plan: %w", err)
+	}
+
+	return &plan, nil
+}
+
 func (r *Runner) State(params ...string) (string, error) {
-	cmd, outputID := r.newCmd(append([]string{"state"}, params...))
+	cmd, outputID := r.newCmd(append([]string{"state"}, params...), false)
 
 	defer r.deleteCmd(outputID)
 
@@ -165,6 +194,36 @@ func (r *Runner) State(params ...string) (string, error) {
 	return cmd.Log.Out.String(), nil
 }
 
+// StateList returns the addresses of the resources in the state, limited to the given ones and the modules they
+// contain when any is given. The addresses are not logged, as they may name sensitive resources.
+func (r *Runner) StateList(addresses ...string) ([]string, error) {
+	cmd, id := r.newCmd(append([]string{"state", "list"}, addresses...), true)
+	defer r.deleteCmd(id)
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("cannot list terraform state: %w", err)
+	}
+
+	out, err := sensitiveOutput(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(string(out)), nil
+}
+
+// StatePull returns the state of the project. The state holds the sensitive values in clear, so it is not logged.
+func (r *Runner) StatePull() ([]byte, error) {
+	cmd, id := r.newCmd([]string{"state", "pull"}, true)
+	defer r.deleteCmd(id)
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("cannot pull terraform state: %w", err)
+	}
+
+	return sensitiveOutput(cmd)
+}
+
 func (r *Runner) Destroy() error {
 	args := []string{"destroy", "-auto-approve"}
 
@@ -172,7 +231,7 @@ func (r *Runner) Destroy() error {
 		args = append(args, "-no-color")
 	}
 
-	cmd, id := r.newCmd(args)
+	cmd, id := r.newCmd(args, false)
 	defer r.deleteCmd(id)
 
 	if err := cmd.Run(); err != nil {
@@ -185,7 +244,7 @@ func (r *Runner) Destroy() error {
 func (r *Runner) Version() (string, error) {
 	args := []string{"version"}
 
-	cmd, id := r.newCmd(args)
+	cmd, id := r.newCmd(args, false)
 	defer r.deleteCmd(id)
 
 	log, err := execx.CombinedOutput(cmd)
@@ -196,6 +255,15 @@ func (r *Runner) Version() (string, error) {
 	return log, nil
 }
 
+func sensitiveOutput(cmd *execx.Cmd) ([]byte, error) {
+	out, ok := cmd.Stdout.(*bytes.Buffer)
+	if !ok {
+		return nil, execx.ErrCastingToBuffer
+	}
+
+	return out.Bytes(), nil
+}
+
 func (r *Runner) Stop() error {
 	for _, cmd := range r.cmds {
 		if err := cmd.Stop(); err != nil {
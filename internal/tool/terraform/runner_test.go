@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/sighupio/furyctl/internal/test"
@@ -114,6 +115,64 @@ func Test_Runner_Output(t *testing.T) {
 	}
 }
 
+func Test_Runner_ShowPlan(t *testing.T) {
+	r := terraform.NewRunner(execx.NewFakeExecutor("TestHelperProcess"), terraform.Paths{
+		Terraform: "terraform",
+		WorkDir:   test.MkdirTemp(t),
+	})
+
+	plan, err := r.ShowPlan()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(plan.ResourceChanges) != 1 {
+		t.Fatalf("expected 1 resource change, got %d", len(plan.ResourceChanges))
+	}
+
+	rc := plan.ResourceChanges[0]
+
+	if rc.Address != "aws_vpc.this" || !rc.Change.Actions.Delete() {
+		t.Errorf("expected aws_vpc.this to be deleted, got %s %v", rc.Address, rc.Change.Actions)
+	}
+}
+
+func Test_Runner_StatePull(t *testing.T) {
+	r := terraform.NewRunner(execx.NewFakeExecutor("TestHelperProcess"), terraform.Paths{
+		Terraform: "terraform",
+		WorkDir:   test.MkdirTemp(t),
+	})
+
+	got, err := r.StatePull()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"version":4,"serial":1}`
+
+	if string(got) != want {
+		t.Errorf("expected state '%s', got '%s'", want, got)
+	}
+}
+
+func Test_Runner_StateList(t *testing.T) {
+	r := terraform.NewRunner(execx.NewFakeExecutor("TestHelperProcess"), terraform.Paths{
+		Terraform: "terraform",
+		WorkDir:   test.MkdirTemp(t),
+	})
+
+	got, err := r.StateList("module.vpn")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"module.vpn[0].aws_eip.vpn[0]", "module.vpn[0].aws_instance.vpn[0]"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("expected addresses %v, got %v", want, got)
+	}
+}
+
 func Test_Runner_Version(t *testing.T) {
 	r := terraform.NewRunner(execx.NewFakeExecutor("TestHelperProcess"), terraform.Paths{
 		Terraform: "terraform",
@@ -154,6 +213,17 @@ func TestHelperProcess(t *testing.T) {
 			fmt.Fprintf(os.Stdout, "v1.2.3")
 		case "output":
 			fmt.Fprintf(os.Stdout, `{"outputs":{"foo":{"sensitive":false,"value":"bar"}}}`)
+		case "state":
+			if len(args) > 5 && args[5] == "list" {
+				fmt.Fprintf(os.Stdout, "module.vpn[0].aws_eip.vpn[0]\nmodule.vpn[0].aws_instance.vpn[0]\n")
+
+				break
+			}
+
+			fmt.Fprintf(os.Stdout, `{"version":4,"serial":1}`)
+		case "show":
+			fmt.Fprintf(os.Stdout, `{"format_version":"1.2","resource_changes":[{"address":"aws_vpc.this",`+
+				`"mode":"managed","type":"aws_vpc","name":"this","change":{"actions":["delete"]}}]}`)
 		default:
 			fmt.Fprintf(os.Stdout, "subcommand '%s' not found", subcmd)
 		}
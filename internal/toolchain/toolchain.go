@@ -0,0 +1,125 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package toolchain
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/sighupio/fury-distribution/pkg/apis/config"
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
+)
+
+var (
+	ErrUnknownTool      = errors.New("tool is not part of the distribution toolchain")
+	ErrToolNotInstalled = errors.New("tool is not installed, run `furyctl download dependencies` to download it")
+)
+
+// Toolchain is the set of tools furyctl downloads for a distribution version, as found in the bin folder.
+type Toolchain struct {
+	binPath string
+	// Paths of the binaries, by tool name.
+	bins map[string]string
+}
+
+// New returns the toolchain pinned by the given distribution tools, downloaded in binPath. The binaries are
+// resolved as the cluster phases do, see cluster.NewOperationPhase.
+func New(kfdTools config.KFDTools, binPath string) *Toolchain {
+	t := &Toolchain{
+		binPath: binPath,
+		bins:    map[string]string{},
+	}
+
+	common := kfdTools.Common
+
+	t.add("furyagent", "furyagent", common.Furyagent.Version)
+	t.add("kubectl", "kubectl", common.Kubectl.Version)
+	t.add("kustomize", "kustomize", common.Kustomize.Version)
+	t.add("yq", "yq", common.Yq.Version)
+	t.add("helm", "helm", common.Helm.Version)
+	t.add("helmfile", "helmfile", common.Helmfile.Version)
+	t.add("kapp", "kapp", common.Kapp.Version)
+
+	// OpenTofu replaces Terraform when the distribution pins it, so it is available under both names.
+	if common.OpenTofu.Version != "" {
+		t.add("opentofu", "tofu", common.OpenTofu.Version)
+		t.bins["tofu"] = t.bins["opentofu"]
+		t.bins["terraform"] = t.bins["opentofu"]
+	} else {
+		t.add("terraform", "terraform", common.Terraform.Version)
+	}
+
+	return t
+}
+
+func (t *Toolchain) add(name, bin, version string) {
+	if version == "" {
+		return
+	}
+
+	t.bins[name] = filepath.Join(platform.ToolPath(t.binPath, name, version), bin)
+}
+
+// Names returns the names the tools of the toolchain can be referred to with.
+func (t *Toolchain) Names() []string {
+	names := make([]string, 0, len(t.bins))
+
+	for name := range t.bins {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Path returns the path of the binary of the given tool, failing if it has not been downloaded.
+func (t *Toolchain) Path(name string) (string, error) {
+	bin, ok := t.bins[name]
+	if !ok {
+		return "", fmt.Errorf("%w: '%s', valid tools are %s", ErrUnknownTool, name, strings.Join(t.Names(), ", "))
+	}
+
+	if _, err := os.Stat(bin); err != nil {
+		return "", fmt.Errorf("%s: %w", name, ErrToolNotInstalled)
+	}
+
+	return bin, nil
+}
+
+// Env returns the variables to add to the environment to run the tools of the toolchain as furyctl does: their
+// folders come first in PATH, the plugins of helm are looked for in the bin folder and kubeconfig, if not empty, is
+// the kubeconfig of the cluster.
+func (t *Toolchain) Env(kubeconfig string) []string {
+	dirs := []string{}
+
+	for _, bin := range t.bins {
+		dir := filepath.Dir(bin)
+		if !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	sort.Strings(dirs)
+
+	env := []string{
+		"PATH=" + strings.Join(append(dirs, os.Getenv("PATH")), string(os.PathListSeparator)),
+	}
+
+	if _, ok := t.bins["helm"]; ok {
+		env = append(env, "HELM_PLUGINS="+filepath.Join(t.binPath, "helm", "plugins"))
+	}
+
+	if kubeconfig != "" {
+		env = append(env, "KUBECONFIG="+kubeconfig)
+	}
+
+	return env
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package toolchain_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sighupio/fury-distribution/pkg/apis/config"
+	"github.com/sighupio/furyctl/internal/toolchain"
+)
+
+func TestToolchain_Path(t *testing.T) {
+	t.Parallel()
+
+	binPath := t.TempDir()
+
+	kubectl := filepath.Join(binPath, "kubectl", "1.30.0", "kubectl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(kubectl), 0o755))
+	require.NoError(t, os.WriteFile(kubectl, []byte{}, 0o755))
+
+	tc := toolchain.New(config.KFDTools{
+		Common: config.KFDToolsCommon{
+			Kubectl: config.KFDTool{Version: "1.30.0"},
+			Helm:    config.KFDTool{Version: "3.14.0"},
+		},
+	}, binPath)
+
+	got, err := tc.Path("kubectl")
+	require.NoError(t, err)
+	assert.Equal(t, kubectl, got)
+
+	_, err = tc.Path("helm")
+	require.ErrorIs(t, err, toolchain.ErrToolNotInstalled)
+
+	_, err = tc.Path("kapp")
+	require.ErrorIs(t, err, toolchain.ErrUnknownTool)
+}
+
+func TestToolchain_Names(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc  string
+		tools config.KFDToolsCommon
+		want  []string
+	}{
+		{
+			desc: "terraform",
+			tools: config.KFDToolsCommon{
+				Kubectl:   config.KFDTool{Version: "1.30.0"},
+				Terraform: config.KFDTool{Version: "1.4.6"},
+			},
+			want: []string{"kubectl", "terraform"},
+		},
+		{
+			desc: "opentofu replaces terraform",
+			tools: config.KFDToolsCommon{
+				Kubectl:   config.KFDTool{Version: "1.30.0"},
+				Terraform: config.KFDTool{Version: "1.4.6"},
+				OpenTofu:  config.KFDTool{Version: "1.9.0"},
+			},
+			want: []string{"kubectl", "opentofu", "terraform", "tofu"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tc := toolchain.New(config.KFDTools{Common: tC.tools}, "/bin")
+
+			assert.Equal(t, tC.want, tc.Names())
+		})
+	}
+}
+
+func TestToolchain_Env(t *testing.T) {
+	t.Parallel()
+
+	tc := toolchain.New(config.KFDTools{
+		Common: config.KFDToolsCommon{
+			Kubectl: config.KFDTool{Version: "1.30.0"},
+			Helm:    config.KFDTool{Version: "3.14.0"},
+		},
+	}, "/furyctl/bin")
+
+	env := tc.Env("/cluster/kubeconfig")
+
+	require.Len(t, env, 3)
+
+	path := strings.Split(strings.TrimPrefix(env[0], "PATH="), string(os.PathListSeparator))
+	assert.Equal(t, []string{"/furyctl/bin/helm/3.14.0", "/furyctl/bin/kubectl/1.30.0"}, path[:2])
+	assert.Equal(t, "HELM_PLUGINS=/furyctl/bin/helm/plugins", env[1])
+	assert.Equal(t, "KUBECONFIG=/cluster/kubeconfig", env[2])
+
+	assert.Len(t, tc.Env(""), 2)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+const caRotationConfigMapName = "furyctl-ca-rotation-state"
+
+// CARotationState tracks the steps of a CA rotation, the same way State tracks the phases of an upgrade, so that an
+// interrupted rotation can be resumed from the first step not completed.
+type CARotationState struct {
+	Steps CARotationSteps `yaml:"steps"`
+}
+
+type CARotationSteps struct {
+	GenerateCA            *Phase `yaml:"generateCA"`
+	DistributeTrustBundle *Phase `yaml:"distributeTrustBundle"`
+	ReissueCertificates   *Phase `yaml:"reissueCertificates"`
+	RemoveOldCA           *Phase `yaml:"removeOldCA"`
+}
+
+func NewCARotationState() *CARotationState {
+	return &CARotationState{
+		Steps: CARotationSteps{
+			GenerateCA:            &Phase{Status: PhaseStatusPending},
+			DistributeTrustBundle: &Phase{Status: PhaseStatusPending},
+			ReissueCertificates:   &Phase{Status: PhaseStatusPending},
+			RemoveOldCA:           &Phase{Status: PhaseStatusPending},
+		},
+	}
+}
+
+// CARotationStore saves the state of a CA rotation in the cluster through the Kubernetes API.
+type CARotationStore struct {
+	Client kubernetes.Interface
+}
+
+func NewCARotationStore(client kubernetes.Interface) *CARotationStore {
+	return &CARotationStore{
+		Client: client,
+	}
+}
+
+func (s *CARotationStore) Store(state *CARotationState) error {
+	x, err := yamlx.MarshalV3(state)
+	if err != nil {
+		return fmt.Errorf("error while marshalling CA rotation state: %w", err)
+	}
+
+	logrus.Info("Saving furyctl CA rotation state in the cluster...")
+
+	if err := applyStateConfigMap(s.Client, caRotationConfigMapName, x); err != nil {
+		return fmt.Errorf("error while saving furyctl CA rotation state in the cluster: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the state of the rotation in progress, or a new state when no rotation has been started.
+func (s *CARotationStore) Get() (*CARotationState, error) {
+	configMap, err := s.Client.CoreV1().ConfigMaps(stateNamespace).Get(
+		context.Background(),
+		caRotationConfigMapName,
+		metav1.GetOptions{},
+	)
+	if k8serrors.IsNotFound(err) {
+		return NewCARotationState(), nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error while getting current CA rotation state: %w", err)
+	}
+
+	data, ok := configMap.Data[stateConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("error while getting current CA rotation state: %w", ErrMissingStateKey)
+	}
+
+	state := NewCARotationState()
+
+	if err := yamlx.UnmarshalV3([]byte(data), state); err != nil {
+		return nil, fmt.Errorf("error while unmarshalling CA rotation state: %w", err)
+	}
+
+	return state, nil
+}
+
+func (s *CARotationStore) Delete() error {
+	err := s.Client.CoreV1().ConfigMaps(stateNamespace).Delete(
+		context.Background(),
+		caRotationConfigMapName,
+		metav1.DeleteOptions{},
+	)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("error while deleting current CA rotation state: %w", err)
+	}
+
+	return nil
+}
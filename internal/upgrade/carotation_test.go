@@ -0,0 +1,46 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package upgrade_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/sighupio/furyctl/internal/upgrade"
+)
+
+func TestCARotationStore(t *testing.T) {
+	t.Parallel()
+
+	store := upgrade.NewCARotationStore(fake.NewClientset())
+
+	// No rotation started yet.
+	state, err := store.Get()
+	require.NoError(t, err)
+	assert.Equal(t, upgrade.NewCARotationState(), state)
+
+	state.Steps.GenerateCA.Status = upgrade.PhaseStatusSuccess
+	state.Steps.DistributeTrustBundle.Status = upgrade.PhaseStatusFailed
+
+	require.NoError(t, store.Store(state))
+
+	got, err := store.Get()
+	require.NoError(t, err)
+	assert.Equal(t, state, got)
+
+	require.NoError(t, store.Delete())
+
+	got, err = store.Get()
+	require.NoError(t, err)
+	assert.Equal(t, upgrade.NewCARotationState(), got)
+
+	// Deleting a missing state is not an error.
+	require.NoError(t, store.Delete())
+}
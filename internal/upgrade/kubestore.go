@@ -0,0 +1,109 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package upgrade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	kubex "github.com/sighupio/furyctl/internal/x/kube"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+const (
+	stateNamespace     = "kube-system"
+	stateConfigMapName = "furyctl-upgrade-state"
+	stateConfigMapKey  = "state"
+)
+
+var ErrMissingStateKey = errors.New("key missing from the upgrade state stored in the cluster")
+
+// KubeStateStore saves the upgrade state in the cluster through the Kubernetes API, without writing it to disk.
+type KubeStateStore struct {
+	Client kubernetes.Interface
+}
+
+func NewKubeStateStore(client kubernetes.Interface) *KubeStateStore {
+	return &KubeStateStore{
+		Client: client,
+	}
+}
+
+func (s *KubeStateStore) Store(state *State) error {
+	x, err := yamlx.MarshalV3(state)
+	if err != nil {
+		return fmt.Errorf("error while marshalling upgrade state: %w", err)
+	}
+
+	logrus.Info("Saving furyctl upgrade state file in the cluster...")
+
+	if err := applyStateConfigMap(s.Client, stateConfigMapName, x); err != nil {
+		return fmt.Errorf("error while saving furyctl upgrade state file in the cluster: %w", err)
+	}
+
+	return nil
+}
+
+func (s *KubeStateStore) Get() ([]byte, error) {
+	configMap, err := s.Client.CoreV1().ConfigMaps(stateNamespace).Get(
+		context.Background(),
+		stateConfigMapName,
+		metav1.GetOptions{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting current cluster upgrade state: %w", err)
+	}
+
+	data, ok := configMap.Data[stateConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("error while getting current cluster upgrade state: %w", ErrMissingStateKey)
+	}
+
+	return []byte(data), nil
+}
+
+func (s *KubeStateStore) Delete() error {
+	err := s.Client.CoreV1().ConfigMaps(stateNamespace).Delete(
+		context.Background(),
+		stateConfigMapName,
+		metav1.DeleteOptions{},
+	)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("error while deleting current cluster upgrade state: %w", err)
+	}
+
+	return nil
+}
+
+func (*KubeStateStore) GetLatestResumablePhase(state *State) string {
+	return latestResumablePhase(state)
+}
+
+// applyStateConfigMap saves data in the state key of the ConfigMap name, with server-side apply.
+func applyStateConfigMap(client kubernetes.Interface, name string, data []byte) error {
+	configMap := corev1ac.ConfigMap(name, stateNamespace).
+		WithData(map[string]string{stateConfigMapKey: string(data)})
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		_, err := client.CoreV1().ConfigMaps(stateNamespace).Apply(context.Background(), configMap, metav1.ApplyOptions{
+			FieldManager: kubex.FieldManager,
+			Force:        true,
+		})
+
+		return err //nolint:wrapcheck // Wrapped below, the retry needs the API error.
+	}); err != nil {
+		return fmt.Errorf("error while applying ConfigMap %s: %w", name, err)
+	}
+
+	return nil
+}
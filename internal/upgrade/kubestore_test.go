@@ -0,0 +1,58 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package upgrade_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/upgrade"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+func TestKubeStateStore(t *testing.T) {
+	t.Parallel()
+
+	store := upgrade.NewKubeStateStore(fake.NewClientset())
+
+	_, err := store.Get()
+	require.Error(t, err)
+
+	state := &upgrade.State{
+		Phases: upgrade.Phases{
+			PreKubernetes: &upgrade.Phase{Status: upgrade.PhaseStatusSuccess},
+			Kubernetes:    &upgrade.Phase{Status: upgrade.PhaseStatusPending},
+		},
+	}
+
+	require.NoError(t, store.Store(state))
+
+	state.Phases.Kubernetes.Status = upgrade.PhaseStatusFailed
+
+	require.NoError(t, store.Store(state))
+
+	out, err := store.Get()
+	require.NoError(t, err)
+
+	got := &upgrade.State{}
+	require.NoError(t, yamlx.UnmarshalV3(out, got))
+
+	assert.Equal(t, state, got)
+	assert.Equal(t, cluster.OperationPhaseKubernetes, store.GetLatestResumablePhase(got))
+
+	require.NoError(t, store.Delete())
+
+	_, err = store.Get()
+	require.Error(t, err)
+
+	// Deleting a missing state is not an error.
+	require.NoError(t, store.Delete())
+}
@@ -9,10 +9,13 @@ import (
 	"os"
 	"path"
 	"reflect"
+	"sync"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/sighupio/furyctl/internal/cluster"
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
 	"github.com/sighupio/furyctl/internal/tool/kubectl"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
 	iox "github.com/sighupio/furyctl/internal/x/io"
@@ -42,6 +45,20 @@ type State struct {
 	Phases Phases `yaml:"phases"`
 }
 
+// MarkInterrupted marks the first phase that has not been completed as failed, as it has been interrupted.
+func (s *State) MarkInterrupted() {
+	for _, name := range cluster.GetPhasesOrder() {
+		phase, ok := reflect.ValueOf(s.Phases).FieldByName(name).Interface().(*Phase)
+		if !ok || phase == nil || phase.Status == PhaseStatusSuccess {
+			continue
+		}
+
+		phase.Status = PhaseStatusFailed
+
+		return
+	}
+}
+
 type Storer interface {
 	Store(state *State) error
 	Get() ([]byte, error)
@@ -49,6 +66,7 @@ type Storer interface {
 	GetLatestResumablePhase(state *State) string
 }
 
+// StateStore saves the upgrade state in the cluster with kubectl, through manifests written to the work directory.
 type StateStore struct {
 	WorkDir       string
 	KubectlRunner *kubectl.Runner
@@ -60,9 +78,19 @@ const (
 	PhaseStatusPending PhaseStatus = "pending"
 )
 
-func NewStateStore(workDir, kubectlVersion, binPath string) *StateStore {
+// NewStateStore returns a store saving the upgrade state in the cluster through the Kubernetes API, falling back to
+// kubectl when no client can be created from the kubeconfig, which is loaded again whenever KUBECONFIG changes.
+func NewStateStore(workDir, kubectlVersion, binPath string) Storer {
+	return &fallbackStateStore{
+		newClient: kubex.NewClient,
+		fallback:  NewKubectlStateStore(workDir, kubectlVersion, binPath),
+	}
+}
+
+// NewKubectlStateStore returns a store saving the upgrade state in the cluster with kubectl.
+func NewKubectlStateStore(workDir, kubectlVersion, binPath string) *StateStore {
 	runner := kubectl.NewRunner(execx.NewStdExecutor(), kubectl.Paths{
-		Kubectl: path.Join(binPath, "kubectl", kubectlVersion, "kubectl"),
+		Kubectl: path.Join(platform.ToolPath(binPath, "kubectl", kubectlVersion), "kubectl"),
 		WorkDir: workDir,
 	}, true, true, false)
 
@@ -134,6 +162,10 @@ func (s *StateStore) Delete() error {
 }
 
 func (*StateStore) GetLatestResumablePhase(state *State) string {
+	return latestResumablePhase(state)
+}
+
+func latestResumablePhase(state *State) string {
 	for _, phase := range cluster.GetPhasesOrder() {
 		reflectedPhase := reflect.ValueOf(state.Phases).FieldByName(phase)
 
@@ -150,3 +182,68 @@ func (*StateStore) GetLatestResumablePhase(state *State) string {
 
 	return ""
 }
+
+type fallbackStateStore struct {
+	newClient func() (kubernetes.Interface, error)
+	fallback  Storer
+
+	mu         sync.Mutex
+	kubeconfig string
+	storer     Storer
+}
+
+// get returns the store for the kubeconfig currently set in KUBECONFIG, creating it again when the variable changes,
+// e.g. when the preflight phase points it to the kubeconfig of the cluster.
+func (s *fallbackStateStore) get() Storer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kubeconfig := os.Getenv("KUBECONFIG")
+	if s.storer != nil && kubeconfig == s.kubeconfig {
+		return s.storer
+	}
+
+	s.kubeconfig = kubeconfig
+
+	client, err := s.newClient()
+	if err != nil {
+		logrus.Debugf("cannot create kubernetes client, using kubectl to store the upgrade state: %v", err)
+
+		s.storer = s.fallback
+
+		return s.storer
+	}
+
+	s.storer = NewKubeStateStore(client)
+
+	return s.storer
+}
+
+func (s *fallbackStateStore) Store(state *State) error {
+	if err := s.get().Store(state); err != nil {
+		return fmt.Errorf("error while storing upgrade state: %w", err)
+	}
+
+	return nil
+}
+
+func (s *fallbackStateStore) Get() ([]byte, error) {
+	data, err := s.get().Get()
+	if err != nil {
+		return nil, fmt.Errorf("error while getting upgrade state: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *fallbackStateStore) Delete() error {
+	if err := s.get().Delete(); err != nil {
+		return fmt.Errorf("error while deleting upgrade state: %w", err)
+	}
+
+	return nil
+}
+
+func (*fallbackStateStore) GetLatestResumablePhase(state *State) string {
+	return latestResumablePhase(state)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package upgrade_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sighupio/furyctl/internal/upgrade"
+)
+
+func TestState_MarkInterrupted(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc  string
+		state *upgrade.State
+		want  upgrade.Phases
+	}{
+		{
+			desc: "marks the first pending phase as failed",
+			state: &upgrade.State{
+				Phases: upgrade.Phases{
+					PreKubernetes:  &upgrade.Phase{Status: upgrade.PhaseStatusSuccess},
+					Kubernetes:     &upgrade.Phase{Status: upgrade.PhaseStatusPending},
+					PostKubernetes: &upgrade.Phase{Status: upgrade.PhaseStatusPending},
+				},
+			},
+			want: upgrade.Phases{
+				PreKubernetes:  &upgrade.Phase{Status: upgrade.PhaseStatusSuccess},
+				Kubernetes:     &upgrade.Phase{Status: upgrade.PhaseStatusFailed},
+				PostKubernetes: &upgrade.Phase{Status: upgrade.PhaseStatusPending},
+			},
+		},
+		{
+			desc: "leaves a completed state untouched",
+			state: &upgrade.State{
+				Phases: upgrade.Phases{
+					Distribution:     &upgrade.Phase{Status: upgrade.PhaseStatusSuccess},
+					PostDistribution: &upgrade.Phase{Status: upgrade.PhaseStatusSuccess},
+				},
+			},
+			want: upgrade.Phases{
+				Distribution:     &upgrade.Phase{Status: upgrade.PhaseStatusSuccess},
+				PostDistribution: &upgrade.Phase{Status: upgrade.PhaseStatusSuccess},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tc.state.MarkInterrupted()
+
+			assert.Equal(t, tc.want, tc.state.Phases)
+		})
+	}
+}
@@ -5,6 +5,7 @@
 package upgrade
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/sighupio/furyctl/internal/cluster"
@@ -13,7 +14,7 @@ import (
 type (
 	Reducers                          = any
 	ReducersOperatorPhase[T Reducers] interface {
-		Exec(reducers T, startFrom string, upgradeState *State) error
+		Exec(ctx context.Context, reducers T, startFrom string, upgradeState *State) error
 		SetUpgrade(upgradeEnabled bool)
 		Self() *cluster.OperationPhase
 	}
@@ -45,10 +46,19 @@ type ReducerOperatorPhaseDecorator[T Reducers] struct {
 	upgr   *Upgrade
 }
 
-func (d *ReducerOperatorPhaseDecorator[T]) Exec(reducers T, startFrom string, upgradeState *State) error {
-	fnErr := d.phase.Exec(reducers, startFrom, upgradeState)
+func (d *ReducerOperatorPhaseDecorator[T]) Exec(
+	ctx context.Context,
+	reducers T,
+	startFrom string,
+	upgradeState *State,
+) error {
+	fnErr := d.phase.Exec(ctx, reducers, startFrom, upgradeState)
 
 	if !d.dryRun && d.upgr.Enabled {
+		if ctx.Err() != nil {
+			upgradeState.MarkInterrupted()
+		}
+
 		if sErr := d.storer.Store(upgradeState); sErr != nil {
 			err := fmt.Errorf("error storing upgrade state: %w", sErr)
 
@@ -96,10 +106,14 @@ type ReducerOperatorPhaseAsyncDecorator[T Reducers] struct {
 	upgr   *Upgrade
 }
 
-func (d *ReducerOperatorPhaseAsyncDecorator[T]) Exec(reducers T, startFrom string, upgradeState *State) error { //nolint: lll // confusing-naming is a false positive
-	fnErr := d.phase.Exec(reducers, startFrom, upgradeState)
+func (d *ReducerOperatorPhaseAsyncDecorator[T]) Exec(ctx context.Context, reducers T, startFrom string, upgradeState *State) error { //nolint: lll // confusing-naming is a false positive
+	fnErr := d.phase.Exec(ctx, reducers, startFrom, upgradeState)
 
 	if !d.dryRun && d.upgr.Enabled {
+		if ctx.Err() != nil {
+			upgradeState.MarkInterrupted()
+		}
+
 		if sErr := d.storer.Store(upgradeState); sErr != nil {
 			err := fmt.Errorf("error storing upgrade state: %w", sErr)
 
@@ -135,7 +149,7 @@ func (d *ReducerOperatorPhaseAsyncDecorator[T]) Self() *cluster.OperationPhase {
 }
 
 type OperatorPhase interface {
-	Exec(startFrom string, upgradeState *State) error
+	Exec(ctx context.Context, startFrom string, upgradeState *State) error
 	Self() *cluster.OperationPhase
 	SetUpgrade(upgradeEnabled bool)
 }
@@ -166,10 +180,14 @@ type OperatorPhaseDecorator struct {
 	upgr   *Upgrade
 }
 
-func (d *OperatorPhaseDecorator) Exec(startFrom string, upgradeState *State) error {
-	fnErr := d.phase.Exec(startFrom, upgradeState)
+func (d *OperatorPhaseDecorator) Exec(ctx context.Context, startFrom string, upgradeState *State) error {
+	fnErr := d.phase.Exec(ctx, startFrom, upgradeState)
 
 	if !d.dryRun && d.upgr.Enabled {
+		if ctx.Err() != nil {
+			upgradeState.MarkInterrupted()
+		}
+
 		if sErr := d.storer.Store(upgradeState); sErr != nil {
 			err := fmt.Errorf("error storing upgrade state: %w", sErr)
 
@@ -217,10 +235,14 @@ func NewOperatorPhaseAsyncDecorator(
 	}
 }
 
-func (d *OperatorPhaseAsyncDecorator) Exec(startFrom string, upgradeState *State) error {
-	fnErr := d.phase.Exec(startFrom, upgradeState)
+func (d *OperatorPhaseAsyncDecorator) Exec(ctx context.Context, startFrom string, upgradeState *State) error {
+	fnErr := d.phase.Exec(ctx, startFrom, upgradeState)
 
 	if !d.dryRun && d.upgr.Enabled {
+		if ctx.Err() != nil {
+			upgradeState.MarkInterrupted()
+		}
+
 		if sErr := d.storer.Store(upgradeState); sErr != nil {
 			err := fmt.Errorf("error storing upgrade state: %w", sErr)
 
@@ -5,6 +5,7 @@
 package upgrade
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path"
@@ -26,11 +27,15 @@ type Upgrade struct {
 	To      string
 }
 
-func (u *Upgrade) Exec(workdir, phase string) error {
+func (u *Upgrade) Exec(ctx context.Context, workdir, phase string) error {
 	if !u.Enabled {
 		return nil
 	}
 
+	if err := cluster.Interrupted(ctx); err != nil {
+		return fmt.Errorf("error before running %s upgrade: %w", phase, err)
+	}
+
 	logrus.Infof(
 		"Running %s upgrade from %s to %s...",
 		phase,
@@ -68,7 +73,7 @@ func (u *Upgrade) Exec(workdir, phase string) error {
 		},
 	)
 
-	if _, err := shellRunner.Run(upgradeScript); err != nil {
+	if _, err := shellRunner.RunContext(ctx, upgradeScript); err != nil {
 		return fmt.Errorf("error running upgrade script: %w", err)
 	}
 
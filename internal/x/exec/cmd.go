@@ -15,6 +15,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sighupio/furyctl/internal/redact"
 	bytesx "github.com/sighupio/furyctl/internal/x/bytes"
 	iox "github.com/sighupio/furyctl/internal/x/io"
 )
@@ -25,6 +26,7 @@ var (
 	NoTTY              = false  //nolint:gochecknoglobals // This variable is shared between all the command instances.
 	ErrCmdFailed       = errors.New("command failed")
 	ErrCmdTimeout      = errors.New("command timed out")
+	ErrCmdCanceled     = errors.New("command canceled")
 	ErrCastingToBuffer = errors.New("error casting stdout to bytes.Buffer")
 )
 
@@ -48,6 +50,7 @@ func NewCmd(name string, opts CmdOptions) *Cmd {
 		stripColor := iox.WriterTransform{
 			W: LogFile,
 			Transforms: []bytesx.TransformFunc{
+				redact.Secrets.Bytes,
 				bytesx.StripColor,
 				bytesx.ToJSONLogFormat("debug", action),
 				bytesx.AppendNewLine,
@@ -71,8 +74,14 @@ func NewCmd(name string, opts CmdOptions) *Cmd {
 	}
 
 	if Debug || LogFile == nil {
-		outWriters = append(outWriters, iox.WriterTransform{W: os.Stdout})
-		errWriters = append(errWriters, iox.WriterTransform{W: os.Stderr})
+		outWriters = append(outWriters, iox.WriterTransform{
+			W:          os.Stdout,
+			Transforms: []bytesx.TransformFunc{redact.Secrets.Bytes},
+		})
+		errWriters = append(errWriters, iox.WriterTransform{
+			W:          os.Stderr,
+			Transforms: []bytesx.TransformFunc{redact.Secrets.Bytes},
+		})
 	}
 
 	coreCmd := opts.Executor.Command(name, opts.Args...)
@@ -80,11 +89,24 @@ func NewCmd(name string, opts CmdOptions) *Cmd {
 	coreCmd.Stderr = iox.MultiWriterTransform(errWriters...)
 	coreCmd.Dir = opts.WorkDir
 
+	if len(opts.Env) > 0 {
+		if coreCmd.Env == nil {
+			coreCmd.Env = os.Environ()
+		}
+
+		coreCmd.Env = append(coreCmd.Env, opts.Env...)
+	}
+
 	if opts.Sensitive {
 		coreCmd.Stdout = bytes.NewBufferString("")
 		coreCmd.Stderr = bytes.NewBufferString("")
 	}
 
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	return &Cmd{
 		Cmd: coreCmd,
 		Log: &CmdLog{
@@ -92,6 +114,7 @@ func NewCmd(name string, opts CmdOptions) *Cmd {
 			Err: errLog,
 		},
 		Sensitive: opts.Sensitive,
+		ctx:       ctx,
 	}
 }
 
@@ -99,10 +122,36 @@ type Cmd struct {
 	*exec.Cmd
 	Log       *CmdLog
 	Sensitive bool
+
+	ctx context.Context //nolint:containedctx // The command is bound to the context it has been created with.
 }
 
+// Run runs the command. When the context of the command is canceled, the command is not started, or it is asked
+// to stop with Stop if it is already running, so that it can exit gracefully. A running command is not signaled
+// again when the terminal already interrupted it, see SignaledByTerminal.
 func (c *Cmd) Run() error {
-	if err := c.Cmd.Run(); err != nil {
+	if err := c.ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrCmdCanceled, c.Path, context.Cause(c.ctx))
+	}
+
+	if err := c.Cmd.Start(); err != nil {
+		return NewErrCmdFailed(c.Path, c.Args, err, c.Log)
+	}
+
+	stop := context.AfterFunc(c.ctx, func() {
+		if SignaledByTerminal(c.ctx) {
+			return
+		}
+
+		_ = c.Stop() //nolint:errcheck // The command exits with an error anyway.
+	})
+	defer stop()
+
+	if err := c.Cmd.Wait(); err != nil {
+		if c.ctx.Err() != nil {
+			return fmt.Errorf("%w: %s: %w", ErrCmdCanceled, c.Path, context.Cause(c.ctx))
+		}
+
 		return NewErrCmdFailed(c.Path, c.Args, err, c.Log)
 	}
 
@@ -160,7 +209,10 @@ func (c *Cmd) RunWithTimeout(timeout time.Duration) error {
 }
 
 type CmdOptions struct {
-	Args      []string
+	Args    []string
+	Context context.Context //nolint:containedctx // Stops the command when canceled, see Cmd.Run.
+	// Env is added to the environment of the process for this command only.
+	Env       []string
 	Err       io.Writer
 	Executor  Executor
 	Out       io.Writer
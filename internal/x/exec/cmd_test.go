@@ -6,10 +6,12 @@ package execx_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"os"
 	"slices"
 	"testing"
+	"time"
 
 	execx "github.com/sighupio/furyctl/internal/x/exec"
 )
@@ -121,6 +123,33 @@ func Test_Cmd_Run(t *testing.T) {
 	}
 }
 
+func Test_Cmd_Run_Canceled(t *testing.T) {
+	t.Parallel()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := execx.NewCmd("true", execx.CmdOptions{Context: canceled}).Run()
+	if !errors.Is(err, execx.ErrCmdCanceled) {
+		t.Errorf("Cmd.Run() error = %v, want = %v", err, execx.ErrCmdCanceled)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+
+	err = execx.NewCmd("sleep", execx.CmdOptions{Args: []string{"60"}, Context: ctx}).Run()
+	if !errors.Is(err, execx.ErrCmdCanceled) {
+		t.Errorf("Cmd.Run() error = %v, want = %v", err, execx.ErrCmdCanceled)
+	}
+
+	if time.Since(start) > 10*time.Second {
+		t.Errorf("Cmd.Run() has not been stopped when the context has been canceled")
+	}
+}
+
 func Test_Cmd_Stop(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,41 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package execx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// SignalCause is the cause of a context canceled because furyctl received Signal, Err tells what was interrupted.
+type SignalCause struct {
+	Signal os.Signal
+	Err    error
+}
+
+func (s *SignalCause) Error() string {
+	return fmt.Sprintf("%v: %s", s.Err, s.Signal)
+}
+
+func (s *SignalCause) Unwrap() error {
+	return s.Err
+}
+
+// SignaledByTerminal reports whether ctx has been canceled by an interrupt coming from the terminal. The terminal
+// delivers it to the whole foreground process group, so the tools started by furyctl already received it: signaling
+// them again would make tools like terraform exit immediately instead of stopping gracefully.
+func SignaledByTerminal(ctx context.Context) bool {
+	var cause *SignalCause
+
+	if !errors.As(context.Cause(ctx), &cause) || cause.Signal != os.Interrupt {
+		return false
+	}
+
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package execx_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+
+	execx "github.com/sighupio/furyctl/internal/x/exec"
+)
+
+func TestSignalCause(t *testing.T) {
+	t.Parallel()
+
+	cause := &execx.SignalCause{Signal: os.Interrupt, Err: ErrTest}
+
+	if !errors.Is(cause, ErrTest) {
+		t.Errorf("SignalCause does not wrap %v", ErrTest)
+	}
+
+	if got, want := cause.Error(), "test error: interrupt"; got != want {
+		t.Errorf("SignalCause.Error() = %q, want = %q", got, want)
+	}
+}
+
+func TestSignaledByTerminal(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc  string
+		cause error
+	}{
+		{
+			desc: "not canceled",
+		},
+		{
+			desc:  "canceled without a signal",
+			cause: ErrTest,
+		},
+		{
+			desc:  "terminated",
+			cause: &execx.SignalCause{Signal: syscall.SIGTERM, Err: ErrTest},
+		},
+		{
+			// The standard input of the tests is not a terminal.
+			desc:  "interrupted without a terminal",
+			cause: &execx.SignalCause{Signal: os.Interrupt, Err: ErrTest},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancelCause(context.Background())
+			defer cancel(nil)
+
+			if tC.cause != nil {
+				cancel(tC.cause)
+			}
+
+			if execx.SignaledByTerminal(ctx) {
+				t.Errorf("SignaledByTerminal() = true, want = false")
+			}
+		})
+	}
+}
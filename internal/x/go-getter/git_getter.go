@@ -0,0 +1,227 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gogetterx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gogetter "github.com/hashicorp/go-getter"
+)
+
+var (
+	ErrGitNotFound       = errors.New("git must be available and on the PATH")
+	ErrGitSSHKey         = errors.New("the sshkey parameter is not supported by this git getter")
+	ErrGitCommandFailed  = errors.New("git command failed")
+	lsRemoteSymRefRegexp = regexp.MustCompile(`ref: refs/heads/([^\s]+).*`)
+)
+
+// GitGetter is a port of the go-getter git getter that runs git with Env added to the environment of the process,
+// e.g. to configure the credentials of a single repository without exposing them to the other processes.
+type GitGetter struct {
+	getter
+
+	Env []string
+}
+
+func (*GitGetter) ClientMode(_ *url.URL) (gogetter.ClientMode, error) {
+	return gogetter.ClientModeDir, nil
+}
+
+func (g *GitGetter) Get(dst string, u *url.URL) error {
+	ctx := g.Context()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		return ErrGitNotFound
+	}
+
+	var ref string
+
+	depth := 0
+
+	q := u.Query()
+	if len(q) > 0 {
+		if q.Get("sshkey") != "" {
+			return ErrGitSSHKey
+		}
+
+		ref = q.Get("ref")
+		q.Del("ref")
+
+		if n, err := strconv.Atoi(q.Get("depth")); err == nil {
+			depth = n
+		}
+
+		q.Del("depth")
+
+		newU := *u
+		u = &newU
+		u.RawQuery = q.Encode()
+	}
+
+	_, err := os.Stat(dst)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err == nil {
+		err = g.update(ctx, dst, u, ref, depth)
+	} else {
+		err = g.clone(ctx, dst, u, ref, depth)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if ref != "" {
+		if err := g.run(ctx, dst, "checkout", ref); err != nil {
+			return err
+		}
+	}
+
+	return g.fetchSubmodules(ctx, dst, depth)
+}
+
+// GetFile gets the whole repository in a temporary folder and copies the requested file from there.
+func (g *GitGetter) GetFile(dst string, u *url.URL) error {
+	td, err := os.MkdirTemp("", "getter")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(td)
+
+	newU := *u
+	newU.Path = filepath.Dir(u.Path)
+
+	repo := filepath.Join(td, "repo")
+
+	if err := g.Get(repo, &newU); err != nil {
+		return err
+	}
+
+	fg := &FileGetter{getter: g.getter, Copy: true}
+
+	return fg.GetFile(dst, &url.URL{Scheme: "file", Path: filepath.Join(repo, filepath.Base(u.Path))})
+}
+
+func (g *GitGetter) clone(ctx context.Context, dst string, u *url.URL, ref string, depth int) error {
+	args := []string{"clone"}
+
+	if depth > 0 {
+		branch := ref
+		if branch == "" {
+			branch = g.remoteDefaultBranch(ctx, u)
+		}
+
+		args = append(args, "--depth", strconv.Itoa(depth), "--branch", branch)
+	}
+
+	args = append(args, "--", u.String(), dst)
+
+	if err := g.run(ctx, "", args...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *GitGetter) update(ctx context.Context, dst string, u *url.URL, ref string, depth int) error {
+	files, err := os.ReadDir(dst)
+	if err != nil {
+		return fmt.Errorf("failed to read the destination directory %s during git update: %w", dst, err)
+	}
+
+	for _, f := range files {
+		if strings.EqualFold(f.Name(), ".git") && f.IsDir() {
+			if err := os.RemoveAll(filepath.Join(dst, f.Name())); err != nil {
+				return fmt.Errorf("failed to remove the .git directory in %s during git update: %w", dst, err)
+			}
+		}
+	}
+
+	pull := []string{"pull", "origin", "--ff-only", "--", ref}
+	if depth > 0 {
+		pull = []string{"pull", "origin", "--depth", strconv.Itoa(depth), "--ff-only", "--", ref}
+	}
+
+	for _, args := range [][]string{
+		{"init"},
+		{"remote", "add", "origin", "--", u.String()},
+		{"fetch", "--tags"},
+		{"fetch", "origin", "--", ref},
+		{"reset", "--hard", "FETCH_HEAD"},
+		{"checkout", ref},
+		pull,
+	} {
+		if err := g.run(ctx, dst, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *GitGetter) fetchSubmodules(ctx context.Context, dst string, depth int) error {
+	if g.client != nil {
+		g.client.DisableSymlinks = true
+	}
+
+	args := []string{"submodule", "update", "--init", "--recursive"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+
+	return g.run(ctx, dst, args...)
+}
+
+// remoteDefaultBranch returns the branch the HEAD of the remote repository points to, master if it cannot be found.
+func (g *GitGetter) remoteDefaultBranch(ctx context.Context, u *url.URL) string {
+	var out bytes.Buffer
+
+	cmd := g.command(ctx, "", "ls-remote", "--symref", "--", u.String(), "HEAD")
+	cmd.Stdout = &out
+
+	err := cmd.Run()
+
+	matches := lsRemoteSymRefRegexp.FindStringSubmatch(out.String())
+	if err != nil || matches == nil {
+		return "master"
+	}
+
+	return matches[len(matches)-1]
+}
+
+func (g *GitGetter) run(ctx context.Context, dir string, args ...string) error {
+	var out bytes.Buffer
+
+	cmd := g.command(ctx, dir, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: git %s: %w\n%s", ErrGitCommandFailed, args[0], err, out.String())
+	}
+
+	return nil
+}
+
+func (g *GitGetter) command(ctx context.Context, dir string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), g.Env...)
+
+	return cmd
+}
@@ -6,6 +6,8 @@ package iox
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -195,3 +197,54 @@ func EnsureDir(fileName string) error {
 
 	return nil
 }
+
+// HashDir returns the sha256 digest of the content of a directory, computed over the relative paths, the kind and
+// the content of its files, so that it changes whenever a file is added, removed or modified.
+func HashDir(dir string) (string, error) {
+	h := sha256.New()
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return fmt.Errorf("error while getting relative path of %s: %w", p, err)
+		}
+
+		switch {
+		case d.IsDir():
+			fmt.Fprintf(h, "d %s\n", filepath.ToSlash(rel))
+
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(p)
+			if err != nil {
+				return fmt.Errorf("error while reading link %s: %w", p, err)
+			}
+
+			fmt.Fprintf(h, "l %s %s\n", filepath.ToSlash(rel), target)
+
+		default:
+			fmt.Fprintf(h, "f %s\n", filepath.ToSlash(rel))
+
+			f, err := os.Open(p)
+			if err != nil {
+				return fmt.Errorf("error while opening file %s: %w", p, err)
+			}
+
+			defer f.Close()
+
+			if _, err := io.Copy(h, f); err != nil {
+				return fmt.Errorf("error while reading file %s: %w", p, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error while hashing directory %s: %w", dir, err)
+	}
+
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
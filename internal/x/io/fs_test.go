@@ -461,3 +461,48 @@ func TestEnsureDir(t *testing.T) {
 		})
 	}
 }
+
+func TestHashDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(dir, "bin"), 0o755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "bin", "tool"), []byte("tool"), 0o755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	h1, err := iox.HashDir(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	h2, err := iox.HashDir(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if h1 != h2 {
+		t.Errorf("expected the same digest for the same content, got %s and %s", h1, h2)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "bin", "tool"), []byte("tampered"), 0o755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	h3, err := iox.HashDir(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if h1 == h3 {
+		t.Errorf("expected a different digest after changing a file, got %s", h3)
+	}
+
+	if _, err := iox.HashDir(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("expected an error hashing a missing directory")
+	}
+}
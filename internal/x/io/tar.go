@@ -0,0 +1,138 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package iox
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ErrUnsafeTarEntry = errors.New("archive entry points outside of the destination folder")
+
+// WriteTar writes the folders and the regular files contained in dir to w as a tar archive, other kinds of files
+// are skipped. The archive is not closed, so that the caller can close the underlying compression or encryption.
+func WriteTar(w io.Writer, dir string) error {
+	tw := tar.NewWriter(w)
+
+	if err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == dir || (!d.IsDir() && !d.Type().IsRegular()) {
+			return nil
+		}
+
+		return addTarEntry(tw, dir, path, d)
+	}); err != nil {
+		return fmt.Errorf("error while archiving %s: %w", dir, err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("error while closing archive: %w", err)
+	}
+
+	return nil
+}
+
+func addTarEntry(tw *tar.Writer, dir, path string, d fs.DirEntry) error {
+	fi, err := d.Info()
+	if err != nil {
+		return fmt.Errorf("error while reading %s: %w", path, err)
+	}
+
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return fmt.Errorf("error while getting relative path of %s: %w", path, err)
+	}
+
+	hdr, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return fmt.Errorf("error while creating archive header for %s: %w", path, err)
+	}
+
+	hdr.Name = filepath.ToSlash(rel)
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("error while writing archive header for %s: %w", path, err)
+	}
+
+	if d.IsDir() {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error while opening %s: %w", path, err)
+	}
+
+	defer f.Close()
+
+	if _, err := io.Copy(tw, f); err != nil {
+		return fmt.Errorf("error while archiving %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// ExtractTar extracts the folders and the regular files of the tar archive read from r into dir. Entries pointing
+// outside of dir make the extraction fail.
+func ExtractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("error while reading archive: %w", err)
+		}
+
+		if err := extractTarEntry(tr, hdr, dir); err != nil {
+			return err
+		}
+	}
+}
+
+func extractTarEntry(tr *tar.Reader, hdr *tar.Header, dir string) error {
+	target := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+
+	if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+		return fmt.Errorf("%w: %s", ErrUnsafeTarEntry, hdr.Name)
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if err := os.MkdirAll(target, hdr.FileInfo().Mode().Perm()); err != nil {
+			return fmt.Errorf("error while creating folder %s: %w", target, err)
+		}
+
+	case tar.TypeReg:
+		if err := EnsureDir(target); err != nil {
+			return err
+		}
+
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, hdr.FileInfo().Mode().Perm())
+		if err != nil {
+			return fmt.Errorf("error while creating file %s: %w", target, err)
+		}
+
+		defer out.Close()
+
+		if _, err := io.Copy(out, tr); err != nil { //nolint:gosec // size is bounded by the archive itself.
+			return fmt.Errorf("error while writing file %s: %w", target, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kubex
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// FieldManager is the field manager of the resources applied by furyctl with server-side apply.
+const FieldManager = "furyctl"
+
+// NewClient creates a Kubernetes clientset from the kubeconfig in the KUBECONFIG environment variable, or from the
+// default locations, the same way kubectl does.
+func NewClient() (kubernetes.Interface, error) {
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{},
+	).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error while loading kubeconfig: %w", err)
+	}
+
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating kubernetes client: %w", err)
+	}
+
+	return client, nil
+}
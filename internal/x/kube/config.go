@@ -10,6 +10,7 @@ import (
 	"path"
 	"path/filepath"
 
+	"github.com/sighupio/furyctl/internal/atrest"
 	iox "github.com/sighupio/furyctl/internal/x/io"
 )
 
@@ -22,12 +23,20 @@ func CreateConfig(data []byte, p string) (string, error) {
 	return path.Join(p, "kubeconfig"), nil
 }
 
+// SetConfigEnv points KUBECONFIG to p, which is decrypted for the duration of the command when it has been encrypted
+// at rest by furyctl.
 func SetConfigEnv(p string) error {
 	kubePath, err := filepath.Abs(p)
 	if err != nil {
 		return fmt.Errorf("error getting kubeconfig absolute path: %w", err)
 	}
 
+	if _, err := os.Stat(kubePath + atrest.FileExt); err == nil {
+		if err := atrest.Default.ProtectFile(kubePath); err != nil {
+			return fmt.Errorf("error decrypting kubeconfig: %w", err)
+		}
+	}
+
 	err = os.Setenv("KUBECONFIG", kubePath)
 	if err != nil {
 		return fmt.Errorf("error setting kubeconfig env: %w", err)
@@ -36,6 +45,8 @@ func SetConfigEnv(p string) error {
 	return nil
 }
 
+// CopyToWorkDir copies the kubeconfig at p to the file n in the current directory, which is encrypted at rest when
+// the encryption is enabled.
 func CopyToWorkDir(p, n string) error {
 	currentDir, err := os.Getwd()
 	if err != nil {
@@ -52,6 +63,10 @@ func CopyToWorkDir(p, n string) error {
 		return fmt.Errorf("error reading file: %w", err)
 	}
 
+	if err := atrest.Default.ProtectFile(path.Join(currentDir, n)); err != nil {
+		return fmt.Errorf("error protecting file: %w", err)
+	}
+
 	err = iox.WriteFile(path.Join(currentDir, n), fileBytes)
 	if err != nil {
 		return fmt.Errorf("error writing file: %w", err)
@@ -0,0 +1,116 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kubex
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+var (
+	ErrKubeconfigNoContext = errors.New("kubeconfig has no current context to merge")
+	ErrContextNameEmpty    = errors.New("the name of the merged context must not be empty")
+)
+
+// MergedKubeconfig is the outcome of MergeKubeconfig.
+type MergedKubeconfig struct {
+	// Path is the kubeconfig the entries have been merged into.
+	Path string
+	// BackupPath is the copy of the previous content of Path, empty when the file did not exist.
+	BackupPath string
+	// Replaced is true when entries with the same name already existed and have been overwritten.
+	Replaced bool
+}
+
+// DefaultKubeconfigPath returns the kubeconfig kubectl writes to: the first path of the KUBECONFIG environment
+// variable, or ~/.kube/config.
+func DefaultKubeconfigPath() string {
+	for _, p := range filepath.SplitList(os.Getenv(clientcmd.RecommendedConfigPathEnvVar)) {
+		if p != "" {
+			return p
+		}
+	}
+
+	return clientcmd.RecommendedHomeFile
+}
+
+// MergeKubeconfig merges the current context of kubeconfig into the kubeconfig at target, renaming its cluster, user
+// and context to name so that they do not collide with the ones of other clusters. The previous content of target is
+// backed up next to it first.
+func MergeKubeconfig(kubeconfig []byte, target, name string, setCurrentContext bool) (MergedKubeconfig, error) {
+	res := MergedKubeconfig{Path: target}
+
+	if name == "" {
+		return res, ErrContextNameEmpty
+	}
+
+	src, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return res, fmt.Errorf("error while parsing kubeconfig: %w", err)
+	}
+
+	srcContextName := src.CurrentContext
+	if srcContextName == "" && len(src.Contexts) == 1 {
+		for n := range src.Contexts {
+			srcContextName = n
+		}
+	}
+
+	srcContext, ok := src.Contexts[srcContextName]
+	if !ok {
+		return res, ErrKubeconfigNoContext
+	}
+
+	dst := clientcmdapi.NewConfig()
+
+	previous, err := os.ReadFile(target)
+	if err == nil {
+		if dst, err = clientcmd.Load(previous); err != nil {
+			return res, fmt.Errorf("error while parsing kubeconfig %s: %w", target, err)
+		}
+
+		res.BackupPath = fmt.Sprintf("%s.%s.bak", target, time.Now().Format("20060102150405"))
+
+		if err := os.WriteFile(res.BackupPath, previous, 0o600); err != nil {
+			return res, fmt.Errorf("error while backing up kubeconfig %s: %w", target, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return res, fmt.Errorf("error while reading kubeconfig %s: %w", target, err)
+	}
+
+	_, clusterExists := dst.Clusters[name]
+	_, userExists := dst.AuthInfos[name]
+	_, contextExists := dst.Contexts[name]
+	res.Replaced = clusterExists || userExists || contextExists
+
+	if cluster, ok := src.Clusters[srcContext.Cluster]; ok {
+		dst.Clusters[name] = cluster
+	}
+
+	if user, ok := src.AuthInfos[srcContext.AuthInfo]; ok {
+		dst.AuthInfos[name] = user
+	}
+
+	mergedContext := *srcContext
+	mergedContext.Cluster = name
+	mergedContext.AuthInfo = name
+	dst.Contexts[name] = &mergedContext
+
+	if setCurrentContext || dst.CurrentContext == "" {
+		dst.CurrentContext = name
+	}
+
+	if err := clientcmd.WriteToFile(*dst, target); err != nil {
+		return res, fmt.Errorf("error while writing kubeconfig %s: %w", target, err)
+	}
+
+	return res, nil
+}
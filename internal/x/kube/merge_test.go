@@ -0,0 +1,116 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kubex_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	kubex "github.com/sighupio/furyctl/internal/x/kube"
+)
+
+func newKubeconfig(t *testing.T, server string) []byte {
+	t.Helper()
+
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters["kubernetes"] = &clientcmdapi.Cluster{Server: server}
+	cfg.AuthInfos["kubernetes-admin"] = &clientcmdapi.AuthInfo{Token: "token"}
+	cfg.Contexts["kubernetes-admin@kubernetes"] = &clientcmdapi.Context{
+		Cluster:  "kubernetes",
+		AuthInfo: "kubernetes-admin",
+	}
+	cfg.CurrentContext = "kubernetes-admin@kubernetes"
+
+	out, err := clientcmd.Write(*cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return out
+}
+
+func TestMergeKubeconfig(t *testing.T) {
+	t.Parallel()
+
+	target := filepath.Join(t.TempDir(), ".kube", "config")
+
+	// The first merge creates the file and sets the current context, as there is none.
+	res, err := kubex.MergeKubeconfig(newKubeconfig(t, "https://one:6443"), target, "one", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.BackupPath != "" || res.Replaced {
+		t.Fatalf("got %+v, want no backup and no replaced entries", res)
+	}
+
+	// Clusters with the same entry names do not collide once renamed.
+	res, err = kubex.MergeKubeconfig(newKubeconfig(t, "https://two:6443"), target, "two", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.BackupPath == "" {
+		t.Fatal("want a backup of the previous kubeconfig")
+	}
+
+	if _, err := os.Stat(res.BackupPath); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := clientcmd.LoadFromFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.CurrentContext != "one" {
+		t.Fatalf("got current context %s, want one", cfg.CurrentContext)
+	}
+
+	for name, server := range map[string]string{"one": "https://one:6443", "two": "https://two:6443"} {
+		if cfg.Clusters[name] == nil || cfg.Clusters[name].Server != server {
+			t.Fatalf("got cluster %s %+v, want server %s", name, cfg.Clusters[name], server)
+		}
+
+		if cfg.AuthInfos[name] == nil {
+			t.Fatalf("missing user %s", name)
+		}
+
+		if ctx := cfg.Contexts[name]; ctx == nil || ctx.Cluster != name || ctx.AuthInfo != name {
+			t.Fatalf("got context %s %+v", name, ctx)
+		}
+	}
+
+	// Merging the same cluster again replaces its entries.
+	res, err = kubex.MergeKubeconfig(newKubeconfig(t, "https://two:6443"), target, "two", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !res.Replaced {
+		t.Fatal("want replaced entries")
+	}
+
+	cfg, err = clientcmd.LoadFromFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.CurrentContext != "two" {
+		t.Fatalf("got current context %s, want two", cfg.CurrentContext)
+	}
+}
+
+func TestDefaultKubeconfigPath(t *testing.T) {
+	t.Setenv("KUBECONFIG", string(filepath.ListSeparator)+"/tmp/first"+string(filepath.ListSeparator)+"/tmp/second")
+
+	if got := kubex.DefaultKubeconfigPath(); got != "/tmp/first" {
+		t.Fatalf("got %s, want /tmp/first", got)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"os"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/sighupio/furyctl/internal/redact"
 )
 
 type LogFormat struct {
@@ -31,6 +33,11 @@ func (hook *formatterHook) Fire(entry *logrus.Entry) error {
 		return fmt.Errorf("error while formatting log entry: %w", err)
 	}
 
+	line, err = redact.Secrets.Bytes(line)
+	if err != nil {
+		return fmt.Errorf("error while redacting log entry: %w", err)
+	}
+
 	_, err = hook.Writer.Write(line)
 	if err != nil {
 		return fmt.Errorf("error while writing log entry: %w", err)
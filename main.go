@@ -24,6 +24,7 @@ import (
 
 	"github.com/sighupio/furyctl/cmd"
 	"github.com/sighupio/furyctl/internal/app"
+	"github.com/sighupio/furyctl/internal/atrest"
 )
 
 var (
@@ -77,9 +78,19 @@ func exec() int {
 
 	defer wg.Wait()
 
-	if _, err := cmd.NewRootCmd().ExecuteC(); err != nil {
+	_, err = cmd.NewRootCmd().ExecuteC()
+
+	// Encrypt back the sensitive files decrypted during the command, if any.
+	sealErr := atrest.Default.Seal()
+	if sealErr != nil {
+		log.Error(sealErr)
+	}
+
+	if err != nil {
 		log.Error(err)
+	}
 
+	if err != nil || sealErr != nil {
 		return 1
 	}
 
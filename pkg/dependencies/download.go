@@ -9,18 +9,22 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
-	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
+	"github.com/sighupio/furyctl/internal/dependencies/lock"
+	"github.com/sighupio/furyctl/internal/dependencies/platform"
+	"github.com/sighupio/furyctl/internal/dependencies/scheduler"
 	"github.com/sighupio/furyctl/internal/dependencies/tools"
 	"github.com/sighupio/furyctl/internal/distribution"
 	"github.com/sighupio/furyctl/internal/git"
+	"github.com/sighupio/furyctl/internal/mirror"
 	"github.com/sighupio/furyctl/internal/semver"
 	"github.com/sighupio/furyctl/internal/tool"
 	execx "github.com/sighupio/furyctl/internal/x/exec"
@@ -29,8 +33,6 @@ import (
 	netx "github.com/sighupio/furyctl/pkg/x/net"
 )
 
-const downloadsTimeout = 5 * time.Minute
-
 var (
 	ErrDownloadingModule  = errors.New("error downloading module")
 	ErrDownloadTimeout    = errors.New("timeout while downloading")
@@ -62,17 +64,36 @@ func NewDownloader(client netx.Client, basePath, binPath string, gitProtocol git
 }
 
 type Downloader struct {
-	client      netx.Client
-	toolFactory *tools.Factory
-	basePath    string
-	binPath     string
-	gitProtocol git.Protocol
+	client          netx.Client
+	toolFactory     *tools.Factory
+	basePath        string
+	binPath         string
+	gitProtocol     git.Protocol
+	pinnedChecksums tools.PinnedChecksums
+	lock            *lock.Lock
+	platforms       []platform.Platform
 }
 
-func (dd *Downloader) DownloadAll(kfd config.KFD) ([]error, []string) {
-	errs := []error{}
-	uts := []string{}
+// UsePlatforms downloads the tools for the given platforms instead of the current one, each one in its own folder
+// inside the bin folder, see platform.BinPath.
+func (dd *Downloader) UsePlatforms(ps []platform.Platform) {
+	dd.platforms = ps
+}
+
+// PinChecksums sets the digests pinned in the distribution, they take precedence over the published checksums.
+func (dd *Downloader) PinChecksums(pcs tools.PinnedChecksums) {
+	dd.pinnedChecksums = pcs
+}
 
+// UseLock pins the modules and the installers to the commits recorded in l, and checks every downloaded dependency
+// against it.
+func (dd *Downloader) UseLock(l *lock.Lock) {
+	dd.lock = l
+}
+
+// DownloadAll downloads all the dependencies of the distribution, stopping the downloads in progress when ctx is
+// canceled, and returns the tools that furyctl cannot download.
+func (dd *Downloader) DownloadAll(ctx context.Context, kfd config.KFD) ([]error, []string) {
 	vendorFolder := filepath.Join(dd.basePath, "vendor")
 
 	logrus.Debug("Cleaning vendor folder ", vendorFolder)
@@ -90,354 +111,461 @@ func (dd *Downloader) DownloadAll(kfd config.KFD) ([]error, []string) {
 		return []error{err}, nil
 	}
 
-	utsCh := make(chan string)
-	errCh := make(chan error)
-	doneCh := make(chan bool)
-
-	go func() {
-		if err := dd.DownloadModules(kfd, gitPrefix); err != nil {
-			errCh <- err
-		}
-
-		doneCh <- true
-	}()
+	modTasks, err := dd.moduleTasks(kfd, gitPrefix)
+	if err != nil {
+		return []error{err}, nil
+	}
 
-	go func() {
-		if err := dd.DownloadInstallers(kfd.Kubernetes, gitPrefix); err != nil {
-			errCh <- err
-		}
+	instTasks, err := dd.installerTasks(kfd, gitPrefix)
+	if err != nil {
+		return []error{err}, nil
+	}
 
-		doneCh <- true
-	}()
+	toolTasks, uts, err := dd.toolTasks(kfd)
+	if err != nil {
+		return []error{err}, uts
+	}
 
-	go func() {
-		uts, err := dd.DownloadTools(kfd)
-		if err != nil {
-			errCh <- err
+	// All the dependencies share the same workers.
+	tasks := append(append(modTasks, instTasks...), toolTasks...)
 
-			return
+	errs := scheduler.Run(ctx, scheduler.Default, tasks)
+	if len(errs) > 0 {
+		if errClear := dd.client.Clear(); errClear != nil {
+			logrus.Error(errClear)
 		}
+	}
 
-		for _, ut := range uts {
-			utsCh <- ut
-		}
+	return errs, uts
+}
 
-		doneCh <- true
-	}()
+func (dd *Downloader) DownloadModules(kfd config.KFD, gitPrefix string) error {
+	tasks, err := dd.moduleTasks(kfd, gitPrefix)
+	if err != nil {
+		return err
+	}
 
-	done := 0
+	return errors.Join(scheduler.Run(context.Background(), scheduler.Default, tasks)...)
+}
 
-	const todo = 3
+func (dd *Downloader) DownloadInstallers(kfd config.KFD, gitPrefix string) error {
+	tasks, err := dd.installerTasks(kfd, gitPrefix)
+	if err != nil {
+		return err
+	}
 
-	for {
-		select {
-		case err := <-errCh:
-			errs = append(errs, err)
+	return errors.Join(scheduler.Run(context.Background(), scheduler.Default, tasks)...)
+}
 
-		case ut := <-utsCh:
-			uts = append(uts, ut)
+// DownloadTools downloads the tools of the distribution and returns the ones that furyctl cannot download.
+func (dd *Downloader) DownloadTools(kfd config.KFD) ([]string, error) {
+	tasks, uts, err := dd.toolTasks(kfd)
+	if err != nil {
+		return uts, err
+	}
 
-		case <-doneCh:
-			done++
+	return uts, errors.Join(scheduler.Run(context.Background(), scheduler.Default, tasks)...)
+}
 
-			if done == todo {
-				if len(errs) > 0 {
-					if errClear := dd.client.Clear(); errClear != nil {
-						logrus.Error(errClear)
-					}
-				}
+func (dd *Downloader) moduleTasks(kfd config.KFD, gitPrefix string) ([]scheduler.Task, error) {
+	mods := reflect.ValueOf(kfd.Modules)
+	tasks := []scheduler.Task{}
 
-				return errs, uts
-			}
+	for i := range mods.NumField() {
+		name := strings.ToLower(mods.Type().Field(i).Name)
 
-		case <-time.After(downloadsTimeout):
-			errs = append(errs, fmt.Errorf("%w dependencies", ErrDownloadTimeout))
+		version, ok := mods.Field(i).Interface().(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", name, ErrModuleHasNoVersion)
+		}
 
-			if errClear := dd.client.Clear(); errClear != nil {
-				logrus.Error(errClear)
-			}
+		if name == "" {
+			return nil, ErrModuleHasNoName
+		}
 
-			return errs, uts
+		if name == "tracing" && !distribution.HasFeature(kfd, distribution.FeatureTracingModule) {
+			continue
 		}
+
+		dst := filepath.Join(dd.basePath, "vendor", "modules", name)
+
+		tasks = append(tasks, scheduler.Task{
+			Name: "modules/" + name,
+			Dst:  dst,
+			Run: func(ctx context.Context) error {
+				return classify(dd.downloadModule(ctx, name, version, kfd.Version, gitPrefix, dst))
+			},
+		})
 	}
+
+	return tasks, nil
 }
 
-func (dd *Downloader) DownloadModules(kfd config.KFD, gitPrefix string) error {
+func (dd *Downloader) downloadModule(ctx context.Context, name, version, kfdVersion, gitPrefix, dst string) error {
 	oldPrefix := "kubernetes-fury"
 	newPrefix := "fury-kubernetes"
-	modules := kfd.Modules
 
-	mods := reflect.ValueOf(modules)
+	offline := netx.IsOffline(dd.client)
 
-	doneCh := make(chan bool)
-	errCh := make(chan error)
+	errs := []error{}
+	retries := 0
 
-	for i := range mods.NumField() {
-		go func(i int) {
-			defer func() {
-				doneCh <- true
-			}()
+	ref := "ref=" + version + "&depth=1"
+	if commit := dd.lockedCommit(lock.SectionModules, name, version); commit != "" {
+		ref = "ref=" + commit
+	}
 
-			name := strings.ToLower(mods.Type().Field(i).Name)
-			version, ok := mods.Field(i).Interface().(string)
+	for _, prefix := range []string{oldPrefix, newPrefix} {
+		src, mirrored := mirror.Default.Rewrite(
+			mirror.ClassModules,
+			"",
+			fmt.Sprintf("git::%s/%s-%s?%s", gitPrefix, prefix, name, ref),
+		)
 
-			if !ok {
-				errCh <- fmt.Errorf("%s: %w", name, ErrModuleHasNoVersion)
+		moduleURL := createURL(prefix, name, version)
 
-				return
+		// Offline clients and mirrors cannot tell in advance which prefix is the right one, all of them are tried.
+		if !offline && !mirrored {
+			found, err := moduleExists(ctx, moduleURL)
+			if err != nil {
+				return fmt.Errorf("%w '%s' (url: %s): %w", ErrDownloadingModule, name, moduleURL, err)
 			}
 
-			if name == "" {
-				errCh <- ErrModuleHasNoName
+			retries++
+
+			// Threshold to retry with the new prefix according to the fallback mechanism.
+			threshold := 2
+
+			if !found {
+				if retries >= threshold {
+					errs = append(
+						errs,
+						fmt.Errorf(
+							"%w '%s (url: %s)': please check if module exists or credentials are correctly configured",
+							ErrModuleNotFound,
+							name,
+							moduleURL,
+						),
+					)
+				}
 
-				return
+				continue
 			}
+		}
 
-			if name == "tracing" && !distribution.HasFeature(kfd, distribution.FeatureTracingModule) {
-				return
+		if err := netx.DownloadContext(ctx, dd.client, src, dst); err != nil {
+			errs = append(errs, fmt.Errorf("%w '%s': %w", dist.ErrDownloadingFolder, src, err))
+
+			if _, err := os.Stat(dst); err == nil {
+				if err := os.RemoveAll(dst); err != nil {
+					logrus.Warningf("Error while cleaning up folder after failing download: %v", err)
+				}
 			}
 
-			errs := []error{}
-			retries := map[string]int{}
+			continue
+		}
 
-			dst := filepath.Join(dd.basePath, "vendor", "modules", name)
+		dd.reference(src, kfdVersion)
 
-			for _, prefix := range []string{oldPrefix, newPrefix} {
-				src := fmt.Sprintf("git::%s/%s-%s?ref=%s&depth=1", gitPrefix, prefix, name, version)
+		errs = []error{}
 
-				moduleURL := createURL(prefix, name, version)
+		break
+	}
 
-				req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, moduleURL, nil)
-				if err != nil {
-					errCh <- fmt.Errorf("%w '%s' (url: %s): %w", ErrDownloadingModule, name, moduleURL, err)
+	if len(errs) > 0 {
+		return fmt.Errorf("%w '%s': %w", ErrDownloadingModule, name, errors.Join(errs...))
+	}
 
-					return
-				}
+	return dd.checkLock(lock.SectionModules, name, version, dst)
+}
 
-				resp, err := http.DefaultClient.Do(req)
-				if err != nil {
-					if resp != nil && resp.Body != nil {
-						if berr := resp.Body.Close(); berr != nil {
-							errCh <- fmt.Errorf("%w '%s' (url: %s): %w, %w", ErrDownloadingModule, name, moduleURL, err, berr)
+func (dd *Downloader) installerTasks(kfd config.KFD, gitPrefix string) ([]scheduler.Task, error) {
+	insts := reflect.ValueOf(kfd.Kubernetes)
+	tasks := []scheduler.Task{}
 
-							return
-						}
-					}
+	for i := range insts.NumField() {
+		name := strings.ToLower(insts.Type().Field(i).Name)
 
-					errCh <- fmt.Errorf("%w '%s' (url: %s): %w", ErrDownloadingModule, name, moduleURL, err)
+		dst := filepath.Join(dd.basePath, "vendor", "installers", name)
 
-					return
-				}
+		v, ok := insts.Field(i).Interface().(config.KFDProvider)
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", name, ErrModuleHasNoVersion)
+		}
 
-				retries[name]++
-
-				// Threshold to retry with the new prefix according to the fallback mechanism.
-				threshold := 2
-
-				if resp.StatusCode != http.StatusOK {
-					if retries[name] >= threshold {
-						errs = append(
-							errs,
-							fmt.Errorf(
-								"%w '%s (url: %s)': please check if module exists or credentials are correctly configured",
-								ErrModuleNotFound,
-								name,
-								moduleURL,
-							),
-						)
-					}
-
-					continue
-				}
+		version := v.Installer
 
-				if err := dd.client.Download(src, dst); err != nil {
-					errs = append(errs, fmt.Errorf("%w '%s': %v", dist.ErrDownloadingFolder, src, err))
+		ref := "ref=" + version + "&depth=1"
+		if commit := dd.lockedCommit(lock.SectionInstallers, name, version); commit != "" {
+			ref = "ref=" + commit
+		}
 
-					if _, err := os.Stat(dst); err == nil {
-						if err := os.RemoveAll(dst); err != nil {
-							logrus.Warningf("Error while cleaning up folder after failing download: %v", err)
-						}
-					}
+		src := fmt.Sprintf("git::%s/fury-%s-installer?%s", gitPrefix, name, ref)
 
-					continue
-				}
+		// Rename the repository.
+		if name == "onpremises" {
+			src = fmt.Sprintf("git::%s/fury-kubernetes-on-premises?%s", gitPrefix, ref)
+		}
 
-				errs = []error{}
+		src, _ = mirror.Default.Rewrite(mirror.ClassInstallers, "", src)
 
-				break
-			}
+		tasks = append(tasks, scheduler.Task{
+			Name: "installers/" + name,
+			Dst:  dst,
+			Run: func(ctx context.Context) error {
+				if err := netx.DownloadContext(ctx, dd.client, src, dst); err != nil {
+					return classify(fmt.Errorf("%w '%s': %w", dist.ErrDownloadingFolder, src, err))
+				}
 
-			if len(errs) > 0 {
-				errCh <- fmt.Errorf("%w '%s': %v", ErrDownloadingModule, name, errs)
+				dd.reference(src, kfd.Version)
 
-				return
-			}
+				return classify(dd.checkLock(lock.SectionInstallers, name, version, dst))
+			},
+		})
+	}
 
-			if err := os.RemoveAll(filepath.Join(dst, ".git")); err != nil {
-				errCh <- fmt.Errorf("error removing .git subfolder: %w", err)
+	return tasks, nil
+}
 
-				return
-			}
-		}(i)
-	}
+// toolTasks returns the tasks downloading the tools of the distribution, and the tools that furyctl cannot download.
+func (dd *Downloader) toolTasks(kfd config.KFD) ([]scheduler.Task, []string, error) {
+	tls := reflect.ValueOf(kfd.Tools)
+	tasks := []scheduler.Task{}
+	uts := []string{}
 
-	done := 0
+	for i := range tls.NumField() {
+		for j := range tls.Field(i).NumField() {
+			name := strings.ToLower(tls.Field(i).Type().Field(j).Name)
 
-	for {
-		select {
-		case <-doneCh:
-			done++
+			toolCfg, ok := tls.Field(i).Field(j).Interface().(config.KFDTool)
+			if !ok {
+				return nil, uts, fmt.Errorf("%s: %w", name, ErrModuleHasNoVersion)
+			}
 
-			if done == mods.NumField() {
-				return nil
+			if (name == "helm" || name == "helmfile") && !distribution.HasFeature(kfd, distribution.FeaturePlugins) {
+				continue
 			}
 
-		case err := <-errCh:
-			return err
+			if name == "yq" && !distribution.HasFeature(kfd, distribution.FeatureYqSupport) {
+				continue
+			}
 
-		case <-time.After(downloadsTimeout):
-			return fmt.Errorf("%w modules", ErrDownloadTimeout)
-		}
-	}
-}
+			if (name == "kapp") && !distribution.HasFeature(kfd, distribution.FeatureKappSupport) {
+				continue
+			}
 
-func (dd *Downloader) DownloadInstallers(installers config.KFDKubernetes, gitPrefix string) error {
-	insts := reflect.ValueOf(installers)
+			if (name == "terraform") && distribution.HasFeature(kfd, distribution.FeatureOpenTofuSupport) {
+				continue
+			}
 
-	for i := range insts.NumField() {
-		name := strings.ToLower(insts.Type().Field(i).Name)
+			if (name == "opentofu") && !distribution.HasFeature(kfd, distribution.FeatureOpenTofuSupport) {
+				continue
+			}
 
-		dst := filepath.Join(dd.basePath, "vendor", "installers", name)
+			for _, p := range dd.toolPlatforms() {
+				tfc := dd.toolFactory.CreateForPlatform(tool.Name(name), toolCfg.Version, p)
+				if tfc == nil || !tfc.SupportsDownload() {
+					uts = append(uts, name)
 
-		v, ok := insts.Field(i).Interface().(config.KFDProvider)
-		if !ok {
-			return fmt.Errorf("%s: %w", name, ErrModuleHasNoVersion)
-		}
+					break
+				}
 
-		version := v.Installer
+				taskName := "tools/" + name
+				dst := filepath.Join(dd.binPath, name, toolCfg.Version)
 
-		src := fmt.Sprintf("git::%s/fury-%s-installer?ref=%s&depth=1", gitPrefix, name, version)
+				if len(dd.platforms) > 0 {
+					taskName += " (" + p.String() + ")"
+					dst = filepath.Join(dd.binPath, p.Dir(), name, toolCfg.Version)
+				}
 
-		// Rename the repository.
-		if name == "onpremises" {
-			src = fmt.Sprintf("git::%s/fury-kubernetes-on-premises?ref=%s&depth=1", gitPrefix, version)
+				tasks = append(tasks, scheduler.Task{
+					Name: taskName,
+					Dst:  dst,
+					Run: func(ctx context.Context) error {
+						return classify(dd.downloadTool(ctx, name, toolCfg.Version, kfd.Version, p, tfc, dst))
+					},
+				})
+			}
 		}
+	}
 
-		if err := dd.client.Download(src, dst); err != nil {
-			return fmt.Errorf("%w '%s': %v", dist.ErrDownloadingFolder, src, err)
-		}
+	return tasks, uts, nil
+}
 
-		err := os.RemoveAll(filepath.Join(dst, ".git"))
-		if err != nil {
-			return fmt.Errorf("error removing .git subfolder: %w", err)
-		}
+// toolPlatforms returns the platforms to download the tools for.
+func (dd *Downloader) toolPlatforms() []platform.Platform {
+	if len(dd.platforms) == 0 {
+		return []platform.Platform{platform.Current()}
 	}
 
-	return nil
+	return dd.platforms
 }
 
-func (dd *Downloader) DownloadTools(kfd config.KFD) ([]string, error) {
-	toolsCount := 0
-	kfdTools := kfd.Tools
-	tls := reflect.ValueOf(kfdTools)
+func (dd *Downloader) downloadTool(
+	ctx context.Context,
+	name, version, kfdVersion string,
+	p platform.Platform,
+	tfc tools.Tool,
+	dst string,
+) error {
+	// Tools already installed are not downloaded again, make sure they have not been changed.
+	if _, err := tools.VerifyChecksumRecord(dst); err != nil && errors.Is(err, tools.ErrChecksumMismatch) {
+		return fmt.Errorf("%s: %w", name, err)
+	}
 
-	doneCh := make(chan bool)
-	utsCh := make(chan string)
-	errCh := make(chan error)
+	checksum := dd.pinnedChecksums.GetForPlatform(name, p)
+	if checksum == "" {
+		checksum = tfc.Checksum()
+	}
 
-	for i := range tls.NumField() {
-		for j := range tls.Field(i).NumField() {
-			toolsCount++
+	// Checksums files are published next to the tools, so they are fetched from the same mirror.
+	if checksumURL, ok := strings.CutPrefix(checksum, "file:"); ok {
+		checksumURL, _ = mirror.Default.Rewrite(mirror.ClassTools, name, checksumURL)
+		checksum = "file:" + checksumURL
+	}
 
-			go func(i, j int) {
-				defer func() {
-					doneCh <- true
-				}()
+	toolURL, _ := mirror.Default.Rewrite(mirror.ClassTools, name, tfc.SrcPath())
 
-				name := strings.ToLower(tls.Field(i).Type().Field(j).Name)
+	src := toolURL
+	if checksum != "" {
+		src += "?" + url.Values{"checksum": []string{checksum}}.Encode()
+	} else {
+		logrus.Warnf("No checksum available for %s, its integrity will not be verified", name)
+	}
 
-				toolCfg, ok := tls.Field(i).Field(j).Interface().(config.KFDTool)
+	if err := netx.DownloadContext(ctx, dd.client, src, dst); err != nil {
+		return fmt.Errorf("%w '%s': %w", dist.ErrDownloadingFolder, toolURL, err)
+	}
 
-				if !ok {
-					errCh <- fmt.Errorf("%s: %w", name, ErrModuleHasNoVersion)
+	dd.reference(src, kfdVersion)
 
-					return
-				}
+	if err := tfc.Rename(dst); err != nil {
+		return fmt.Errorf("%w '%s': %w", dist.ErrRenamingFile, tfc.SrcPath(), err)
+	}
 
-				if (name == "helm" || name == "helmfile") && !distribution.HasFeature(kfd, distribution.FeaturePlugins) {
-					return
-				}
+	if _, err := os.Stat(filepath.Join(dst, name)); err == nil {
+		if err := os.Chmod(filepath.Join(dst, name), iox.FullPermAccess); err != nil {
+			return fmt.Errorf("%w '%s': %w", dist.ErrChangingFilePermissions, filepath.Join(dst, name), err)
+		}
+	}
 
-				if name == "yq" && !distribution.HasFeature(kfd, distribution.FeatureYqSupport) {
-					return
-				}
+	if err := tools.WriteChecksumRecord(dst, checksum); err != nil {
+		return fmt.Errorf("error writing checksum record of %s: %w", name, err)
+	}
 
-				if (name == "kapp") && !distribution.HasFeature(kfd, distribution.FeatureKappSupport) {
-					return
-				}
+	if dd.lock == nil {
+		return nil
+	}
 
-				if (name == "terraform") && distribution.HasFeature(kfd, distribution.FeatureOpenTofuSupport) {
-					return
-				}
+	digest, err := iox.HashDir(dst)
+	if err != nil {
+		return fmt.Errorf("error hashing %s: %w", name, err)
+	}
 
-				if (name == "opentofu") && !distribution.HasFeature(kfd, distribution.FeatureOpenTofuSupport) {
-					return
-				}
+	// The binaries differ by platform, the ones for other platforms are locked separately.
+	lockName := name
+	if !p.IsCurrent() {
+		lockName += "@" + p.String()
+	}
 
-				tfc := dd.toolFactory.Create(tool.Name(name), toolCfg.Version)
-				if tfc == nil || !tfc.SupportsDownload() {
-					utsCh <- name
+	if err := dd.lock.Check(lock.SectionTools, lockName, lock.Entry{
+		Version: version,
+		Digest:  digest,
+	}); err != nil {
+		return fmt.Errorf("error checking furyctl.lock: %w", err)
+	}
 
-					return
-				}
+	return nil
+}
 
-				dst := filepath.Join(dd.binPath, name, toolCfg.Version)
+// classify marks the integrity errors as fatal, so that the other downloads are stopped, and the timeouts of the
+// single downloads as such.
+func classify(err error) error {
+	switch {
+	case err == nil:
+		return nil
+
+	case errors.Is(err, lock.ErrMismatch),
+		errors.Is(err, tools.ErrChecksumMismatch),
+		errors.Is(err, netx.ErrChecksumMismatch),
+		errors.Is(err, netx.ErrCacheChecksumMismatch):
+		return scheduler.Fatal(err)
+
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%w: %w", ErrDownloadTimeout, err)
+	}
 
-				if err := dd.client.Download(tfc.SrcPath(), dst); err != nil {
-					errCh <- fmt.Errorf("%w '%s': %w", dist.ErrDownloadingFolder, tfc.SrcPath(), err)
+	return err
+}
 
-					return
-				}
+// reference records in the download cache that src is used by the given distribution version.
+func (dd *Downloader) reference(src, version string) {
+	if err := netx.Reference(dd.client, src, version); err != nil {
+		logrus.Warnf("Cannot record the use of %s in the download cache: %v", src, err)
+	}
+}
 
-				if err := tfc.Rename(dst); err != nil {
-					errCh <- fmt.Errorf("%w '%s': %w", dist.ErrRenamingFile, tfc.SrcPath(), err)
+func (dd *Downloader) lockedCommit(section lock.Section, name, version string) string {
+	if dd.lock == nil {
+		return ""
+	}
 
-					return
-				}
+	return dd.lock.Commit(section, name, version)
+}
 
-				if _, err := os.Stat(filepath.Join(dst, name)); err == nil {
-					if err := os.Chmod(filepath.Join(dst, name), iox.FullPermAccess); err != nil {
-						errCh <- fmt.Errorf("%w '%s': %w", dist.ErrChangingFilePermissions, filepath.Join(dst, name), err)
+// checkLock removes the repository of the dependency downloaded in dst, after checking its commit and its content
+// against the lock, if any.
+func (dd *Downloader) checkLock(section lock.Section, name, version, dst string) error {
+	var commit string
 
-						return
-					}
-				}
-			}(i, j)
+	if dd.lock != nil {
+		var err error
+
+		if commit, err = lock.ResolveCommit(dst); err != nil {
+			return err
 		}
 	}
 
-	uts := []string{}
-	done := 0
+	if err := os.RemoveAll(filepath.Join(dst, ".git")); err != nil {
+		return fmt.Errorf("error removing .git subfolder: %w", err)
+	}
 
-	for {
-		select {
-		case <-doneCh:
-			done++
+	if dd.lock == nil {
+		return nil
+	}
 
-			if done == toolsCount {
-				return uts, nil
-			}
+	digest, err := iox.HashDir(dst)
+	if err != nil {
+		return err
+	}
+
+	if err := dd.lock.Check(section, name, lock.Entry{
+		Version: version,
+		Commit:  commit,
+		Digest:  digest,
+	}); err != nil {
+		return fmt.Errorf("error checking furyctl.lock: %w", err)
+	}
 
-		case ut := <-utsCh:
-			uts = append(uts, ut)
+	return nil
+}
+
+func moduleExists(ctx context.Context, moduleURL string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, moduleURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("error while creating request: %w", err)
+	}
 
-		case err := <-errCh:
-			return uts, err
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("error while checking module: %w", err)
+	}
 
-		case <-time.After(downloadsTimeout):
-			return uts, fmt.Errorf("%w tools", ErrDownloadTimeout)
-		}
+	if err := resp.Body.Close(); err != nil {
+		return false, fmt.Errorf("error while closing response body: %w", err)
 	}
+
+	return resp.StatusCode == http.StatusOK, nil
 }
 
 func createURL(prefix, name, version string) string {
@@ -22,7 +22,7 @@ var (
 func NewValidator(executor execx.Executor, binPath, furyctlPath string, autoConnect bool) *Validator {
 	return &Validator{
 		toolsValidator:   tools.NewValidator(executor, binPath, furyctlPath, autoConnect),
-		envVarsValidator: envvars.NewValidator(),
+		envVarsValidator: envvars.NewValidator(executor, furyctlPath),
 	}
 }
 
@@ -12,6 +12,7 @@ import (
 
 	r3diff "github.com/r3labs/diff/v3"
 
+	"github.com/sighupio/furyctl/internal/redact"
 	rules "github.com/sighupio/furyctl/pkg/rulesextractor"
 )
 
@@ -80,12 +81,30 @@ func (*BaseChecker) DiffToString(diffs r3diff.Changelog) string {
 	for _, diff := range diffs {
 		joinedPath := "." + strings.Join(diff.Path, ".")
 
-		str += fmt.Sprintf("%s: %v -> %v\n", joinedPath, diff.From, diff.To)
+		str += fmt.Sprintf(
+			"%s: %v -> %v\n",
+			joinedPath,
+			redactDiffValue(diff.Path, diff.From),
+			redactDiffValue(diff.Path, diff.To),
+		)
 	}
 
 	return str
 }
 
+// redactDiffValue hides values of sensitive fields and values containing secrets.
+func redactDiffValue(path []string, value any) any {
+	if value == nil {
+		return nil
+	}
+
+	if len(path) > 0 && redact.Secrets.IsSensitiveField(path[len(path)-1]) {
+		return redact.Mask
+	}
+
+	return redact.Secrets.Value(value)
+}
+
 func (*BaseChecker) AssertImmutableViolations(diffs r3diff.Changelog, immutablePaths []string) []error {
 	var errs []error
 
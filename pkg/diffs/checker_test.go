@@ -196,3 +196,31 @@ func TestBaseChecker_AssertImmutableViolations(t *testing.T) {
 		})
 	}
 }
+
+func TestBaseChecker_DiffToString(t *testing.T) {
+	t.Parallel()
+
+	checker := diffs.NewBaseChecker(map[string]any{}, map[string]any{})
+
+	got := checker.DiffToString(diffx.Changelog{
+		{
+			Type: "update",
+			Path: []string{"spec", "distribution", "modules", "auth", "basicAuth", "password"},
+			From: "old-password",
+			To:   "new-password",
+		},
+		{
+			Type: "create",
+			Path: []string{"spec", "distribution", "modules", "auth", "basicAuth", "username"},
+			From: nil,
+			To:   "admin",
+		},
+	})
+
+	want := ".spec.distribution.modules.auth.basicAuth.password: [REDACTED] -> [REDACTED]\n" +
+		".spec.distribution.modules.auth.basicAuth.username: <nil> -> admin\n"
+
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -18,8 +18,11 @@ import (
 
 	"github.com/sighupio/fury-distribution/pkg/apis/config"
 	"github.com/sighupio/furyctl/configs"
+	"github.com/sighupio/furyctl/internal/dependencies/lock"
+	"github.com/sighupio/furyctl/internal/dependencies/tools"
 	idist "github.com/sighupio/furyctl/internal/distribution"
 	"github.com/sighupio/furyctl/internal/git"
+	"github.com/sighupio/furyctl/internal/mirror"
 	iox "github.com/sighupio/furyctl/internal/x/io"
 	netx "github.com/sighupio/furyctl/pkg/x/net"
 	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
@@ -47,6 +50,7 @@ type DownloadResult struct {
 	RepoPath       string
 	MinimalConf    config.Furyctl
 	DistroManifest config.KFD
+	ToolsChecksums tools.PinnedChecksums
 }
 
 func NewCachingDownloader(
@@ -76,6 +80,12 @@ type Downloader struct {
 	validate                *validator.Validate
 	gitProtocol             git.Protocol
 	customDistroPatchesPath string
+	lock                    *lock.Lock
+}
+
+// UseLock pins the default distribution to the commit recorded in l, and checks the downloaded one against it.
+func (d *Downloader) UseLock(l *lock.Lock) {
+	d.lock = l
 }
 
 func (d *Downloader) Download(
@@ -126,6 +136,12 @@ func (d *Downloader) DoDownload(
 
 	if distroLocation == "" {
 		url = fmt.Sprintf(DefaultBaseURL, protocol, minimalConf.Spec.DistributionVersion)
+
+		if d.lock != nil {
+			if commit := d.lock.DistributionCommit(minimalConf.Spec.DistributionVersion); commit != "" {
+				url = fmt.Sprintf("git::%s/fury-distribution?ref=%s", protocol, commit)
+			}
+		}
 	}
 
 	if strings.HasPrefix(url, ".") {
@@ -140,7 +156,7 @@ func (d *Downloader) DoDownload(
 		return DownloadResult{}, fmt.Errorf("%w: %v", ErrCreatingTempDir, err)
 	}
 
-	src := url
+	src, _ := mirror.Default.Rewrite(mirror.ClassDistribution, "", url)
 	dst := filepath.Join(baseDst, "data")
 	logrus.Debugf("Downloading distribution from %s to %s", src, dst)
 
@@ -174,6 +190,24 @@ func (d *Downloader) DoDownload(
 		return DownloadResult{}, fmt.Errorf("%w '%s': %v", ErrDownloadingFolder, src, err)
 	}
 
+	if err := netx.Reference(d.client, src, minimalConf.Spec.DistributionVersion); err != nil {
+		logrus.Warnf("Cannot record the use of %s in the download cache: %v", src, err)
+	}
+
+	if d.lock != nil {
+		commit, err := lock.ResolveCommit(dst)
+		if err != nil {
+			return DownloadResult{}, fmt.Errorf("%w: %w", ErrCannotDownloadDistribution, err)
+		}
+
+		if err := d.lock.CheckDistribution(lock.Entry{
+			Version: minimalConf.Spec.DistributionVersion,
+			Commit:  commit,
+		}); err != nil {
+			return DownloadResult{}, fmt.Errorf("%w: %w", ErrCannotDownloadDistribution, err)
+		}
+	}
+
 	kfdPath := filepath.Join(dst, "kfd.yaml")
 
 	_, err = os.Stat(kfdPath)
@@ -217,10 +251,16 @@ func (d *Downloader) DoDownload(
 		return DownloadResult{}, err
 	}
 
+	toolsChecksums, err := tools.ReadPinnedChecksums(kfdPath)
+	if err != nil {
+		return DownloadResult{}, err
+	}
+
 	return DownloadResult{
 		RepoPath:       dst,
 		MinimalConf:    minimalConf,
 		DistroManifest: postPatchkfdManifest,
+		ToolsChecksums: toolsChecksums,
 	}, nil
 }
 
@@ -122,16 +122,20 @@ func (m *Mapper) injectDynamicValuesAndPaths(
 	return context, nil
 }
 
-func (m *Mapper) injectDynamicValuesAndPathsString(value string) (string, error) {
+func (m *Mapper) injectDynamicValuesAndPathsString(value string) (any, error) {
 	cfgParser := parser.NewConfigParser(m.furyctlConfDir)
 
 	// Use the shared parser method to handle multiple dynamic values.
-	parsedValue, err := cfgParser.ParseMultipleDynamicValues(value)
+	parsedValue, err := cfgParser.ParseDynamicValue(value)
 	if err != nil {
-		return "", fmt.Errorf("error parsing dynamic values: %w", err)
+		return nil, fmt.Errorf("error parsing dynamic values: %w", err)
 	}
 
-	value = parsedValue
+	// Terraform outputs keep their type when they are the whole value.
+	value, ok := parsedValue.(string)
+	if !ok {
+		return parsedValue, nil
+	}
 
 	// If the value is a relative path, we need to convert it to an absolute path.
 	isRelativePath := RelativePathRegexp.MatchString(value)
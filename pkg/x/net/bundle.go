@@ -0,0 +1,359 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/sirupsen/logrus"
+
+	iox "github.com/sighupio/furyctl/internal/x/io"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+const (
+	// BundleUpgradesSrc is the source of the custom upgrade scripts stored in a bundle.
+	BundleUpgradesSrc = "bundle::upgrades"
+
+	bundleIndexFile     = "index.yaml"
+	bundleItemsDir      = "items"
+	bundleFormatVersion = 1
+)
+
+var (
+	ErrCannotWriteBundle      = errors.New("cannot write bundle")
+	ErrCannotReadBundle       = errors.New("cannot read bundle")
+	ErrUnsupportedBundle      = errors.New("unsupported bundle version")
+	ErrNotInBundle            = errors.New("not found in bundle")
+	ErrBundleChecksumMismatch = errors.New("bundle item does not match its checksum")
+)
+
+// BundleIndex describes the content of a bundle.
+type BundleIndex struct {
+	Version  int          `yaml:"version"`
+	Platform string       `yaml:"platform"`
+	Items    []BundleItem `yaml:"items"`
+}
+
+// BundleItem is a download stored in a bundle, its content is in the items/<key> folder.
+type BundleItem struct {
+	Src    string `yaml:"src"`
+	Key    string `yaml:"key"`
+	Digest string `yaml:"digest"`
+}
+
+// NewBundleRecorder returns a client that downloads through c and records a copy of every download in dir,
+// to be packed into a bundle that can be used in place of the network with NewBundleClient.
+func NewBundleRecorder(c Client, dir string) *BundleRecorder {
+	return &BundleRecorder{
+		client: c,
+		dir:    dir,
+		items:  map[string]BundleItem{},
+	}
+}
+
+type BundleRecorder struct {
+	client Client
+	dir    string
+	mu     sync.Mutex
+	items  map[string]BundleItem
+}
+
+func (r *BundleRecorder) Clear() error {
+	return r.client.Clear() //nolint:wrapcheck // Decorator.
+}
+
+func (r *BundleRecorder) ClearItem(src string) error {
+	return r.client.ClearItem(src) //nolint:wrapcheck // Decorator.
+}
+
+func (r *BundleRecorder) Reference(src, distributionVersion string) error {
+	return Reference(r.client, src, distributionVersion)
+}
+
+func (r *BundleRecorder) Download(src, dst string) error {
+	return r.DownloadContext(context.Background(), src, dst)
+}
+
+func (r *BundleRecorder) DownloadContext(ctx context.Context, src, dst string) error {
+	if err := DownloadContext(ctx, r.client, src, dst); err != nil {
+		return err //nolint:wrapcheck // Decorator.
+	}
+
+	return r.Add(src, dst)
+}
+
+// Add stores the content of the local folder dir in the bundle, to be served for src.
+func (r *BundleRecorder) Add(src, dir string) error {
+	if err := r.record(src, dir); err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotWriteBundle, err)
+	}
+
+	return nil
+}
+
+func (r *BundleRecorder) record(src, dst string) error {
+	key := keyFromURL(URLPrefixRegexp.ReplaceAllString(src, ""))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[key]; ok {
+		return nil
+	}
+
+	itemDir := filepath.Join(r.dir, bundleItemsDir, key)
+
+	if err := os.MkdirAll(itemDir, iox.FullPermAccess); err != nil {
+		return fmt.Errorf("error while creating folder %s: %w", itemDir, err)
+	}
+
+	if err := iox.CopyRecursive(os.DirFS(dst), itemDir); err != nil {
+		return fmt.Errorf("error while copying %s to bundle: %w", src, err)
+	}
+
+	// Repositories are only needed for their content.
+	if err := os.RemoveAll(filepath.Join(itemDir, ".git")); err != nil {
+		return fmt.Errorf("error while removing .git folder from bundle item %s: %w", src, err)
+	}
+
+	digest, err := iox.HashDir(itemDir)
+	if err != nil {
+		return err
+	}
+
+	r.items[key] = BundleItem{Src: src, Key: key, Digest: digest}
+
+	logrus.Debugf("Added '%s' to bundle", src)
+
+	return nil
+}
+
+// Pack writes the recorded downloads as a zstd-compressed tarball at path.
+func (r *BundleRecorder) Pack(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	idx := BundleIndex{
+		Version:  bundleFormatVersion,
+		Platform: runtime.GOOS + "/" + runtime.GOARCH,
+		Items:    make([]BundleItem, 0, len(r.items)),
+	}
+
+	for _, item := range r.items {
+		idx.Items = append(idx.Items, item)
+	}
+
+	sort.Slice(idx.Items, func(i, j int) bool {
+		return idx.Items[i].Src < idx.Items[j].Src
+	})
+
+	idxBytes, err := yamlx.MarshalV3(idx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotWriteBundle, err)
+	}
+
+	if err := iox.WriteFile(filepath.Join(r.dir, bundleIndexFile), idxBytes); err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotWriteBundle, err)
+	}
+
+	if err := writeBundle(r.dir, path); err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotWriteBundle, err)
+	}
+
+	return nil
+}
+
+func writeBundle(dir, path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("error while creating %s: %w", path, err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	zw, err := zstd.NewWriter(tmp)
+	if err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("error while initializing compression: %w", err)
+	}
+
+	if err := iox.WriteTar(zw, dir); err != nil {
+		zw.Close()
+		tmp.Close()
+
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("error while closing compression: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error while closing %s: %w", path, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error while writing %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// NewBundleClient returns a client that serves all downloads from the bundle at path, without accessing the
+// network. The bundle is extracted in a temporary folder that is removed by Close.
+func NewBundleClient(path string) (*BundleClient, error) {
+	dir, err := os.MkdirTemp("", "furyctl-bundle-")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCannotReadBundle, err)
+	}
+
+	if err := readBundle(path, dir); err != nil {
+		os.RemoveAll(dir)
+
+		return nil, fmt.Errorf("%w %s: %w", ErrCannotReadBundle, path, err)
+	}
+
+	idx, err := yamlx.FromFileV3[BundleIndex](filepath.Join(dir, bundleIndexFile))
+	if err != nil {
+		os.RemoveAll(dir)
+
+		return nil, fmt.Errorf("%w %s: %w", ErrCannotReadBundle, path, err)
+	}
+
+	if idx.Version != bundleFormatVersion {
+		os.RemoveAll(dir)
+
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedBundle, idx.Version)
+	}
+
+	if platform := runtime.GOOS + "/" + runtime.GOARCH; idx.Platform != platform {
+		logrus.Warnf("Bundle %s has been created on %s, its tools may not run on %s", path, idx.Platform, platform)
+	}
+
+	items := make(map[string]BundleItem, len(idx.Items))
+
+	for _, item := range idx.Items {
+		items[item.Key] = item
+	}
+
+	return &BundleClient{
+		dir:      dir,
+		items:    items,
+		verified: map[string]bool{},
+	}, nil
+}
+
+func readBundle(path, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error while opening bundle: %w", err)
+	}
+
+	defer f.Close()
+
+	zr, err := zstd.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("error while initializing decompression: %w", err)
+	}
+
+	defer zr.Close()
+
+	return iox.ExtractTar(zr, dir) //nolint:wrapcheck // Wrapped by the caller.
+}
+
+type BundleClient struct {
+	dir      string
+	items    map[string]BundleItem
+	mu       sync.Mutex
+	verified map[string]bool
+}
+
+func (*BundleClient) Clear() error {
+	return nil
+}
+
+func (*BundleClient) ClearItem(_ string) error {
+	return nil
+}
+
+func (*BundleClient) Offline() bool {
+	return true
+}
+
+func (b *BundleClient) Download(src, dst string) error {
+	itemDir, err := b.Path(src)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dst, iox.FullPermAccess); err != nil {
+		return fmt.Errorf("error while creating folder %s: %w", dst, err)
+	}
+
+	if err := iox.CopyRecursive(os.DirFS(itemDir), dst); err != nil {
+		return fmt.Errorf("error while copying '%s' from bundle: %w", src, err)
+	}
+
+	return nil
+}
+
+// Path returns the folder holding the content stored in the bundle for src, after verifying it.
+func (b *BundleClient) Path(src string) (string, error) {
+	item, ok := b.items[keyFromURL(URLPrefixRegexp.ReplaceAllString(src, ""))]
+	if !ok {
+		return "", fmt.Errorf("'%s' %w", src, ErrNotInBundle)
+	}
+
+	itemDir := filepath.Join(b.dir, bundleItemsDir, item.Key)
+
+	if err := b.verify(item, itemDir); err != nil {
+		return "", err
+	}
+
+	return itemDir, nil
+}
+
+func (b *BundleClient) verify(item BundleItem, itemDir string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.verified[item.Key] {
+		return nil
+	}
+
+	digest, err := iox.HashDir(itemDir)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotReadBundle, err)
+	}
+
+	if digest != item.Digest {
+		return fmt.Errorf("%w: %s", ErrBundleChecksumMismatch, item.Src)
+	}
+
+	b.verified[item.Key] = true
+
+	return nil
+}
+
+// Close removes the extracted content of the bundle.
+func (b *BundleClient) Close() error {
+	if err := os.RemoveAll(b.dir); err != nil {
+		return fmt.Errorf("error while removing folder %s: %w", b.dir, err)
+	}
+
+	return nil
+}
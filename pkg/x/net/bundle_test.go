@@ -0,0 +1,91 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package netx_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	netx "github.com/sighupio/furyctl/pkg/x/net"
+)
+
+func newTestBundle(t *testing.T) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+
+	r := netx.NewBundleRecorder(NewFakeClient(), filepath.Join(tmpDir, "staging"))
+
+	require.NoError(t, r.Download(distroHTTPSURL, filepath.Join(tmpDir, "distro")))
+
+	upgrades := filepath.Join(tmpDir, "upgrades")
+
+	require.NoError(t, os.MkdirAll(upgrades, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(upgrades, "pre-apply.sh"), []byte("#!/bin/sh\n"), 0o755))
+	require.NoError(t, r.Add(netx.BundleUpgradesSrc, upgrades))
+
+	bundle := filepath.Join(tmpDir, "bundle.tar.zst")
+
+	require.NoError(t, r.Pack(bundle))
+
+	return bundle
+}
+
+func TestBundleClient_Download(t *testing.T) {
+	t.Parallel()
+
+	bc, err := netx.NewBundleClient(newTestBundle(t))
+	require.NoError(t, err)
+
+	defer bc.Close()
+
+	assert.True(t, netx.IsOffline(bc))
+
+	// The same repository is served regardless of the protocol used to request it.
+	for _, src := range []string{distroHTTPSURL, "git::" + distroSSHURL} {
+		dst := filepath.Join(t.TempDir(), "distro")
+
+		require.NoError(t, bc.Download(src, dst))
+		assert.FileExists(t, filepath.Join(dst, "kfd.yaml"))
+		assert.NoDirExists(t, filepath.Join(dst, ".git"))
+	}
+
+	upgrades, err := bc.Path(netx.BundleUpgradesSrc)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(upgrades, "pre-apply.sh"))
+
+	err = bc.Download("https://example.com/missing.tar.gz", t.TempDir())
+	assert.ErrorIs(t, err, netx.ErrNotInBundle)
+}
+
+func TestBundleClient_Close(t *testing.T) {
+	t.Parallel()
+
+	bc, err := netx.NewBundleClient(newTestBundle(t))
+	require.NoError(t, err)
+
+	upgrades, err := bc.Path(netx.BundleUpgradesSrc)
+	require.NoError(t, err)
+
+	require.NoError(t, bc.Close())
+	assert.NoDirExists(t, upgrades)
+}
+
+func TestNewBundleClient_Invalid(t *testing.T) {
+	t.Parallel()
+
+	bundle := filepath.Join(t.TempDir(), "bundle.tar.zst")
+
+	require.NoError(t, os.WriteFile(bundle, []byte("not a bundle"), 0o600))
+
+	_, err := netx.NewBundleClient(bundle)
+	assert.ErrorIs(t, err, netx.ErrCannotReadBundle)
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netx
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	iox "github.com/sighupio/furyctl/internal/x/io"
+	yamlx "github.com/sighupio/furyctl/pkg/x/yaml"
+)
+
+const metadataExt = ".meta.yaml"
+
+var ErrCacheChecksumMissing = errors.New("cached content has no checksum")
+
+// CacheMetadata describes a cached download, it is stored next to it.
+type CacheMetadata struct {
+	URL      string    `yaml:"url"`
+	Created  time.Time `yaml:"created"`
+	LastUsed time.Time `yaml:"lastUsed"`
+	// Distributions are the distribution versions the download has been used for.
+	Distributions []string `yaml:"distributions,omitempty"`
+}
+
+// CacheEntry is an item of the local cache. Items cached by older versions of furyctl have no URL.
+type CacheEntry struct {
+	CacheMetadata
+
+	Key  string
+	Path string
+	Size int64
+}
+
+type referencer interface {
+	Reference(src, distributionVersion string) error
+}
+
+// Reference records that src, downloaded through c, is used by the given distribution version. Clients that do not
+// keep track of their downloads ignore it.
+func Reference(c Client, src, distributionVersion string) error {
+	rc, ok := c.(referencer)
+	if !ok {
+		return nil
+	}
+
+	return rc.Reference(src, distributionVersion) //nolint:wrapcheck // Errors are wrapped by the decorators.
+}
+
+func (d *LocalCacheClientDecorator) Reference(src, distributionVersion string) error {
+	key := d.getKeyFromURL(URLPrefixRegexp.ReplaceAllString(src, ""))
+
+	meta, err := readCacheMetadata(key)
+	if err != nil {
+		return err
+	}
+
+	if meta == nil || slices.Contains(meta.Distributions, distributionVersion) {
+		return nil
+	}
+
+	meta.Distributions = append(meta.Distributions, distributionVersion)
+
+	sort.Strings(meta.Distributions)
+
+	return writeCacheMetadata(key, *meta)
+}
+
+// touchLocalCache records the use of the cached src, creating its metadata if missing.
+func (d *LocalCacheClientDecorator) touchLocalCache(src string) error {
+	key := d.getKeyFromURL(src)
+	now := time.Now().UTC()
+
+	meta, err := readCacheMetadata(key)
+	if err != nil {
+		return err
+	}
+
+	if meta == nil {
+		meta = &CacheMetadata{URL: src, Created: now}
+	}
+
+	meta.LastUsed = now
+
+	return writeCacheMetadata(key, *meta)
+}
+
+func readCacheMetadata(key string) (*CacheMetadata, error) {
+	meta, err := yamlx.FromFileV3[CacheMetadata](key + metadataExt)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error while reading cache metadata of %s: %w", key, err)
+	}
+
+	return &meta, nil
+}
+
+// writeCacheMetadata replaces the metadata file atomically, caches can be shared by concurrent executions.
+func writeCacheMetadata(key string, meta CacheMetadata) error {
+	out, err := yamlx.MarshalV3(meta)
+	if err != nil {
+		return fmt.Errorf("error while marshaling cache metadata of %s: %w", key, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(key), filepath.Base(key)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error while writing cache metadata of %s: %w", key, err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(out); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("error while writing cache metadata of %s: %w", key, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error while writing cache metadata of %s: %w", key, err)
+	}
+
+	if err := os.Rename(tmp.Name(), key+metadataExt); err != nil {
+		return fmt.Errorf("error while writing cache metadata of %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// verifyCacheItem fails if the content of the cache item at key has changed since it was downloaded.
+func verifyCacheItem(key string) error {
+	want, err := os.ReadFile(key + checksumExt)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrCacheChecksumMissing, key)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotCheckLocalCache, err)
+	}
+
+	got, err := iox.HashDir(key)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotCheckLocalCache, err)
+	}
+
+	if got != strings.TrimSpace(string(want)) {
+		return fmt.Errorf("%w: %s, delete the folder to download it again", ErrCacheChecksumMismatch, key)
+	}
+
+	return nil
+}
+
+// NewLocalCache returns a handle to inspect and maintain the cache folder used by WithLocalCache.
+func NewLocalCache(dir string) *LocalCache {
+	return &LocalCache{dir: dir}
+}
+
+type LocalCache struct {
+	dir string
+}
+
+// List returns the items of the cache, the most recently used first.
+func (c *LocalCache) List() ([]CacheEntry, error) {
+	des, err := os.ReadDir(c.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCannotCheckLocalCache, err)
+	}
+
+	entries := []CacheEntry{}
+
+	for _, de := range des {
+		if !de.IsDir() {
+			continue
+		}
+
+		e, err := c.entry(de)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, e)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].LastUsed.After(entries[j].LastUsed)
+	})
+
+	return entries, nil
+}
+
+func (c *LocalCache) entry(de fs.DirEntry) (CacheEntry, error) {
+	path := filepath.Join(c.dir, de.Name())
+
+	e := CacheEntry{Key: de.Name(), Path: path}
+
+	meta, err := readCacheMetadata(path)
+	if err != nil {
+		return CacheEntry{}, err
+	}
+
+	if meta != nil {
+		e.CacheMetadata = *meta
+	} else {
+		fi, err := de.Info()
+		if err != nil {
+			return CacheEntry{}, fmt.Errorf("%w: %w", ErrCannotCheckLocalCache, err)
+		}
+
+		e.Created = fi.ModTime()
+		e.LastUsed = fi.ModTime()
+	}
+
+	if err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			return err //nolint:wrapcheck // Wrapped below.
+		}
+
+		e.Size += fi.Size()
+
+		return nil
+	}); err != nil {
+		return CacheEntry{}, fmt.Errorf("%w: %w", ErrCannotCheckLocalCache, err)
+	}
+
+	return e, nil
+}
+
+// Verify fails if the content of the entry has changed since it was downloaded.
+func (*LocalCache) Verify(e CacheEntry) error {
+	return verifyCacheItem(e.Path)
+}
+
+// Remove deletes the entry and its checksum and metadata files.
+func (*LocalCache) Remove(e CacheEntry) error {
+	for _, p := range []string{e.Path, e.Path + checksumExt, e.Path + metadataExt} {
+		if err := os.RemoveAll(p); err != nil {
+			return fmt.Errorf("%w: %w", ErrCannotClearCache, err)
+		}
+	}
+
+	return nil
+}
+
+// Clear deletes the whole cache.
+func (c *LocalCache) Clear() error {
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotClearCache, err)
+	}
+
+	return nil
+}
+
+// PruneOptions selects the entries to remove from the cache, every criterion that is set applies.
+type PruneOptions struct {
+	// OlderThan selects the entries that have not been used for longer than the given duration.
+	OlderThan time.Duration
+	// MaxSize selects the least recently used entries until the rest of the cache fits the given size in bytes.
+	MaxSize int64
+	// Keep, if set, selects the entries it returns false for.
+	Keep func(e CacheEntry) bool
+}
+
+// SelectPrunable returns the entries to remove according to opts.
+func SelectPrunable(entries []CacheEntry, opts PruneOptions, now time.Time) []CacheEntry {
+	prune := []CacheEntry{}
+	kept := []CacheEntry{}
+
+	for _, e := range entries {
+		if (opts.OlderThan > 0 && now.Sub(e.LastUsed) > opts.OlderThan) || (opts.Keep != nil && !opts.Keep(e)) {
+			prune = append(prune, e)
+
+			continue
+		}
+
+		kept = append(kept, e)
+	}
+
+	if opts.MaxSize <= 0 {
+		return prune
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].LastUsed.Before(kept[j].LastUsed)
+	})
+
+	var size int64
+
+	for _, e := range kept {
+		size += e.Size
+	}
+
+	for _, e := range kept {
+		if size <= opts.MaxSize {
+			break
+		}
+
+		prune = append(prune, e)
+		size -= e.Size
+	}
+
+	return prune
+}
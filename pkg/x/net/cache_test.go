@@ -0,0 +1,151 @@
+// Copyright (c) 2017-present SIGHUP s.r.l All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unit
+
+package netx_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	netx "github.com/sighupio/furyctl/pkg/x/net"
+)
+
+func TestLocalCache_Metadata(t *testing.T) {
+	t.Parallel()
+
+	cacheDir := t.TempDir()
+
+	c := netx.WithLocalCache(NewFakeClient(), cacheDir)
+
+	require.NoError(t, c.Download(distroHTTPSURL, filepath.Join(t.TempDir(), "data")))
+	require.NoError(t, netx.Reference(c, distroHTTPSURL, "v1.31.0"))
+	require.NoError(t, netx.Reference(c, "git::"+distroHTTPSURL, "v1.31.0"))
+
+	lc := netx.NewLocalCache(cacheDir)
+
+	entries, err := lc.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	e := entries[0]
+
+	assert.Equal(t, distroHTTPSURL, e.URL)
+	assert.Equal(t, []string{"v1.31.0"}, e.Distributions)
+	assert.Positive(t, e.Size)
+	assert.False(t, e.LastUsed.IsZero())
+	require.NoError(t, lc.Verify(e))
+
+	// A cache hit updates the last use.
+	require.NoError(t, c.Download(distroHTTPSURL, filepath.Join(t.TempDir(), "data")))
+
+	entries, err = lc.List()
+	require.NoError(t, err)
+	assert.False(t, entries[0].LastUsed.Before(e.LastUsed))
+
+	require.NoError(t, os.WriteFile(filepath.Join(e.Path, "kfd.yaml"), []byte("tampered"), 0o644))
+	require.ErrorIs(t, lc.Verify(e), netx.ErrCacheChecksumMismatch)
+
+	require.NoError(t, lc.Remove(e))
+
+	entries, err = lc.List()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.NoFileExists(t, e.Path+".sha256")
+	assert.NoFileExists(t, e.Path+".meta.yaml")
+}
+
+func TestLocalCache_List_LegacyEntries(t *testing.T) {
+	t.Parallel()
+
+	cacheDir := t.TempDir()
+
+	require.NoError(t, createFakeDistroCache(cacheDir, "legacy"))
+
+	entries, err := netx.NewLocalCache(cacheDir).List()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	assert.Equal(t, "legacy", entries[0].Key)
+	assert.Empty(t, entries[0].URL)
+	assert.False(t, entries[0].LastUsed.IsZero())
+}
+
+func TestSelectPrunable(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	entry := func(key string, age time.Duration, size int64, distributions ...string) netx.CacheEntry {
+		return netx.CacheEntry{
+			Key:  key,
+			Size: size,
+			CacheMetadata: netx.CacheMetadata{
+				LastUsed:      now.Add(-age),
+				Distributions: distributions,
+			},
+		}
+	}
+
+	entries := []netx.CacheEntry{
+		entry("recent", time.Hour, 100, "v1.31.0"),
+		entry("old", 60*24*time.Hour, 100, "v1.31.0"),
+		entry("unreferenced", 2*time.Hour, 100),
+		entry("older", 3*time.Hour, 300, "v1.30.0"),
+	}
+
+	keys := func(es []netx.CacheEntry) []string {
+		ks := []string{}
+		for _, e := range es {
+			ks = append(ks, e.Key)
+		}
+
+		return ks
+	}
+
+	testCases := []struct {
+		desc string
+		opts netx.PruneOptions
+		want []string
+	}{
+		{
+			desc: "no criteria",
+			want: []string{},
+		},
+		{
+			desc: "older than",
+			opts: netx.PruneOptions{OlderThan: 30 * 24 * time.Hour},
+			want: []string{"old"},
+		},
+		{
+			desc: "unreferenced",
+			opts: netx.PruneOptions{Keep: func(e netx.CacheEntry) bool { return len(e.Distributions) > 0 }},
+			want: []string{"unreferenced"},
+		},
+		{
+			desc: "max size evicts the least recently used",
+			opts: netx.PruneOptions{MaxSize: 150},
+			want: []string{"old", "older", "unreferenced"},
+		},
+		{
+			desc: "max size after the other criteria",
+			opts: netx.PruneOptions{OlderThan: 30 * 24 * time.Hour, MaxSize: 400},
+			want: []string{"old", "older"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, keys(netx.SelectPrunable(entries, tc.opts, now)))
+		})
+	}
+}
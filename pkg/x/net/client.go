@@ -5,6 +5,7 @@
 package netx
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
@@ -13,16 +14,21 @@ import (
 	"path/filepath"
 	"regexp"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/sighupio/furyctl/internal/git"
 	iox "github.com/sighupio/furyctl/internal/x/io"
 )
 
+const checksumExt = ".sha256"
+
 var (
 	ErrCannotCacheDownload          = errors.New("cannot cache download")
 	ErrCannotCheckLocalCache        = errors.New("cannot check local cache")
 	ErrCannotGetKeyFromURL          = errors.New("cannot get key from url")
 	ErrCannotCopyCacheToDestination = errors.New("cannot copy cache to destination")
 	ErrCannotClearCache             = errors.New("cannot clear cache")
+	ErrCacheChecksumMismatch        = errors.New("cached content does not match its checksum")
 	URLPrefixRegexp                 = regexp.MustCompile(`^[A-z0-9]+::`)
 )
 
@@ -32,6 +38,32 @@ type Client interface {
 	ClearItem(src string) error
 }
 
+type contextClient interface {
+	DownloadContext(ctx context.Context, src, dst string) error
+}
+
+// IsOffline reports whether the client serves downloads without accessing the network, in that case callers must
+// not perform requests on their own, e.g. to check that a resource exists before downloading it.
+func IsOffline(c Client) bool {
+	oc, ok := c.(interface{ Offline() bool })
+
+	return ok && oc.Offline()
+}
+
+// DownloadContext downloads src in dst through c, stopping when ctx is done if the client supports it.
+func DownloadContext(ctx context.Context, c Client, src, dst string) error {
+	if err := ctx.Err(); err != nil {
+		return err //nolint:wrapcheck // Context errors are checked by the callers.
+	}
+
+	cc, ok := c.(contextClient)
+	if !ok {
+		return c.Download(src, dst) //nolint:wrapcheck // Errors are wrapped by the clients.
+	}
+
+	return cc.DownloadContext(ctx, src, dst) //nolint:wrapcheck // Errors are wrapped by the clients.
+}
+
 func WithLocalCache(c Client, dir string) Client {
 	return &LocalCacheClientDecorator{
 		client: c,
@@ -59,10 +91,20 @@ func (d *LocalCacheClientDecorator) ClearItem(src string) error {
 		return fmt.Errorf("%w: %w", ErrCannotClearCache, err)
 	}
 
+	for _, p := range []string{key + checksumExt, key + metadataExt} {
+		if err := os.RemoveAll(p); err != nil {
+			return fmt.Errorf("%w: %w", ErrCannotClearCache, err)
+		}
+	}
+
 	return nil
 }
 
 func (d *LocalCacheClientDecorator) Download(src, dst string) error {
+	return d.DownloadContext(context.Background(), src, dst)
+}
+
+func (d *LocalCacheClientDecorator) DownloadContext(ctx context.Context, src, dst string) error {
 	csrc := URLPrefixRegexp.ReplaceAllString(src, "")
 
 	hlc, err := d.hasLocalCache(csrc)
@@ -71,6 +113,14 @@ func (d *LocalCacheClientDecorator) Download(src, dst string) error {
 	}
 
 	if hlc {
+		if err := verifyCacheItem(d.getKeyFromURL(csrc)); err != nil {
+			return fmt.Errorf("%w: %w", ErrCannotCacheDownload, err)
+		}
+
+		if err := d.touchLocalCache(csrc); err != nil {
+			logrus.Debugf("Cannot update cache metadata of %s: %v", csrc, err)
+		}
+
 		if _, err := os.Stat(dst); err != nil {
 			return d.copyCacheToDestination(csrc, dst)
 		}
@@ -78,7 +128,7 @@ func (d *LocalCacheClientDecorator) Download(src, dst string) error {
 		return nil
 	}
 
-	if err := d.client.Download(src, dst); err != nil {
+	if err := DownloadContext(ctx, d.client, src, dst); err != nil {
 		return fmt.Errorf("%w: %w", ErrCannotCacheDownload, err)
 	}
 
@@ -86,29 +136,66 @@ func (d *LocalCacheClientDecorator) Download(src, dst string) error {
 		return fmt.Errorf("%w: %w", ErrCannotCacheDownload, err)
 	}
 
+	if err := d.writeLocalCacheChecksum(csrc); err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotCacheDownload, err)
+	}
+
+	if err := d.touchLocalCache(csrc); err != nil {
+		logrus.Debugf("Cannot write cache metadata of %s: %v", csrc, err)
+	}
+
 	return nil
 }
 
+// hasLocalCache reports whether src is in the cache. Items cached without a checksum cannot be trusted, so they
+// are reported as missing and downloaded again.
 func (d *LocalCacheClientDecorator) hasLocalCache(src string) (bool, error) {
 	key := d.getKeyFromURL(src)
 
-	if _, err := os.Stat(key); err != nil {
-		if os.IsNotExist(err) {
-			return false, nil
-		}
+	for _, p := range []string{key, key + checksumExt} {
+		if _, err := os.Stat(p); err != nil {
+			if os.IsNotExist(err) {
+				return false, nil
+			}
 
-		return false, fmt.Errorf("%w: %w", ErrCannotCheckLocalCache, err)
+			return false, fmt.Errorf("%w: %w", ErrCannotCheckLocalCache, err)
+		}
 	}
 
 	return true, nil
 }
 
+func (d *LocalCacheClientDecorator) writeLocalCacheChecksum(src string) error {
+	key := d.getKeyFromURL(src)
+
+	digest, err := iox.HashDir(key)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotCopyCacheToDestination, err)
+	}
+
+	if err := iox.WriteFile(key+checksumExt, []byte(digest+"\n")); err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotCopyCacheToDestination, err)
+	}
+
+	return nil
+}
+
 func (d *LocalCacheClientDecorator) getKeyFromURL(url string) string {
+	return filepath.Join(d.dir, keyFromURL(url))
+}
+
+// Offline reports whether the decorated client serves downloads without accessing the network.
+func (d *LocalCacheClientDecorator) Offline() bool {
+	return IsOffline(d.client)
+}
+
+// keyFromURL returns a key that identifies the content of url regardless of the git protocol used to get it.
+func keyFromURL(url string) string {
 	cleanURL := git.CleanupRepoURL(url)
 
 	urlSum := sha256.Sum256([]byte(cleanURL))
 
-	return filepath.Join(d.dir, hex.EncodeToString(urlSum[:]))
+	return hex.EncodeToString(urlSum[:])
 }
 
 func (d *LocalCacheClientDecorator) copyCacheToDestination(cacheFolder, destFolder string) error {
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/sighupio/furyctl/internal/test"
+	iox "github.com/sighupio/furyctl/internal/x/io"
 	netx "github.com/sighupio/furyctl/pkg/x/net"
 )
 
@@ -97,6 +98,7 @@ func TestLocalCacheClientDecorator_Download_ColdCache(t *testing.T) {
 			// Check the files have been cached.
 			assert.FileExists(t, filepath.Join(cacheDir, tC.shasum, "kfd.yaml"))
 			assert.FileExists(t, filepath.Join(cacheDir, tC.shasum, "README.md"))
+			assert.FileExists(t, filepath.Join(cacheDir, tC.shasum+".sha256"))
 		})
 	}
 }
@@ -141,7 +143,7 @@ func TestLocalCacheClientDecorator_Download_WarmCache(t *testing.T) {
 			c := netx.WithLocalCache(NewFakeClient(), cacheDir)
 
 			// Warm up the cache.
-			if err := createFakeDistroDst(filepath.Join(cacheDir, tC.shasum)); err != nil {
+			if err := createFakeDistroCache(cacheDir, tC.shasum); err != nil {
 				t.Fatal(err)
 			}
 
@@ -161,6 +163,48 @@ func TestLocalCacheClientDecorator_Download_WarmCache(t *testing.T) {
 	}
 }
 
+func TestLocalCacheClientDecorator_Download_TamperedCache(t *testing.T) {
+	t.Parallel()
+
+	shasum := "25ea7ee9d13d1843dfbeff40948be729af77a30503a6681a1d8293c746de527f"
+
+	cacheDir := t.TempDir()
+	dst := filepath.Join(t.TempDir(), "data")
+
+	if err := createFakeDistroCache(cacheDir, shasum); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(cacheDir, shasum, "kfd.yaml"), []byte("tampered"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := netx.WithLocalCache(NewFakeClient(), cacheDir)
+
+	err := c.Download(distroHTTPSURL, dst)
+
+	test.AssertErrorIs(t, err, netx.ErrCacheChecksumMismatch)
+
+	assert.NoDirExists(t, dst)
+}
+
+func createFakeDistroCache(cacheDir, key string) error {
+	if err := createFakeDistroDst(filepath.Join(cacheDir, key)); err != nil {
+		return err
+	}
+
+	digest, err := iox.HashDir(filepath.Join(cacheDir, key))
+	if err != nil {
+		return fmt.Errorf("%w: %w", errCannotCreateFakeDistroDstFolder, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(cacheDir, key+".sha256"), []byte(digest+"\n"), 0o644); err != nil {
+		return fmt.Errorf("%w: %w", errCannotCreateFakeDistroDstFolder, err)
+	}
+
+	return nil
+}
+
 func createFakeDistroDst(dst string) error {
 	if err := os.MkdirAll(dst, 0o755); err != nil {
 		return fmt.Errorf("%w: %w", errCannotCreateFakeDistroDstFolder, err)
@@ -5,6 +5,7 @@
 package netx
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -12,10 +13,14 @@ import (
 	"github.com/hashicorp/go-getter"
 	"github.com/sirupsen/logrus"
 
+	"github.com/sighupio/furyctl/internal/mirror"
 	gogetterx "github.com/sighupio/furyctl/internal/x/go-getter"
 )
 
-var ErrDownloadOptionsExhausted = errors.New("downloading options exhausted")
+var (
+	ErrDownloadOptionsExhausted = errors.New("downloading options exhausted")
+	ErrChecksumMismatch         = errors.New("downloaded file does not match its checksum")
+)
 
 func NewGoGetterClient() *GoGetterClient {
 	return &GoGetterClient{
@@ -36,6 +41,14 @@ func (*GoGetterClient) ClearItem(_ string) error {
 }
 
 func (g *GoGetterClient) Download(src, dst string) error {
+	return g.DownloadContext(context.Background(), src, dst)
+}
+
+// DownloadContext downloads src in dst, trying all the supported protocols unless src forces one. The error of the
+// first protocol, the one detected by go-getter, is reported when all of them fail.
+func (g *GoGetterClient) DownloadContext(ctx context.Context, src, dst string) error {
+	var firstErr error
+
 	protocols := []string{""}
 	if !g.URLHasForcedProtocol(src) {
 		protocols = g.protocols
@@ -46,7 +59,23 @@ func (g *GoGetterClient) Download(src, dst string) error {
 
 		logrus.Debugf("Downloading '%s' in '%s'", fullSrc, dst)
 
+		header, err := mirror.Default.Authenticate(fullSrc)
+		if err != nil {
+			return fmt.Errorf("error while authenticating '%s': %w", fullSrc, err)
+		}
+
+		gitEnv, err := mirror.Default.GitEnv(fullSrc)
+		if err != nil {
+			return fmt.Errorf("error while authenticating '%s': %w", fullSrc, err)
+		}
+
+		var gitGetter getter.Getter = new(getter.GitGetter)
+		if gitEnv != nil {
+			gitGetter = &gogetterx.GitGetter{Env: gitEnv}
+		}
+
 		client := &getter.Client{
+			Ctx:  ctx,
 			Src:  fullSrc,
 			Dst:  dst,
 			Mode: getter.ClientModeAny,
@@ -54,29 +83,45 @@ func (g *GoGetterClient) Download(src, dst string) error {
 				"file": &gogetterx.FileGetter{
 					Copy: true,
 				},
-				"git": new(getter.GitGetter),
+				"git": gitGetter,
 				"gcs": new(getter.GCSGetter),
 				"hg":  new(getter.HgGetter),
 				"s3":  new(getter.S3Getter),
 				"http": &getter.HttpGetter{
-					Netrc: true,
+					Netrc:  true,
+					Header: header,
 				},
 				"https": &getter.HttpGetter{
-					Netrc: true,
+					Netrc:  true,
+					Header: header,
 				},
 			},
 			DisableSymlinks: false,
 		}
 
-		err := client.Get()
+		err = client.Get()
 		if err == nil {
 			return nil
 		}
 
+		// A file that does not match its checksum must not be retried with other protocols.
+		var cerr *getter.ChecksumError
+		if errors.As(err, &cerr) {
+			return fmt.Errorf("%w: %w", ErrChecksumMismatch, err)
+		}
+
+		if ctx.Err() != nil {
+			return fmt.Errorf("error while downloading '%s': %w", src, ctx.Err())
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+
 		logrus.Debug(err)
 	}
 
-	return ErrDownloadOptionsExhausted
+	return fmt.Errorf("%w: %w", ErrDownloadOptionsExhausted, firstErr)
 }
 
 // URLHasForcedProtocol checks if the url has a forced protocol as described in hashicorp/go-getter.
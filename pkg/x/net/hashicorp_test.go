@@ -7,8 +7,10 @@
 package netx_test
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	netx "github.com/sighupio/furyctl/pkg/x/net"
@@ -51,6 +53,30 @@ func Test_GoGetterClient_Download(t *testing.T) {
 	}
 }
 
+func Test_GoGetterClient_Download_ChecksumMismatch(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	src := filepath.Join(tmpDir, "test.txt")
+
+	if err := os.WriteFile(src, []byte("test"), 0o644); err != nil {
+		t.Fatalf("error creating temp input file: %v", err)
+	}
+
+	// The sha256 of "test".
+	sum := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	if err := netx.NewGoGetterClient().Download(src+"?checksum=sha256:"+sum, filepath.Join(tmpDir, "ok")); err != nil {
+		t.Fatalf("error getting file with a valid checksum: %v", err)
+	}
+
+	err := netx.NewGoGetterClient().Download(src+"?checksum=sha256:"+strings.Repeat("0", len(sum)), filepath.Join(tmpDir, "ko"))
+	if !errors.Is(err, netx.ErrChecksumMismatch) {
+		t.Fatalf("expected checksum mismatch error, got: %v", err)
+	}
+}
+
 func TestUrlHasForcedProtocol(t *testing.T) {
 	t.Parallel()
 
@@ -289,8 +289,7 @@ var (
 				Expect(out).To(ContainSubstring("kubectl:"))
 				Expect(out).To(ContainSubstring("kustomize:"))
 				Expect(out).To(ContainSubstring("furyagent:"))
-				Expect(out).To(ContainSubstring("missing environment variables, either AWS_PROFILE or the " +
-					"following environment variables must be set: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY"))
+				Expect(out).To(ContainSubstring("cannot resolve AWS credentials"))
 			})
 
 			It("should report an error when dependencies are wrong", Serial, func() {
@@ -326,8 +325,7 @@ var (
 				Expect(out).To(
 					ContainSubstring("terraform: wrong tool version - installed = 0.15.3, expected = 0.15.4"),
 				)
-				Expect(out).To(ContainSubstring("missing environment variables, either AWS_PROFILE or the " +
-					"following environment variables must be set: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY"))
+				Expect(out).To(ContainSubstring("cannot resolve AWS credentials"))
 			})
 
 			It("should exit without errors when dependencies are correct", Serial, func() {